@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ComponentCmd represents the parent component command.
+var ComponentCmd = &cobra.Command{
+	Use:   "component",
+	Short: "Discover the components available to CRIB-SDK Plans",
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		cmd.SilenceUsage = true
+	},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(ComponentCmd)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
+)
+
+// componentDescribeCmd represents the component describe command.
+var componentDescribeCmd = &cobra.Command{
+	Use:   "describe <name>",
+	Short: "Describe the props of a registered component",
+	Long: `Describe prints every field of the props of a registered component, with its type,
+its default value and its validation rules, as declared by the default and validate struct tags.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := cribctl.LookupComponent(args[0])
+		if err != nil {
+			return err
+		}
+		return cribctl.WriteComponentDescription(cmd.OutOrStdout(), c)
+	},
+}
+
+func init() {
+	ComponentCmd.AddCommand(componentDescribeCmd)
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
+)
+
+// componentListCmd represents the component list command.
+var componentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the registered components",
+	Long: `List the scalar and composite components that are registered with cribctl, with their
+version and a short description. Registered components can be used by name in YAML plan files.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cribctl.WriteComponentList(cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	ComponentCmd.AddCommand(componentListCmd)
+}
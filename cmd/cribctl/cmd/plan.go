@@ -1,13 +1,30 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/smartcontractkit/crib-sdk/contrib"
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/planstate"
+	"github.com/smartcontractkit/crib-sdk/internal/core/service"
 )
 
-var planFh *filehandler.Handler
+// paramEnvPrefix is the prefix of environment variables that set plan parameters, e.g. CRIB_PARAM_NODES.
+const paramEnvPrefix = "CRIB_PARAM_"
+
+var (
+	planFh    *filehandler.Handler
+	planStore *planstate.FileStore
+	// filePlan is the name of the plan loaded from the --file flag, if any.
+	filePlan string
+)
 
 // PlanCmd represents the plan command.
 var PlanCmd = &cobra.Command{
@@ -25,7 +42,15 @@ var PlanCmd = &cobra.Command{
 			}
 		}
 		createFh()
-		return err
+		if err != nil {
+			return err
+		}
+		// Records of applied plans are kept alongside the cribctl configuration.
+		planStore, err = planstate.NewFileStore(ctx, filepath.Join(configDirectory(), "state"))
+		if err != nil {
+			return err
+		}
+		return loadPlanFile(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
@@ -47,4 +72,68 @@ func init() {
 
 	// Flag to allow overriding the render directory for plan commands.
 	PlanCmd.PersistentFlags().String("render-dir", "", "Directory to render manifests to - defaults to system temp directory")
+	// Flag to read the plan from a YAML plan file instead of the plan registry.
+	PlanCmd.PersistentFlags().StringP("file", "f", "", "Read the plan from a YAML plan file instead of naming a registered plan")
+	// Flag to set plan parameters, may be repeated.
+	PlanCmd.PersistentFlags().StringArray("set", nil, "Set a plan parameter, e.g. --set nodes=6 (can be repeated)")
+	// Flag to apply Kubernetes resources through the Kubernetes API instead of kubectl.
+	PlanCmd.PersistentFlags().String("applier", cribctl.ApplierKubectl,
+		"How Kubernetes resources are applied and deleted: kubectl, or native for server-side apply through the Kubernetes API")
+}
+
+// loadPlanFile loads the plan file given with the --file flag, if any, and registers the plan so that
+// it can be used by name like any other plan.
+func loadPlanFile(cmd *cobra.Command) error {
+	path, err := cmd.Flags().GetString("file")
+	if err != nil || path == "" {
+		return err
+	}
+	plan, err := contrib.LoadPlanFile(cmd.Context(), path)
+	if err != nil {
+		return err
+	}
+	if err := contrib.RegisterPlan(plan.Plan()); err != nil {
+		return fmt.Errorf("loading plan file %s: %w", path, err)
+	}
+	filePlan = plan.Name()
+	return nil
+}
+
+// planArg returns the name of the plan that the command operates on: the plan loaded from the
+// --file flag, or the plan named by the first argument.
+func planArg(args []string) string {
+	if filePlan != "" {
+		return filePlan
+	}
+	return args[0]
+}
+
+// planParams collects the values of the parameters of the named plan. Values are read from the "params"
+// section of the config file, from CRIB_PARAM_<NAME> environment variables, and from --set flags, in
+// increasing order of precedence.
+func planParams(cmd *cobra.Command, name string) (service.PlanServiceOpt, error) {
+	values := make(map[string]any)
+	env := strings.NewReplacer("-", "_", ".", "_")
+	for _, param := range cribctl.PlanParams(name) {
+		if v := viper.Get("params." + param.Name()); v != nil {
+			values[param.Name()] = v
+		}
+		if v, ok := os.LookupEnv(paramEnvPrefix + strings.ToUpper(env.Replace(param.Name()))); ok {
+			values[param.Name()] = v
+		}
+	}
+
+	// --set is read from the flag directly, as viper splits the values of string arrays on commas.
+	set, err := cmd.Flags().GetStringArray("set")
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range set {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid --set %q, expected name=value", kv)
+		}
+		values[k] = v
+	}
+	return service.WithParams(values), nil
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/service"
 )
 
 // applyCmd represents the apply command.
@@ -16,31 +18,62 @@ var applyCmd = &cobra.Command{
 	Short: "Apply a CRIB-SDK Plan",
 	Long: `Apply a CRIB-SDK Plan to the target cluster. 
 	
-The command will first show a preview of the plan's DAG structure, then prompt for confirmation before applying.`,
+The command will first show a preview of the plan's DAG structure, then prompt for confirmation before applying.
+
+Instead of naming a registered plan, the plan can be read from a YAML plan file:
+
+	cribctl plan apply -f sandbox.yaml
+
+Part of a plan can be applied by targeting or skipping components and child plans. Components are
+selected by ID, optionally narrowed to the component containing a given name, and child plans by name:
+
+	cribctl plan apply my-plan --target sdk.HelmChart#anvil --target plan:chainlink-jd --skip sdk.ClientSideApply
+
+With --with-dependencies, the components that the targets depend on are applied as well.
+
+Every rendered resource is labelled with crib.sdk/plan and crib.sdk/component. With --prune, resources
+labelled with the plan that are no longer rendered, e.g. because their component was removed from the
+plan, are deleted once every bundle was applied. Namespaces are never pruned.
+
+Kubernetes resources are applied with kubectl by default. With --applier native, they are applied with
+server-side apply through the Kubernetes API instead, using the current context of the kubeconfig, and
+each object is reported as created, configured or unchanged.
+
+Wait steps, e.g. for the pods of a Helm chart to be ready, time out after 10 minutes and poll every 2
+seconds unless they set their own timeout and interval. The defaults can be changed for a plan with
+crib.WaitDefaults, and overridden with --wait-timeout and --wait-interval.
+
+The output of each bundle is written to its own log file in the logs directory of the render directory.
+The console shows a line per started and finished bundle, with the last lines of the output of failed
+bundles (see --log-tail). With -v, the output of the commands is streamed as well. With --log-format json,
+each started and finished bundle is written as a line of JSON, e.g. for CI.`,
 	Args: cribctl.ValidatePlanArgs("apply"),
-	Run: func(cmd *cobra.Command, args []string) {
-		planName := args[0]
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Errors past this point are not usage errors.
+		cmd.SilenceUsage = true
+		planName := planArg(args)
 		autoAccept := viper.GetBool("yes")
+		params, err := planParams(cmd, planName)
+		if err != nil {
+			return err
+		}
 
 		// Show preview first
 		if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Previewing plan %q...\n\n", planName); err != nil {
-			return
+			return err
 		}
-		preview, _, err := cribctl.PreviewPlan(cmd.Context(), planFh, planName)
+		preview, _, err := cribctl.PreviewPlan(cmd.Context(), planFh, planName, params)
 		if err != nil {
-			if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Error previewing plan: %v\n", err); err != nil {
-				return
-			}
-			return
+			return fmt.Errorf("previewing plan: %w", err)
 		}
 		if _, err := fmt.Fprintln(cmd.ErrOrStderr(), preview); err != nil {
-			return
+			return err
 		}
 
 		// If auto-accept is enabled, skip confirmation
 		if autoAccept {
 			if _, err := fmt.Fprintln(cmd.ErrOrStderr(), "\nAuto-accepting (--yes flag provided)..."); err != nil {
-				return
+				return err
 			}
 		} else {
 			// Prompt for confirmation
@@ -53,32 +86,77 @@ The command will first show a preview of the plan's DAG structure, then prompt f
 				Value(&confirmed)
 
 			if err := confirm.Run(); err != nil {
-				if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Error during confirmation: %v\n", err); err != nil {
-					return
-				}
-				return
+				return fmt.Errorf("during confirmation: %w", err)
 			}
 			if !confirmed {
-				if _, err := fmt.Fprintln(cmd.ErrOrStderr(), "Plan application cancelled."); err != nil {
-					return
-				}
-				return
+				_, err := fmt.Fprintln(cmd.ErrOrStderr(), "Plan application cancelled.")
+				return err
 			}
 		}
 
 		// Apply the plan
 		if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "\nApplying plan %q...\n", planName); err != nil {
-			return
+			return err
+		}
+		var opts []service.ApplyOpt
+		if viper.GetBool("resume") {
+			opts = append(opts, service.WithResume())
+		}
+		if from := viper.GetString("from"); from != "" {
+			opts = append(opts, service.WithFrom(from))
+		}
+		if to := viper.GetString("to"); to != "" {
+			opts = append(opts, service.WithTo(to))
 		}
-		if err := cribctl.ApplyPlan(cmd.Context(), planFh, planName); err != nil {
-			if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Error applying plan: %v\n", err); err != nil {
-				return
+		targets, err := cmd.Flags().GetStringArray("target")
+		if err != nil {
+			return err
+		}
+		skip, err := cmd.Flags().GetStringArray("skip")
+		if err != nil {
+			return err
+		}
+		opts = append(opts, service.WithTargets(targets...), service.WithSkip(skip...))
+		if viper.GetBool("with-dependencies") {
+			opts = append(opts, service.WithDependencies())
+		}
+		if viper.GetBool("prune") {
+			opts = append(opts, service.WithPrune())
+		}
+		applier, err := cribctl.PlanApplier(viper.GetString("applier"))
+		if err != nil {
+			return err
+		}
+		progress, err := cribctl.NewApplyProgress(cmd.ErrOrStderr(), viper.GetString("log-format"), viper.GetInt("log-tail"))
+		if err != nil {
+			return err
+		}
+		svcOpts := []service.PlanServiceOpt{
+			params, applier,
+			service.WithProgress(progress),
+			service.WithConcurrency(viper.GetInt("concurrency")),
+			service.WithWaitDefaults(domain.WaitDefaults{
+				Timeout:  viper.GetDuration("wait-timeout"),
+				Interval: viper.GetDuration("wait-interval"),
+			}),
+		}
+		if !viper.GetBool("verbose") {
+			svcOpts = append(svcOpts, service.WithQuietOutput())
+		}
+		report, err := cribctl.ApplyPlan(cmd.Context(), planFh, planStore, planName, svcOpts, opts...)
+		if len(report) > 0 && viper.GetString("log-format") != cribctl.LogFormatJSON {
+			if _, err := fmt.Fprintln(cmd.ErrOrStderr()); err != nil {
+				return err
+			}
+			if err := cribctl.WriteApplyReport(cmd.ErrOrStderr(), report); err != nil {
+				return err
 			}
-			return
 		}
-		if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Successfully applied plan: %s\n", planName); err != nil {
-			return
+		if err != nil {
+			return fmt.Errorf("applying plan: %w", err)
 		}
+		_, err = fmt.Fprintf(cmd.ErrOrStderr(), "Successfully applied plan: %s\n", planName)
+		return err
 	},
 }
 
@@ -87,6 +165,25 @@ func init() {
 
 	// Add the -y/--yes flag for auto-accepting
 	applyCmd.Flags().BoolP("yes", "y", false, "Auto-accept the confirmation prompt")
+	// Add the --concurrency flag for applying independent bundles at the same time
+	applyCmd.Flags().Int("concurrency", 1, "Maximum number of independent manifest bundles to apply at the same time")
+	// Add the --resume, --from and --to flags for rerunning part of a plan
+	applyCmd.Flags().Bool("resume", false, "Skip bundles that succeeded with the same content in the last apply of the plan")
+	applyCmd.Flags().String("from", "", "Skip bundles before the first bundle matching this key or directory")
+	applyCmd.Flags().String("to", "", "Skip bundles after the last bundle matching this key or directory")
+	// Add the --target, --skip and --with-dependencies flags for applying selected components
+	applyCmd.Flags().StringArray("target", nil, "Only apply components matching this ID, e.g. sdk.HelmChart#anvil or plan:chainlink-jd (can be repeated)")
+	applyCmd.Flags().StringArray("skip", nil, "Skip components matching this ID, e.g. sdk.ClientSideApply (can be repeated)")
+	applyCmd.Flags().Bool("with-dependencies", false, "Also apply the components that the targets depend on")
+	// Add the --prune flag for deleting resources that were removed from the plan
+	applyCmd.Flags().Bool("prune", false, "Delete resources labelled with the plan that are no longer rendered by it")
+	// Add the --wait-timeout and --wait-interval flags for overriding the wait defaults of the plan
+	applyCmd.Flags().Duration("wait-timeout", 0, "Timeout of the wait steps that do not set their own (default: the plan's, or 10m)")
+	applyCmd.Flags().Duration("wait-interval", 0, "Poll interval of the wait steps that do not set their own (default: the plan's, or 2s)")
+	// Add the -v/--verbose, --log-format and --log-tail flags for the output of the apply
+	applyCmd.Flags().BoolP("verbose", "v", false, "Stream the output of the commands run by the bundles")
+	applyCmd.Flags().String("log-format", cribctl.LogFormatText, "Format of the progress of the apply: text, or json for a JSON event per line")
+	applyCmd.Flags().Int("log-tail", cribctl.DefaultTailLines, "Number of trailing lines of the output of a failed bundle to show")
 
 	// Here you will define your flags and configuration settings.
 
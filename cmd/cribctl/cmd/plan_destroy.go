@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
+)
+
+var errDestroyCancelled = errors.New("plan destruction cancelled")
+
+// destroyCmd represents the destroy command.
+var destroyCmd = &cobra.Command{
+	Use:   "destroy",
+	Short: "Destroy a CRIB-SDK Plan",
+	Long: `Destroy tears down everything a CRIB-SDK Plan applied to the target cluster.
+
+The plan is rendered again and its manifests are processed in reverse order. Kubernetes
+resources are deleted, and ClientSideApply steps that declare an undo step are undone, for
+example deleting the kind cluster created by a bootstrap plan.
+
+The command will first show a preview of the plan's DAG structure, then prompt for confirmation before destroying.`,
+	Args: cribctl.ValidatePlanArgs("destroy"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Errors past this point are not usage errors.
+		cmd.SilenceUsage = true
+		planName := planArg(args)
+		autoAccept := viper.GetBool("yes")
+		params, err := planParams(cmd, planName)
+		if err != nil {
+			return err
+		}
+
+		// Show preview first
+		if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Previewing plan %q...\n\n", planName); err != nil {
+			return err
+		}
+		preview, _, err := cribctl.PreviewPlan(cmd.Context(), planFh, planName, params)
+		if err != nil {
+			return fmt.Errorf("previewing plan: %w", err)
+		}
+		if _, err := fmt.Fprintln(cmd.ErrOrStderr(), preview); err != nil {
+			return err
+		}
+
+		// If auto-accept is enabled, skip confirmation
+		if autoAccept {
+			if _, err := fmt.Fprintln(cmd.ErrOrStderr(), "\nAuto-accepting (--yes flag provided)..."); err != nil {
+				return err
+			}
+		} else {
+			// Prompt for confirmation
+			var confirmed bool
+			confirm := huh.NewConfirm().
+				Title("Destroy this plan?").
+				Description(fmt.Sprintf("This will delete everything plan %q applied to the target cluster.", planName)).
+				Affirmative("Yes, destroy").
+				Negative("No, cancel").
+				Value(&confirmed)
+
+			if err := confirm.Run(); err != nil {
+				return fmt.Errorf("during confirmation: %w", err)
+			}
+			if !confirmed {
+				return errDestroyCancelled
+			}
+		}
+
+		// Destroy the plan
+		applier, err := cribctl.PlanApplier(viper.GetString("applier"))
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "\nDestroying plan %q...\n", planName); err != nil {
+			return err
+		}
+		if err := cribctl.DestroyPlan(cmd.Context(), planFh, planStore, planName, params, applier); err != nil {
+			return fmt.Errorf("destroying plan: %w", err)
+		}
+		_, err = fmt.Fprintf(cmd.ErrOrStderr(), "Successfully destroyed plan: %s\n", planName)
+		return err
+	},
+}
+
+func init() {
+	PlanCmd.AddCommand(destroyCmd)
+
+	// Add the -y/--yes flag for auto-accepting
+	destroyCmd.Flags().BoolP("yes", "y", false, "Auto-accept the confirmation prompt")
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+var errPlanDrifted = errors.New("plan has drifted")
+
+// driftCmd represents the drift command.
+var driftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Detect drift of an applied CRIB-SDK Plan",
+	Long: `Drift renders a CRIB-SDK Plan again and compares it against the record of its last apply.
+
+Bundles that were added, removed or changed since the plan was applied are reported, as well as
+Helm charts that now resolve to a different version. The rendered Kubernetes resources are also
+compared against the live objects in the target cluster using kubectl diff.
+
+The command exits with a non-zero status when drift is detected.`,
+	Args: cribctl.ValidatePlanArgs("drift"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Errors past this point are not usage errors.
+		cmd.SilenceUsage = true
+		planName := planArg(args)
+
+		params, err := planParams(cmd, planName)
+		if err != nil {
+			return err
+		}
+		drift, err := cribctl.DriftPlan(cmd.Context(), planFh, planStore, planName, params)
+		if errors.Is(err, domain.ErrPlanRecordNotFound) {
+			return fmt.Errorf("plan %q has not been applied", planName)
+		}
+		if err != nil {
+			return fmt.Errorf("detecting drift: %w", err)
+		}
+		if err := cribctl.WritePlanDrift(cmd.OutOrStdout(), planName, drift); err != nil {
+			return err
+		}
+		if drift.HasDrift() {
+			return errPlanDrifted
+		}
+		return nil
+	},
+}
+
+func init() {
+	PlanCmd.AddCommand(driftCmd)
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 )
 
 // previewCmd represents the preview command.
@@ -17,17 +20,47 @@ var previewCmd = &cobra.Command{
 without applying it to the cluster. This is useful for debugging and understanding
 the dependency relationships between components in the plan.
 
+The preview covers the full construct tree of the plan, showing the kind, namespace
+and rendered resource of every node, and the dependencies declared between them.
+With --output json, dot or mermaid, the graph is written to stdout in that format,
+e.g. to render it with Graphviz:
+
+	cribctl plan preview my-plan -o dot | dot -Tsvg > plan.svg
+
 When the --render-dir flag is provided, the generated Kubernetes manifests will be
 written to the specified directory instead of the default temporary location.`,
 	Args: cribctl.ValidatePlanArgs("preview"),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Preview the plan using the unified function
-		preview, outputDir, err := cribctl.PreviewPlan(cmd.Context(), planFh, args[0])
+		planName := planArg(args)
+		format := viper.GetString("output")
+		if !slices.Contains(cribctl.PreviewFormats, format) {
+			return fmt.Errorf("unsupported output format %q, expected one of %s", format, strings.Join(cribctl.PreviewFormats, ", "))
+		}
+		params, err := planParams(cmd, planName)
 		if err != nil {
-			return fmt.Errorf("previewing plan: %w", err)
+			return err
 		}
-		if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Plan DAG Preview for %s:\n\n%s\n", args[0], preview); err != nil {
-			return fmt.Errorf("writing preview output: %w", err)
+
+		var outputDir string
+		if format == cribctl.PreviewFormatTree {
+			// Preview the plan using the unified function
+			var preview string
+			preview, outputDir, err = cribctl.PreviewPlan(cmd.Context(), planFh, planName, params)
+			if err != nil {
+				return fmt.Errorf("previewing plan: %w", err)
+			}
+			if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "Plan DAG Preview for %s:\n\n%s\n", planName, preview); err != nil {
+				return fmt.Errorf("writing preview output: %w", err)
+			}
+		} else {
+			var graph *domain.PlanGraph
+			graph, outputDir, err = cribctl.PreviewPlanGraph(cmd.Context(), planFh, planName, params)
+			if err != nil {
+				return fmt.Errorf("previewing plan: %w", err)
+			}
+			if err := cribctl.WritePlanGraph(cmd.OutOrStdout(), graph, format); err != nil {
+				return fmt.Errorf("writing preview output: %w", err)
+			}
 		}
 
 		if viper.IsSet("render-dir") {
@@ -41,4 +74,6 @@ written to the specified directory instead of the default temporary location.`,
 
 func init() {
 	PlanCmd.AddCommand(previewCmd)
+
+	previewCmd.Flags().StringP("output", "o", cribctl.PreviewFormatTree, "Output format: "+strings.Join(cribctl.PreviewFormats, ", "))
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// statusCmd represents the status command.
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the recorded state of an applied CRIB-SDK Plan",
+	Long: `Status shows the record kept by cribctl of the last time a CRIB-SDK Plan was applied.
+
+The record includes the manifest bundles of the plan and the outcome of applying each of them,
+as well as the Helm chart versions resolved while rendering the plan. Records are stored in
+$HOME/.cribctl/state, one per plan and namespace, and are removed when the plan is destroyed.`,
+	Args: cribctl.ValidatePlanArgs("status"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Errors past this point are not usage errors.
+		cmd.SilenceUsage = true
+		planName := planArg(args)
+		record, err := planStore.Load(cmd.Context(), planName, cribctl.PlanNamespace(planName))
+		if errors.Is(err, domain.ErrPlanRecordNotFound) {
+			return fmt.Errorf("plan %q has not been applied", planName)
+		}
+		if err != nil {
+			return fmt.Errorf("loading plan record: %w", err)
+		}
+		return cribctl.WritePlanStatus(cmd.OutOrStdout(), record)
+	},
+}
+
+func init() {
+	PlanCmd.AddCommand(statusCmd)
+}
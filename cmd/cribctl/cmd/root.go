@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 )
 
 var cfgFile string
@@ -43,7 +44,13 @@ to quickly create a Cobra application.`,
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute(ctx context.Context) {
-	if err := RootCmd.ExecuteContext(ctx); err != nil {
+	// Secrets registered while rendering plans are masked in everything the commands write, errors included.
+	stdout, stderr := domain.NewRedactWriter(os.Stdout), domain.NewRedactWriter(os.Stderr)
+	RootCmd.SetOut(stdout)
+	RootCmd.SetErr(stderr)
+	err := RootCmd.ExecuteContext(ctx)
+	_, _ = stdout.Flush(), stderr.Flush()
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/cribctl"
+)
+
+// schemaCmd represents the schema command.
+var schemaCmd = &cobra.Command{
+	Use:   "schema [component]",
+	Short: "Print the JSON Schema of plan files or of the props of a component",
+	Long: `Schema prints a JSON Schema generated from the Go types of CRIB-SDK. Without arguments it
+prints the schema of YAML plan files, which includes the schema of the props of every registered
+component. With the name of a registered component it prints the schema of the props of that component.
+
+The schemas translate the validate and default struct tags of the props, so that editors can complete
+and check plan files, e.g. with a modeline in the plan file:
+
+	# yaml-language-server: $schema=<path to plan.json>
+
+With --output-dir, every schema is written to a file in the directory instead.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		if dir := viper.GetString("output-dir"); dir != "" {
+			if err := cribctl.WriteSchemas(dir); err != nil {
+				return fmt.Errorf("writing schemas: %w", err)
+			}
+			_, err := fmt.Fprintf(cmd.ErrOrStderr(), "Schemas written to: %s\n", dir)
+			return err
+		}
+		name := cribctl.PlanSchemaName
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return cribctl.WriteSchema(cmd.OutOrStdout(), name)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.Flags().String("output-dir", "", "Write every schema to a file in this directory")
+}
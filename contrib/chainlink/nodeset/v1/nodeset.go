@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/smartcontractkit/crib-sdk/crib"
@@ -15,8 +16,14 @@ const (
 )
 
 // Plan is a sample CRIB-SDK Plan demonstrating how to use the nodeset v1 component
-// to create multiple Chainlink nodes with a shared PostgreSQL database.
+// to create multiple Chainlink nodes with a shared PostgreSQL database. The number of
+// nodes is set with the "nodes" parameter, e.g. `cribctl plan apply chainlink-nodesetv1 --set nodes=3`.
 func Plan() *crib.Plan {
+	nodes := crib.Param[int]("nodes", 5,
+		crib.ParamUsage("Number of Chainlink nodes"),
+		crib.ParamValidate("min=1"),
+	)
+
 	config := dry.RemoveIndentation(`
 					[Database]
 					MaxIdleConns = 20
@@ -53,32 +60,35 @@ func Plan() *crib.Plan {
 					HTTPURL = 'http://anvil-1337:8545'
 				`)
 
-	propsSlice := make([]*chainlinknodev1.Props, 0)
-	for i := 0; i < 5; i++ {
-		props := &chainlinknodev1.Props{
-			// Namespace is intentionally left empty - nodeset component will set it
-			Image:           "localhost:5001/chainlink:nightly-20250624-plugins",
-			AppInstanceName: fmt.Sprintf("%s-%d", "chainlink", i),
-			// passing as config not as override
-			Config: config,
-			// todo test with secret overrides
-			// SecretsOverrides: map[string]string{
-			//	"overrides": *secrets,
-			// },
-		}
-		propsSlice = append(propsSlice, props)
-	}
-
 	return crib.NewPlan(
 		"chainlink-nodesetv1",
 		crib.Namespace(cribNamespace),
+		crib.Params(nodes),
 		crib.ComponentSet(
-			// NodeSet component that creates multiple Chainlink nodes with shared PostgreSQL
-			nodesetv1.Component(&nodesetv1.Props{
-				Namespace: cribNamespace,
-				Size:      len(propsSlice), // Create 3 Chainlink nodes
-				NodeProps: propsSlice,
-			}),
+			// NodeSet component that creates multiple Chainlink nodes with shared PostgreSQL.
+			// The component is created once the value of the nodes parameter is known.
+			func(ctx context.Context) (crib.Component, error) {
+				propsSlice := make([]*chainlinknodev1.Props, 0)
+				for i := range nodes.Value(ctx) {
+					props := &chainlinknodev1.Props{
+						// Namespace is intentionally left empty - nodeset component will set it
+						Image:           "localhost:5001/chainlink:nightly-20250624-plugins",
+						AppInstanceName: fmt.Sprintf("%s-%d", "chainlink", i),
+						// passing as config not as override
+						Config: config,
+						// todo test with secret overrides
+						// SecretsOverrides: map[string]string{
+						//	"overrides": *secrets,
+						// },
+					}
+					propsSlice = append(propsSlice, props)
+				}
+				return nodesetv1.Component(&nodesetv1.Props{
+					Namespace: cribNamespace,
+					Size:      len(propsSlice),
+					NodeProps: propsSlice,
+				})(ctx)
+			},
 		),
 	)
 }
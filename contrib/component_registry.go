@@ -0,0 +1,106 @@
+package contrib
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/smartcontractkit/crib-sdk/crib"
+
+	anvilv1 "github.com/smartcontractkit/crib-sdk/crib/composite/blockchain/anvil/v1"
+	jdv1 "github.com/smartcontractkit/crib-sdk/crib/composite/chainlink/jd/v1"
+	chainlinknodev1 "github.com/smartcontractkit/crib-sdk/crib/composite/chainlink/node/v1"
+	nodesetv1 "github.com/smartcontractkit/crib-sdk/crib/composite/chainlink/nodeset/v1"
+	telepresencev1 "github.com/smartcontractkit/crib-sdk/crib/composite/cluster-services/telepresence/v1"
+	clientsideapplyv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
+	helmchartv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/helmchart/v1"
+	namespacev1 "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/namespace/v1"
+	waitv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
+)
+
+var (
+	// componentRegistry is a way to register components with the CRIB-SDK, so that they can be
+	// used by name, e.g. from a plan file. Component names must be globally unique to avoid collisions.
+	componentRegistry = []crib.ComponentRegistration{
+		crib.RegisterComponent(anvilv1.ComponentName, anvilComponent,
+			crib.ComponentDescription("An Anvil development blockchain exposed by a Kubernetes Service."),
+		),
+		crib.RegisterComponent(jdv1.ComponentName, jdv1.Component,
+			crib.ComponentDescription("The Chainlink Job Distributor with its PostgreSQL database."),
+		),
+		crib.RegisterComponent(chainlinknodev1.ComponentName, chainlinknodev1.Component,
+			crib.ComponentDescription("A Chainlink node, with a PostgreSQL database unless a database URL is given."),
+		),
+		crib.RegisterComponent(nodesetv1.ComponentName, nodesetv1.Component,
+			crib.ComponentDescription("A set of Chainlink nodes sharing a single PostgreSQL database."),
+		),
+		crib.RegisterComponent(telepresencev1.ComponentName, telepresencev1.Component,
+			crib.ComponentDescription("The Telepresence traffic manager, giving local processes access to the cluster."),
+		),
+		crib.RegisterComponent(clientsideapplyv1.ComponentName, clientSideApplyComponent,
+			crib.ComponentVersion("v1"),
+			crib.ComponentDescription("A command run by cribctl on the client, e.g. kind, kubectl or task."),
+		),
+		crib.RegisterComponent(waitv1.ComponentName, waitComponent,
+			crib.ComponentVersion("v1"),
+			crib.ComponentDescription("Waits for a rollout, ready pods, a completed job, endpoints, an HTTP 200 or an established CRD."),
+		),
+		crib.RegisterComponent(helmchartv1.ComponentName, helmchartv1.Component,
+			crib.ComponentVersion("v1"),
+			crib.ComponentDescription("Any Helm chart, rendered with the locally installed helm binary."),
+		),
+		crib.RegisterComponent(namespacev1.ComponentName, namespaceComponent,
+			crib.ComponentVersion("v1"),
+			crib.ComponentDescription("A Kubernetes Namespace."),
+		),
+	}
+
+	// availableComponents is a map of component name to component registration. This is
+	// autogenerated by the SDK during initialization.
+	availableComponents = make(map[string]crib.ComponentRegistration)
+)
+
+// Component returns the registration of a component by its name, and whether the component is registered.
+func Component(name string) (crib.ComponentRegistration, bool) {
+	c, ok := availableComponents[name]
+	return c, ok
+}
+
+// Components returns a list of all registered components, sorted by name.
+func Components() []string {
+	return slices.Sorted(maps.Keys(availableComponents))
+}
+
+func init() {
+	for _, c := range componentRegistry {
+		// Panic if the component name is empty.
+		name := c.Name()
+		if name == "" {
+			panic("Components must have their name populated!")
+		}
+		// Panic if the component name is already registered.
+		if _, ok := availableComponents[name]; ok {
+			panic("Component " + name + " has already been registered!")
+		}
+		availableComponents[name] = c
+	}
+}
+
+// anvilComponent creates an Anvil component from its props alone.
+func anvilComponent(props *anvilv1.Props) crib.ComponentFunc {
+	return anvilv1.Component(props)
+}
+
+// clientSideApplyComponent creates a ClientSideApply component from its concrete props.
+func clientSideApplyComponent(props *clientsideapplyv1.Props) crib.ComponentFunc {
+	return clientsideapplyv1.Component(props)
+}
+
+// waitComponent creates a Wait component from its concrete props.
+func waitComponent(props *waitv1.Props) crib.ComponentFunc {
+	return waitv1.Component(props)
+}
+
+// namespaceComponent creates a Namespace component from its props.
+func namespaceComponent(props *namespacev1.Props) crib.ComponentFunc {
+	return namespacev1.Component(props.Namespace)
+}
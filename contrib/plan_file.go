@@ -0,0 +1,144 @@
+package contrib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/smartcontractkit/crib-sdk/crib"
+)
+
+type (
+	// PlanFile is the declarative form of a Plan, as written in a YAML plan file:
+	//
+	//	name: qa-sandbox
+	//	namespace: crib-qa
+	//	plans:
+	//	  - bootstrap-kindv1
+	//	components:
+	//	  - component: sdk.composite.chainlink.jd.v1
+	//	    props:
+	//	      namespace: crib-qa
+	//	      jd:
+	//	        image: localhost:5001/job-distributor:0.12.7
+	//	        csaEncryptionKey: <64 hex characters>
+	//
+	// Components are referenced by the name they are registered with, see Components, and child plans
+	// by the name they are registered with, see Plans. Props are matched to the fields of the props of
+	// the component by name, ignoring case.
+	PlanFile struct {
+		Name       string          `yaml:"name"`
+		Namespace  string          `yaml:"namespace"`
+		Plans      []string        `yaml:"plans"`
+		Components []PlanComponent `yaml:"components"`
+	}
+
+	// PlanComponent is a component of a PlanFile.
+	PlanComponent struct {
+		Component string         `yaml:"component"`
+		Props     map[string]any `yaml:"props"`
+	}
+)
+
+// LoadPlanFile reads a Plan from a YAML plan file, see PlanFile.
+func LoadPlanFile(ctx context.Context, path string) (*crib.Plan, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	plan, err := LoadPlan(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("loading plan file %s: %w", path, err)
+	}
+	return plan, nil
+}
+
+// LoadPlan reads a Plan from its YAML form, see PlanFile. The props of every component are decoded
+// into the props type of the component and validated before the Plan is returned. All errors are
+// collected and returned at once.
+func LoadPlan(ctx context.Context, r io.Reader) (*crib.Plan, error) {
+	var file PlanFile
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("decoding plan: %w", err)
+	}
+	if file.Name == "" {
+		return nil, errors.New("plan must have a name")
+	}
+
+	var (
+		errs error
+		opts []crib.PlanOpt
+	)
+	if file.Namespace != "" {
+		opts = append(opts, crib.Namespace(file.Namespace))
+	}
+	for _, name := range file.Plans {
+		plan, ok := availablePlans[name]
+		if !ok {
+			errs = errors.Join(errs, fmt.Errorf("plan %s is not registered", name))
+			continue
+		}
+		opts = append(opts, crib.AddPlan(plan))
+	}
+
+	components := make([]crib.ComponentFunc, 0, len(file.Components))
+	for i, c := range file.Components {
+		component, err := c.component(ctx)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("components[%d] (%s): %w", i, c.Component, err))
+			continue
+		}
+		components = append(components, component)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	opts = append(opts, crib.ComponentSet(components...))
+	return crib.NewPlan(file.Name, opts...), nil
+}
+
+// component decodes and validates the props of the component, and returns the ComponentFunc that
+// creates it.
+func (c PlanComponent) component(ctx context.Context) (crib.ComponentFunc, error) {
+	registration, ok := Component(c.Component)
+	if !ok {
+		return nil, errors.New("component is not registered")
+	}
+
+	// The props are decoded through JSON, which matches field names without regard to case, so that
+	// the props can be written in camel case without the props types declaring any tags.
+	b, err := json.Marshal(c.Props)
+	if err != nil {
+		return nil, fmt.Errorf("encoding props: %w", err)
+	}
+	decode := func() (crib.Props, error) {
+		props := registration.NewProps()
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(props); err != nil {
+			return nil, fmt.Errorf("decoding props: %w", err)
+		}
+		return props, nil
+	}
+
+	// Validate a copy of the props, as components validate their props again when they are created
+	// and validation may modify the props, e.g. by setting defaults.
+	props, err := decode()
+	if err != nil {
+		return nil, err
+	}
+	if err := props.Validate(ctx); err != nil {
+		return nil, err
+	}
+	props, _ = decode()
+	return registration.Component(props), nil
+}
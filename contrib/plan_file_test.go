@@ -0,0 +1,85 @@
+package contrib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPlanFile(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+	is := assert.New(t)
+
+	plan, err := LoadPlanFile(t.Context(), "testdata/plan.yaml")
+	must.NoError(err)
+	is.Equal("qa-sandbox", plan.Name())
+	is.Equal("crib-qa", plan.Namespace())
+	is.Len(plan.Components(), 2)
+
+	built := plan.Build()
+	must.Len(built.ChildPlans(), 1)
+	is.Equal("examplev1", built.ChildPlans()[0].Name())
+}
+
+func TestLoadPlan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc    string
+		plan    string
+		wantErr string
+	}{
+		{
+			desc:    "missing name",
+			plan:    "namespace: crib",
+			wantErr: "plan must have a name",
+		},
+		{
+			desc:    "unknown field",
+			plan:    "name: p\nchildren: [examplev1]",
+			wantErr: "field children not found",
+		},
+		{
+			desc:    "unknown plan",
+			plan:    "name: p\nplans: [missing]",
+			wantErr: "plan missing is not registered",
+		},
+		{
+			desc:    "unknown component",
+			plan:    "name: p\ncomponents:\n  - component: sdk.Missing",
+			wantErr: "components[0] (sdk.Missing): component is not registered",
+		},
+		{
+			desc:    "unknown prop",
+			plan:    "name: p\ncomponents:\n  - component: sdk.Namespace\n    props:\n      name: crib",
+			wantErr: `decoding props: json: unknown field "name"`,
+		},
+		{
+			desc:    "invalid props",
+			plan:    "name: p\ncomponents:\n  - component: sdk.ClientSideApply\n    props:\n      action: rm\n      args: [-rf]",
+			wantErr: "components[0] (sdk.ClientSideApply): Key: 'Props.Action'",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			_, err := LoadPlan(t.Context(), strings.NewReader(tc.plan))
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestComponent(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Contains(Components(), "sdk.composite.chainlink.nodeset.v1")
+	c, ok := Component("sdk.HelmChart")
+	is.True(ok)
+	is.Equal("ChartProps", c.PropsType().Name())
+	_, ok = Component("sdk.Missing")
+	is.False(ok)
+}
@@ -1,6 +1,8 @@
 package contrib
 
 import (
+	"errors"
+	"fmt"
 	"maps"
 	"slices"
 
@@ -55,6 +57,20 @@ func Plans() []string {
 	return slices.Sorted(maps.Keys(availablePlans))
 }
 
+// RegisterPlan registers a plan at runtime, for example a plan loaded from a file with LoadPlanFile,
+// so that it can be applied by name. It returns an error if a plan with the same name is registered.
+func RegisterPlan(plan func() *crib.Plan) error {
+	name := plan().Name()
+	if name == "" {
+		return errors.New("plans must have their name populated")
+	}
+	if _, ok := availablePlans[name]; ok {
+		return fmt.Errorf("plan %s has already been registered", name)
+	}
+	availablePlans[name] = plan
+	return nil
+}
+
 func init() {
 	for _, plan := range planRegistry {
 		p := plan()
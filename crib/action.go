@@ -0,0 +1,61 @@
+package crib
+
+import (
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+type (
+	// Action describes an action of ClientSideApply steps: the binary that runs the steps, or a Run
+	// func that runs them in process, the version of the binary that `cribctl doctor` checks, and the
+	// args that the steps accept. Actions are registered with RegisterAction.
+	Action = domain.ActionDefinition
+
+	// ArgsValidator validates the args of the steps of an Action.
+	ArgsValidator = domain.ArgsValidator
+
+	// VersionConstraint is a version prefixed by one of the operators >=, >, <=, < or ==, e.g. ">=1.2.0".
+	VersionConstraint = domain.VersionConstraint
+
+	// RunnerResult is the result of a step run by the Run func of an Action.
+	RunnerResult = domain.RunnerResult
+
+	// ClientSideApplyManifest is the manifest of a ClientSideApply step, as passed to the Run func of an Action.
+	ClientSideApplyManifest = domain.ClientSideApplyManifest
+)
+
+// RegisterAction registers an action for ClientSideApply steps, next to the built-in actions such as
+// cmd, kubectl and helm. It is meant to be called from the init func of the package that provides
+// the action, and panics if the action is invalid or an action with the same name is registered:
+//
+//	func init() {
+//		crib.RegisterAction(crib.Action{
+//			Name:              "vault",
+//			Binary:            "vault",
+//			VersionCommand:    []string{"vault", "version"},
+//			VersionConstraint: ">=1.15.0",
+//			Args:              crib.MinArgs(1),
+//		})
+//	}
+//
+// Once registered, the action is accepted by the validation of ClientSideApply props, checked by
+// `cribctl doctor`, and run by cribctl when the plan is applied.
+func RegisterAction(a Action) {
+	if err := domain.RegisterAction(a); err != nil {
+		panic(err)
+	}
+}
+
+// Actions returns the names of the registered actions, sorted.
+func Actions() []string {
+	return domain.ActionNames()
+}
+
+// MinArgs returns an ArgsValidator that requires at least n args.
+func MinArgs(n int) ArgsValidator {
+	return domain.MinArgs(n)
+}
+
+// ExactArgs returns an ArgsValidator that requires exactly n args.
+func ExactArgs(n int) ArgsValidator {
+	return domain.ExactArgs(n)
+}
@@ -7,6 +7,33 @@ import (
 	"github.com/smartcontractkit/crib-sdk/internal/core/service"
 )
 
+type (
+	// In is embedded in a struct parameter of the Apply method of a Scalar to have each of its exported
+	// fields provided separately. Fields tagged with a qualifier, e.g. `name:"registry-5001"`, are provided
+	// by the Scalar registered with that qualifier, see Named.
+	In = service.In
+
+	// Out is embedded in the struct returned by the Apply method of a Scalar to have each of its exported
+	// fields produced separately. Fields tagged with a qualifier, e.g. `name:"registry-url"`, are consumed
+	// by asking for that qualifier with a field of an In struct.
+	Out = service.Out
+
+	// NamedConstructor is the constructor of a Scalar registered with a qualifier, see Named.
+	NamedConstructor = service.NamedConstructor
+)
+
+// Optional is a parameter of a constructor or an Apply method, or a field of an In struct, that holds the
+// value of type T produced by another Scalar, or is empty if no Scalar produces one.
+type Optional[T any] = service.Optional[T]
+
+// Named registers the constructor of a Scalar within a Composite with a qualifier, so that consumers can
+// ask for its result by name when several Scalars produce the same type. Qualifiers must be unique
+// within a Composite. The result is still collected by consumers of a slice of its type. Scalars that return
+// an Out struct cannot be registered with a qualifier, their fields are qualified with name tags instead.
+func Named(name string, ctor any) NamedConstructor {
+	return service.Named(name, ctor)
+}
+
 // NewComposite returns a ComponentFunc that creates a composite from the given scalars.
 // All scalars within a single Composite share the same Composite context. The Composite API
 // provides a dependency graph allowing Scalars to produce and consume results that are
@@ -49,8 +76,72 @@ import (
 //		NewGroupConsumer,
 //	)
 //
-// Caveats and limitations:
-// - At the moment, getting results _out_ of a Composite is not supported, but coming soon.
+// When several Scalars produce the same type, a consumer of a single value cannot tell which one it
+// should receive. Register the producers with a qualifier using Named, and ask for one of them with a
+// field of a struct parameter that embeds In:
+//
+//	type RegistryParams struct {
+//		crib.In
+//		Registry *DockerResults `name:"registry-5001"`
+//	}
+//
+//	func (m MyComponent) Apply(params RegistryParams) {
+//		// params.Registry is the result of the producer registered as "registry-5001".
+//	}
+//
+//	NewComposite(
+//		crib.Named("registry-5000", NewDockerRegistry("5000")),
+//		crib.Named("registry-5001", NewDockerRegistry("5001")),
+//		NewMyComponent,
+//	)
+//
+// Every parameter must be provided by a Scalar of the Composite, unless it is Optional:
+//
+//	func (m MyComponent) Apply(registry crib.Optional[*DockerResults]) {
+//		if r, ok := registry.Get(); ok {
+//			// The registry is deployed by the Composite.
+//		}
+//	}
+//
+// A Scalar produces several values by returning a struct that embeds Out. Each of its exported fields is
+// produced separately, and may be qualified with a name tag:
+//
+//	type RegistryOutputs struct {
+//		crib.Out
+//		URL         string `name:"registry-url"`
+//		Credentials *Credentials
+//		Chart       crib.Component
+//	}
+//
+//	func (d *DockerRegistry) Apply(ctx context.Context) (RegistryOutputs, error) {
+//		// ...
+//	}
+//
+// Constructors may take parameters too, which are provided like the parameters of `Apply`: results of other
+// Scalars, the built-in types above, the *Validator of the plan, and the ParamValues it is applied with. Such
+// constructors are only called once their parameters are available, so a Scalar can be configured from
+// upstream results when it is created rather than in `Apply`. A constructor may also return an error, which
+// is returned by the ComponentFunc:
+//
+//	func NewMyComponent(registry *DockerResults, params crib.ParamValues) (*MyComponent, error) {
+//		if registry.URL == "" {
+//			return nil, errors.New("registry has no URL")
+//		}
+//		return &MyComponent{Registry: registry.URL, Replicas: replicas.From(params)}, nil
+//	}
+//
+// The name of a Scalar created by a constructor with parameters is derived from the constructor, as the
+// Scalar does not exist yet when the graph is built.
+//
+// Scalars that do not depend on each other are applied concurrently, level by level. Calls into cdk8s are
+// serialized, so Scalars mostly overlap while they run binaries such as `helm template`. Use
+// NewSequentialComposite to apply the Scalars one at a time.
+//
+// The values produced by the Scalars are kept once the Composite is applied. Read them from the Component
+// returned by the ComponentFunc with Outputs, or from the state of an applied plan with CompositeOutputs:
+//
+//	state, err := plan.Apply(ctx)
+//	registries := crib.CompositeOutputs[*DockerResults](state)
 func NewComposite(scalars ...any) ComponentFunc {
 	cs := service.NewCompositeSet()
 	return func(ctx context.Context) (Component, error) {
@@ -58,3 +149,13 @@ func NewComposite(scalars ...any) ComponentFunc {
 		return dry.Wrap2(component, err)
 	}
 }
+
+// NewSequentialComposite returns a ComponentFunc that creates a composite like NewComposite, but applies
+// its scalars one at a time in dependency order, instead of applying independent scalars concurrently.
+func NewSequentialComposite(scalars ...any) ComponentFunc {
+	cs := service.NewCompositeSet(service.WithSequentialExecution())
+	return func(ctx context.Context) (Component, error) {
+		component, err := cs.Apply(ctx, scalars...)
+		return dry.Wrap2(component, err)
+	}
+}
@@ -17,8 +17,8 @@ import (
 	deploymentv1 "github.com/smartcontractkit/crib-sdk/crib/composite/deployment/v1"
 	workloadv1 "github.com/smartcontractkit/crib-sdk/crib/composite/workload/v1"
 	postgresv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/charts/postgres/v1"
-	clientsideapply "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
 	helmchartv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/helmchart/v1"
+	wait "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
 const (
@@ -37,7 +37,7 @@ type (
 	// JDProps contains properties specific to the JD component.
 	JDProps struct {
 		Image            string `validate:"required,image_uri"`
-		CSAEncryptionKey string `validate:"required,hexadecimal,len=64"`
+		CSAEncryptionKey string `sensitive:"true" validate:"required,hexadecimal,len=64"`
 	}
 
 	// Props contains Composite component props.
@@ -222,18 +222,10 @@ func AddPostgresWithWait(ctx context.Context, jdProps *Props) (pg, waitForDB cri
 		fmt.Sprintf("statefulset.kubernetes.io/pod-name=%s-0", jdProps.dbInstanceName()),
 		"app.kubernetes.io/name=postgresql",
 	}
-	waitForDB, err = clientsideapply.New(ctx, &clientsideapply.Props{
+	waitForDB, err = wait.New(ctx, &wait.Props{
 		Namespace: jdProps.Namespace,
-		OnFailure: "abort",
-		Action:    "kubectl",
-		Args: []string{
-			"wait",
-			"-n", jdProps.Namespace,
-			"--for=condition=ready",
-			"pod",
-			fmt.Sprintf("-l=%s", strings.Join(labels, ",")),
-			"--timeout=600s",
-		},
+		Condition: domain.WaitPodReady,
+		Selector:  strings.Join(labels, ","),
 	})
 	if err != nil {
 		return nil, nil, err
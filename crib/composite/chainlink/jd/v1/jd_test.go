@@ -46,7 +46,7 @@ func TestComponent_MinimalProps(t *testing.T) {
 	wantCharts := []string{
 		"TestingApp",
 		ComponentName,
-		"sdk.HelmChart#postgres", "sdk.Namespace", "sdk.HelmChart", "sdk.ClientSideApply", "sdk.DeploymentV1", "sdk.ServiceV1",
+		"sdk.HelmChart#postgres", "sdk.Namespace", "sdk.HelmChart", "sdk.Wait", "sdk.DeploymentV1", "sdk.ServiceV1",
 	}
 	is.Equal(wantCharts, gotCharts, "Expected charts should match actual charts")
 	is.Len(*app.Charts(), len(wantCharts), "Number of charts should match expected count")
@@ -102,7 +102,7 @@ func TestComponent_AllProps(t *testing.T) {
 	wantCharts := []string{
 		"TestingApp",
 		ComponentName,
-		"sdk.HelmChart#postgres", "sdk.Namespace", "sdk.HelmChart", "sdk.ClientSideApply", "sdk.ServiceV1", "sdk.DeploymentV1", "sdk.ClientSideApply",
+		"sdk.HelmChart#postgres", "sdk.Namespace", "sdk.HelmChart", "sdk.Wait", "sdk.ServiceV1", "sdk.DeploymentV1", "sdk.Wait",
 	}
 	is.Equal(wantCharts, gotCharts, "Expected charts should match actual charts")
 	is.Len(*app.Charts(), len(wantCharts), "Number of charts should match expected count")
@@ -63,10 +63,10 @@ type Props struct {
 	// SecretsOverrides contains additional secrets files to be passed as -s arguments.
 	// The files are processed in lexicographic order by filename to ensure consistent
 	// behavior when Chainlink performs configuration merging.
-	SecretsOverrides map[string]string
+	SecretsOverrides map[string]string `sensitive:"true"`
 	Resources        ResourceRequirements
 	// DatabaseURL for connecting to existing database. If not provided, a postgres component will be created automatically.
-	DatabaseURL string
+	DatabaseURL string          `sensitive:"true"`
 	Ports       []ContainerPort // Container ports to expose, defaults to API and P2P ports
 	Replicas    int32           `default:"1"`
 }
@@ -193,6 +193,7 @@ func chainlinkNode(ctx context.Context, props crib.Props) (crib.Component, error
 		// Generate database URL
 		actualDatabaseURL = fmt.Sprintf("postgresql://%s:%s@%s:5432/%s?sslmode=disable",
 			username, defaultPassword, postgresReleaseName, database)
+		crib.RegisterSecret(defaultPassword, actualDatabaseURL)
 
 		postgresInfo = &PostgresInfo{
 			ReleaseName:       postgresReleaseName,
@@ -10,11 +10,12 @@ import (
 	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 
 	chainlinknodev1 "github.com/smartcontractkit/crib-sdk/crib/composite/chainlink/node/v1"
 	postgresv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/charts/postgres/v1"
-	clientsideapplyv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
 	helmchartv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/helmchart/v1"
+	waitv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
 const (
@@ -25,7 +26,7 @@ const (
 type Props struct {
 	Namespace           string                       `validate:"required"`
 	PostgresReleaseName string                       `default:"shared-postgres"`
-	PostgresPassword    string                       `default:"postgres"`
+	PostgresPassword    string                       `default:"postgres" sensitive:"true"`
 	PostgresResources   map[string]map[string]string // Resource requirements for PostgreSQL (requests/limits)
 	NodeProps           []*chainlinknodev1.Props     `validate:"required"`
 	Size                int                          `validate:"required,min=1"`
@@ -146,18 +147,10 @@ func nodeSet(ctx context.Context, props crib.Props) (crib.Component, error) {
 	}
 
 	// Wait for all Chainlink nodes to be ready
-	waitForNodes, err := clientsideapplyv1.New(ctx, &clientsideapplyv1.Props{
+	waitForNodes, err := waitv1.New(ctx, &waitv1.Props{
 		Namespace: nodeSetProps.Namespace,
-		OnFailure: "abort",
-		Action:    "kubectl",
-		Args: []string{
-			"wait",
-			"-n", nodeSetProps.Namespace,
-			"--for=condition=ready",
-			"pod",
-			"-l=app.kubernetes.io/name=chainlink",
-			"--timeout=600s",
-		},
+		Condition: domain.WaitPodReady,
+		Selector:  "app.kubernetes.io/name=chainlink",
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create wait for nodes: %w", err)
@@ -115,9 +115,9 @@ SecureCookies = false`,
 	// - sdk.NodeSet (main component)
 	// - sdk.HelmChart#postgres (PostgreSQL)
 	// - 3 x sdk.composite.chainlink.node.v1 (Chainlink nodes)
-	// - sdk.ClientSideApply (wait for all nodes)
+	// - sdk.Wait (wait for all nodes)
 	// Each Chainlink node creates multiple sub-charts, so we expect more charts
-	is.GreaterOrEqual(len(gotCharts), 6, "Should have at least 6 charts (app, nodeset, postgres, 3 chainlink nodes, clientsideapply)")
+	is.GreaterOrEqual(len(gotCharts), 6, "Should have at least 6 charts (app, nodeset, postgres, 3 chainlink nodes, wait)")
 
 	// Verify PostgreSQL chart exists
 	postgresCharts := lo.Filter(gotCharts, func(name string, _ int) bool {
@@ -131,38 +131,37 @@ SecureCookies = false`,
 	})
 	is.Len(chainlinkCharts, 3, "Should have exactly 3 Chainlink node charts")
 
-	// Verify ClientSideApply chart exists
-	clientSideApplyCharts := lo.Filter(gotCharts, func(name string, _ int) bool {
-		return name == "sdk.ClientSideApply"
+	// Verify Wait chart exists
+	waitCharts := lo.Filter(gotCharts, func(name string, _ int) bool {
+		return name == "sdk.Wait"
 	})
-	is.Len(clientSideApplyCharts, 1, "Should have exactly one ClientSideApply chart")
+	is.Len(waitCharts, 1, "Should have exactly one Wait chart")
 
-	// Find and verify the ClientSideApply component
+	// Find and verify the Wait component
 	var waitForChart cdk8s.Chart
 	for _, c := range *app.Charts() {
 		if !*cdk8s.Chart_IsChart(c) {
 			continue
 		}
-		if crib.ExtractResource(c.Node().Id()) == "sdk.ClientSideApply" {
+		if crib.ExtractResource(c.Node().Id()) == "sdk.Wait" {
 			waitForChart = c
 			break
 		}
 	}
-	must.NotNil(waitForChart, "ClientSideApply chart should be found")
+	must.NotNil(waitForChart, "Wait chart should be found")
 
-	// Verify ClientSideApply configuration
-	t.Run("ClientSideApply", func(t *testing.T) {
+	// Verify Wait configuration
+	t.Run("Wait", func(t *testing.T) {
 		var obj cdk8s.ApiObject
 		is.NotPanics(func() {
 			obj = cdk8s.ApiObject_Of(waitForChart.Node().DefaultChild())
 		}, "Should not panic when getting default child")
-		is.NotNil(obj, "ClientSideApply object should not be nil")
+		is.NotNil(obj, "Wait object should not be nil")
 
 		// Verify object metadata
 		is.Equal("crib.smartcontract.com/v1alpha1", *obj.ApiVersion(), "API version should match")
-		is.Equal("ClientSideApply", *obj.Kind(), "Kind should be ClientSideApply")
+		is.Equal("Wait", *obj.Kind(), "Kind should be Wait")
 		is.Equal("crib.smartcontract.com", *obj.ApiGroup(), "API group should match")
-		is.Equal("test-namespace", *obj.Metadata().Namespace(), "Namespace should match")
 
 		// Verify object specification
 		json := dry.As[map[string]any](obj.ToJson())
@@ -171,16 +170,9 @@ SecureCookies = false`,
 		is.NotNil(spec, "Spec should not be nil")
 
 		want := map[string]any{
-			"onFailure": "abort",
-			"action":    "kubectl",
-			"args": []any{
-				"wait",
-				"-n", "test-namespace",
-				"--for=condition=ready",
-				"pod",
-				"-l=app.kubernetes.io/name=chainlink",
-				"--timeout=600s",
-			},
+			"condition": "pod-ready",
+			"namespace": "test-namespace",
+			"selector":  "app.kubernetes.io/name=chainlink",
 		}
 		is.Equal(want, spec, "Spec should match expected configuration")
 	})
@@ -461,3 +453,39 @@ func TestNodeSetWithEmptyPostgresResources(t *testing.T) {
 	must.True(ok, "Component should return a Result struct")
 	is.Len(result.Nodes, 1, "Should have exactly 1 node")
 }
+
+// TestNodeSetDefaultPasswordNotRedacted verifies that the default PostgreSQL password is not
+// registered as a secret, it would mask every "postgres" in the output.
+func TestNodeSetDefaultPasswordNotRedacted(t *testing.T) {
+	t.Parallel()
+	internal.JSIIKernelMutex.Lock()
+	t.Cleanup(internal.JSIIKernelMutex.Unlock)
+
+	is := assert.New(t)
+	must := require.New(t)
+
+	app := internal.NewTestApp(t)
+	ctx := internal.ContextWithConstruct(t.Context(), app.Chart)
+
+	newProps := func() *Props {
+		return &Props{
+			Namespace: "test-redact-namespace",
+			Size:      1,
+			NodeProps: []*chainlinknodev1.Props{
+				{
+					AppInstanceName: "redact-test-node-0",
+					Image:           "chainlink/chainlink:latest",
+					Config:          "[Log]\nLevel = 'warn'",
+				},
+			},
+		}
+	}
+	validated := newProps()
+	must.NoError(validated.Validate(ctx))
+	is.Equal("postgres", validated.PostgresPassword)
+	is.Equal("shared-postgres postgresql://", crib.Redact("shared-postgres postgresql://"))
+
+	_, err := Component(newProps())(ctx)
+	must.NoError(err, "Component creation should succeed")
+	is.Contains(crib.Redact(*app.SynthYaml()), "shared-postgres")
+}
@@ -2,7 +2,6 @@ package nginxcontrollerv1
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
@@ -10,10 +9,11 @@ import (
 	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 
-	clientsideapply "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
 	namespace "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/namespace/v1"
 	remoteapply "github.com/smartcontractkit/crib-sdk/crib/scalar/remoteapply/v1"
+	wait "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
 const manifestURI = "https://raw.githubusercontent.com/kubernetes/ingress-nginx/main/deploy/static/provider/kind/deploy.yaml"
@@ -93,18 +93,10 @@ func nginxController(ctx context.Context, props crib.Props) (crib.Component, err
 		"app.kubernetes.io/instance=ingress-nginx",
 		"app.kubernetes.io/name=ingress-nginx",
 	}
-	waitFor, err := clientsideapply.New(ctx, &clientsideapply.Props{
+	waitFor, err := wait.New(ctx, &wait.Props{
 		Namespace: ns,
-		OnFailure: "abort",
-		Action:    "kubectl",
-		Args: []string{
-			"wait",
-			"-n", ns,
-			"--for=condition=ready",
-			"pod",
-			fmt.Sprintf("-l=%s", strings.Join(labels, ",")),
-			"--timeout=600s",
-		},
+		Condition: domain.WaitPodReady,
+		Selector:  strings.Join(labels, ","),
 	})
 	if err != nil {
 		return nil, err
@@ -34,12 +34,12 @@ func TestComponent(t *testing.T) {
 		"sdk.NginxController",
 		"sdk.Namespace",
 		"sdk.RemoteApply",
-		"sdk.ClientSideApply",
+		"sdk.Wait",
 	}
 	is.Equal(wantCharts, gotCharts)
 	is.Len(*app.Charts(), len(wantCharts))
 
-	var ns, apply cdk8s.Chart
+	var ns, wait cdk8s.Chart
 	for _, c := range *app.Charts() {
 		if !dry.FromPtr(cdk8s.Chart_IsChart(c)) {
 			continue
@@ -48,8 +48,8 @@ func TestComponent(t *testing.T) {
 		switch crib.ExtractResource(c.Node().Id()) {
 		case "sdk.Namespace":
 			ns = c
-		case "sdk.ClientSideApply":
-			apply = c
+		case "sdk.Wait":
+			wait = c
 		}
 	}
 
@@ -65,15 +65,15 @@ func TestComponent(t *testing.T) {
 		is.Equal("ingress-nginx", *obj.Metadata().Name())
 	})
 
-	t.Run("ClientSideApply", func(t *testing.T) {
+	t.Run("Wait", func(t *testing.T) {
 		var obj cdk8s.ApiObject
 		is.NotPanics(func() {
-			obj = cdk8s.ApiObject_Of(apply.Node().DefaultChild())
+			obj = cdk8s.ApiObject_Of(wait.Node().DefaultChild())
 		})
 		is.NotNil(obj)
 
 		is.Equal("crib.smartcontract.com/v1alpha1", *obj.ApiVersion())
-		is.Equal("ClientSideApply", *obj.Kind())
+		is.Equal("Wait", *obj.Kind())
 		is.Equal("crib.smartcontract.com", *obj.ApiGroup())
 	})
 
@@ -2,7 +2,6 @@ package telepresencev1
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
@@ -11,6 +10,7 @@ import (
 	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 
 	telepresence "github.com/smartcontractkit/crib-sdk/crib/scalar/charts/telepresence/v1"
 	clientsideapply "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
@@ -18,6 +18,7 @@ import (
 	rolev1 "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/role/v1"
 	rolebindingv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/rolebinding/v1"
 	serviceaccountv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/serviceaccount/v1"
+	waitv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
 const ComponentName = "sdk.composite.telepresence.v1"
@@ -87,18 +88,11 @@ func telepresenceComposite(ctx context.Context, props crib.Props) (crib.Componen
 		"app=traffic-manager",
 		"telepresence=manager",
 	}
-	waitForTelepresence, err := clientsideapply.New(ctx, &clientsideapply.Props{
+	waitForTelepresence, err := waitv1.New(ctx, &waitv1.Props{
 		Namespace: telepresenceProps.Namespace,
-		OnFailure: "abort",
-		Action:    "kubectl",
-		Args: []string{
-			"wait",
-			"-n", telepresenceProps.Namespace,
-			"--for=condition=available",
-			"deployment",
-			fmt.Sprintf("-l=%s", strings.Join(labels, ",")),
-			"--timeout=600s",
-		},
+		Condition: domain.WaitRollout,
+		Kind:      "Deployment",
+		Selector:  strings.Join(labels, ","),
 	})
 	if err != nil {
 		return nil, dry.Wrapf(err, "failed to wait for telepresence")
@@ -140,7 +134,7 @@ func telepresenceComposite(ctx context.Context, props crib.Props) (crib.Componen
 
 	// Set up dependencies: telepresence -> wait -> connect
 	waitForTelepresence.Node().AddDependency(telepresenceComponent)
-	connectTelepresence.Node().AddDependency(crib.ComponentState[*clientsideapply.Result](waitForTelepresence).Component)
+	connectTelepresence.Node().AddDependency(waitForTelepresence.Component)
 
 	return connectTelepresence, nil
 }
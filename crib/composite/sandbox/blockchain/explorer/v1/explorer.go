@@ -13,8 +13,8 @@ import (
 	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 
 	otterscanv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/charts/otterscan/v1"
-	clientsideapply "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
 	helmchartv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/helmchart/v1"
+	wait "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
 const (
@@ -109,18 +109,10 @@ func blockchainExplorer(ctx context.Context, props crib.Props) (crib.Component,
 		fmt.Sprintf("app.kubernetes.io/component=%s", blockExplorerProps.ReleaseName),
 		"app.kubernetes.io/name=devspace-app",
 	}
-	waitFor, err := clientsideapply.New(ctx, &clientsideapply.Props{
+	waitFor, err := wait.New(ctx, &wait.Props{
 		Namespace: blockExplorerProps.Namespace,
-		OnFailure: domain.FailureAbort,
-		Action:    domain.ActionKubectl,
-		Args: []string{
-			"wait",
-			"-n", blockExplorerProps.Namespace,
-			"--for=condition=ready",
-			"pod",
-			fmt.Sprintf("-l=%s", strings.Join(labels, ",")),
-			"--timeout=600s",
-		},
+		Condition: domain.WaitPodReady,
+		Selector:  strings.Join(labels, ","),
 	})
 	if err != nil {
 		return nil, err
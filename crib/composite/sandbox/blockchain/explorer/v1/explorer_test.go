@@ -50,7 +50,7 @@ func TestComponent(t *testing.T) {
 		"sdk.HelmChart#otterscan",
 		"sdk.Namespace",
 		"sdk.HelmChart",
-		"sdk.ClientSideApply",
+		"sdk.Wait",
 	}
 	is.Equal(wantCharts, gotCharts)
 	is.Len(*app.Charts(), len(wantCharts))
@@ -74,7 +74,7 @@ func TestComponent(t *testing.T) {
 		switch crib.ExtractResource(c.Node().Id()) {
 		case "sdk.HelmChart#otterscan":
 			otterscan = c
-		case "sdk.ClientSideApply":
+		case "sdk.Wait":
 			waitFor = c
 		}
 	}
@@ -111,9 +111,8 @@ func TestComponent(t *testing.T) {
 		is.NotNil(obj)
 
 		is.Equal("crib.smartcontract.com/v1alpha1", *obj.ApiVersion())
-		is.Equal("ClientSideApply", *obj.Kind())
+		is.Equal("Wait", *obj.Kind())
 		is.Equal("crib.smartcontract.com", *obj.ApiGroup())
-		is.Equal("test-namespace", *obj.Metadata().Namespace())
 
 		json := dry.As[map[string]any](obj.ToJson())
 		is.NotNil(json)
@@ -121,16 +120,9 @@ func TestComponent(t *testing.T) {
 		is.NotNil(spec)
 
 		want := map[string]any{
-			"onFailure": domain.FailureAbort,
-			"action":    domain.ActionKubectl,
-			"args": []any{
-				"wait",
-				"-n", "test-namespace",
-				"--for=condition=ready",
-				"pod",
-				"-l=app.kubernetes.io/component=test-blockexplorer,app.kubernetes.io/name=devspace-app",
-				"--timeout=600s",
-			},
+			"condition": domain.WaitPodReady,
+			"namespace": "test-namespace",
+			"selector":  "app.kubernetes.io/component=test-blockexplorer,app.kubernetes.io/name=devspace-app",
 		}
 		is.Equal(want, spec)
 	})
@@ -10,11 +10,12 @@ import (
 	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 
 	anvilv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/charts/anvil/v1"
 	aptosv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/charts/aptos/v1"
-	clientsideapply "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
 	helmchartv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/helmchart/v1"
+	wait "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
 const (
@@ -114,18 +115,10 @@ func blockchainNode(ctx context.Context, props crib.Props) (crib.Component, erro
 		fmt.Sprintf("app.kubernetes.io/component=%s", blockchainProps.ReleaseName),
 		"app.kubernetes.io/name=devspace-app",
 	}
-	waitFor, err := clientsideapply.New(ctx, &clientsideapply.Props{
+	waitFor, err := wait.New(ctx, &wait.Props{
 		Namespace: blockchainProps.Namespace,
-		OnFailure: "abort",
-		Action:    "kubectl",
-		Args: []string{
-			"wait",
-			"-n", blockchainProps.Namespace,
-			"--for=condition=ready",
-			"pod",
-			fmt.Sprintf("-l=%s", strings.Join(labels, ",")),
-			"--timeout=600s",
-		},
+		Condition: domain.WaitPodReady,
+		Selector:  strings.Join(labels, ","),
 	})
 	if err != nil {
 		return nil, err
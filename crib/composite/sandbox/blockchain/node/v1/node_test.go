@@ -31,7 +31,7 @@ func TestComponent(t *testing.T) {
 				"sdk.HelmChart#anvil",
 				"sdk.Namespace",
 				"sdk.HelmChart",
-				"sdk.ClientSideApply",
+				"sdk.Wait",
 			},
 		},
 		{
@@ -43,7 +43,7 @@ func TestComponent(t *testing.T) {
 				"sdk.HelmChart#aptos",
 				"sdk.Namespace",
 				"sdk.HelmChart",
-				"sdk.ClientSideApply",
+				"sdk.Wait",
 			},
 		},
 	}
@@ -108,7 +108,7 @@ func TestComponent(t *testing.T) {
 					namespace = c
 				case "sdk.HelmChart":
 					helmChart = c
-				case "sdk.ClientSideApply":
+				case "sdk.Wait":
 					waitFor = c
 				}
 			}
@@ -185,9 +185,8 @@ func TestComponent(t *testing.T) {
 				is.NotNil(obj)
 
 				is.Equal("crib.smartcontract.com/v1alpha1", *obj.ApiVersion())
-				is.Equal("ClientSideApply", *obj.Kind())
+				is.Equal("Wait", *obj.Kind())
 				is.Equal("crib.smartcontract.com", *obj.ApiGroup())
-				is.Equal("test-namespace", *obj.Metadata().Namespace())
 
 				json := dry.As[map[string]any](obj.ToJson())
 				is.NotNil(json)
@@ -195,16 +194,9 @@ func TestComponent(t *testing.T) {
 				is.NotNil(spec)
 
 				want := map[string]any{
-					"onFailure": "abort",
-					"action":    "kubectl",
-					"args": []any{
-						"wait",
-						"-n", "test-namespace",
-						"--for=condition=ready",
-						"pod",
-						"-l=app.kubernetes.io/component=test-blockchain,app.kubernetes.io/name=devspace-app",
-						"--timeout=600s",
-					},
+					"condition": "pod-ready",
+					"namespace": "test-namespace",
+					"selector":  "app.kubernetes.io/component=test-blockchain,app.kubernetes.io/name=devspace-app",
 				}
 				is.Equal(want, spec)
 			})
@@ -10,11 +10,12 @@ import (
 	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 
 	jdv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/charts/jd/v1"
 	postgresv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/charts/postgres/v1"
-	clientsideapply "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
 	helmchartv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/helmchart/v1"
+	wait "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
 const ComponentName = "sdk.composite.chainlink.jd.v1"
@@ -79,18 +80,10 @@ func jdComposite(ctx context.Context, props crib.Props) (crib.Component, error)
 		fmt.Sprintf("statefulset.kubernetes.io/pod-name=%s-0", jdProps.DB.ReleaseName),
 		"app.kubernetes.io/name=postgresql",
 	}
-	waitForDB, err := clientsideapply.New(ctx, &clientsideapply.Props{
+	waitForDB, err := wait.New(ctx, &wait.Props{
 		Namespace: jdProps.DB.Namespace,
-		OnFailure: "abort",
-		Action:    "kubectl",
-		Args: []string{
-			"wait",
-			"-n", jdProps.DB.Namespace,
-			"--for=condition=ready",
-			"pod",
-			fmt.Sprintf("-l=%s", strings.Join(labels, ",")),
-			"--timeout=600s",
-		},
+		Condition: domain.WaitPodReady,
+		Selector:  strings.Join(labels, ","),
 	})
 	if err != nil {
 		return nil, err
@@ -112,28 +105,19 @@ func jdComposite(ctx context.Context, props crib.Props) (crib.Component, error)
 		fmt.Sprintf("app.kubernetes.io/component=%s", jdProps.JD.ReleaseName),
 		"app.kubernetes.io/name=devspace-app",
 	}
-	waitForJD, err := clientsideapply.New(ctx, &clientsideapply.Props{
+	waitForJD, err := wait.New(ctx, &wait.Props{
 		Namespace: jdProps.JD.Namespace,
-		OnFailure: "abort",
-		Action:    "kubectl",
-		Args: []string{
-			"wait",
-			"-n", jdProps.JD.Namespace,
-			"--for=condition=ready",
-			"pod",
-			fmt.Sprintf("-l=%s", strings.Join(labels, ",")),
-			"--timeout=600s",
-		},
+		Condition: domain.WaitPodReady,
+		Selector:  strings.Join(labels, ","),
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Set up dependencies
-	// Use ComponentState to access the underlying chart components from Result objects
 	waitForDB.Node().AddDependency(pg)
-	jd.Node().AddDependency(crib.ComponentState[*clientsideapply.Result](waitForDB).Component)
-	crib.ComponentState[*clientsideapply.Result](waitForJD).Component.Node().AddDependency(jd)
+	jd.Node().AddDependency(waitForDB.Component)
+	waitForJD.Node().AddDependency(jd)
 
 	return waitForJD, nil
 }
@@ -63,9 +63,9 @@ func TestComponent(t *testing.T) {
 		"sdk.Namespace",
 		"sdk.Namespace",
 		"sdk.HelmChart",
-		"sdk.ClientSideApply",
+		"sdk.Wait",
 		"sdk.HelmChart",
-		"sdk.ClientSideApply",
+		"sdk.Wait",
 	}
 	is.Equal(wantCharts, gotCharts, "Expected charts should match actual charts")
 	is.Len(*app.Charts(), len(wantCharts), "Number of charts should match expected count")
@@ -82,8 +82,8 @@ func TestComponent(t *testing.T) {
 			postgres = c
 		case "sdk.HelmChart#jd":
 			jd = c
-		case "sdk.ClientSideApply":
-			// Get the last ClientSideApply (JD wait)
+		case "sdk.Wait":
+			// Get the last Wait (JD wait)
 			waitFor = c
 		}
 	}
@@ -93,19 +93,18 @@ func TestComponent(t *testing.T) {
 	is.NotNil(jd, "JD chart should be present")
 	is.NotNil(waitFor, "WaitFor chart should be present")
 
-	// Test ClientSideApply configuration
-	t.Run("ClientSideApply", func(t *testing.T) {
+	// Test Wait configuration
+	t.Run("Wait", func(t *testing.T) {
 		var obj cdk8s.ApiObject
 		is.NotPanics(func() {
 			obj = cdk8s.ApiObject_Of(waitFor.Node().DefaultChild())
 		}, "Should not panic when getting default child")
-		is.NotNil(obj, "ClientSideApply object should not be nil")
+		is.NotNil(obj, "Wait object should not be nil")
 
 		// Verify object metadata
 		is.Equal("crib.smartcontract.com/v1alpha1", *obj.ApiVersion(), "API version should match")
-		is.Equal("ClientSideApply", *obj.Kind(), "Kind should be ClientSideApply")
+		is.Equal("Wait", *obj.Kind(), "Kind should be Wait")
 		is.Equal("crib.smartcontract.com", *obj.ApiGroup(), "API group should match")
-		is.Equal("test-namespace", *obj.Metadata().Namespace(), "Namespace should match")
 
 		// Verify object specification
 		json := dry.As[map[string]any](obj.ToJson())
@@ -114,16 +113,9 @@ func TestComponent(t *testing.T) {
 		is.NotNil(spec, "Spec should not be nil")
 
 		want := map[string]any{
-			"onFailure": "abort",
-			"action":    "kubectl",
-			"args": []any{
-				"wait",
-				"-n", "test-namespace",
-				"--for=condition=ready",
-				"pod",
-				"-l=app.kubernetes.io/component=test-jd,app.kubernetes.io/name=devspace-app",
-				"--timeout=600s",
-			},
+			"condition": "pod-ready",
+			"namespace": "test-namespace",
+			"selector":  "app.kubernetes.io/component=test-jd,app.kubernetes.io/name=devspace-app",
 		}
 		is.Equal(want, spec, "Spec should match expected configuration")
 	})
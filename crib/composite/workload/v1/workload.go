@@ -12,11 +12,17 @@ import (
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
 	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 
-	clientsideapply "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
 	ingressv1 "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/ingress/v1"
 	servicev1 "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/service/v1"
+	wait "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
+// rolloutKinds maps the resource types of workloads to the kinds that their rollout is waited for with.
+var rolloutKinds = map[string]string{
+	"deployment":  "Deployment",
+	"statefulset": "StatefulSet",
+}
+
 // WorkloadResource defines the common interface for workload resources.
 type WorkloadResource interface {
 	// Component provides the crib.Component interface
@@ -65,19 +71,15 @@ func (p *ExposeViaServiceProps) Validate(ctx context.Context) error {
 // WaitForRollout sets up a dependency that ensures the system waits for a workload
 // to fully roll out before proceeding.
 func WaitForRollout(ctx context.Context, resource WorkloadResource) error {
-	resourceType := resource.GetResourceType()
-	waitFor, err := clientsideapply.New(ctx, &clientsideapply.Props{
+	kind, ok := rolloutKinds[resource.GetResourceType()]
+	if !ok {
+		return fmt.Errorf("failed to wait for rollout: unsupported resource type %q", resource.GetResourceType())
+	}
+	waitFor, err := wait.New(ctx, &wait.Props{
 		Namespace: resource.GetNamespace(),
-		OnFailure: "abort",
-		Action:    "kubectl",
-		Args: []string{
-			"rollout",
-			"status",
-			resourceType,
-			"-l",
-			fmt.Sprintf("app.kubernetes.io/instance=%s", resource.GetAppInstance()),
-			"--timeout=600s",
-		},
+		Condition: domain.WaitRollout,
+		Kind:      kind,
+		Name:      resource.GetName(),
 	})
 	if err != nil {
 		return dry.Wrapf(err, "failed to wait for rollout")
@@ -2,6 +2,7 @@ package crib_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -82,7 +83,8 @@ func TestCompositeErrRegistration(t *testing.T) {
 	assert.ErrorContains(t, err, "sdk.composite.ErrScalar")
 	assert.ErrorContains(t, err, "missing Apply method")
 	assert.ErrorContains(t, err, ".SimpleProducer")
-	assert.ErrorContains(t, err, "with non-zero required arguments")
+	// NewSimpleProducer returns a closure, which is not a component.
+	assert.ErrorContains(t, err, `crib_test.NewSimpleProducer@func() *crib_test.SimpleProducer", does not implement Composite`)
 	assert.Nil(t, res)
 	t.Logf("Got error: %v", err)
 }
@@ -101,4 +103,143 @@ func TestCompositeE2E(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Implements(t, (*crib.Component)(nil), res)
+	if outputs := crib.Outputs[*SimpleResult](res); assert.Len(t, outputs, 1) {
+		assert.Equal(t, "Hello, World!", outputs[0].Arg)
+	}
+}
+
+type (
+	NamedConsumer struct {
+		got *SimpleResult
+	}
+
+	NamedParams struct {
+		crib.In
+		Result *SimpleResult `name:"second"`
+	}
+)
+
+func (n *NamedConsumer) Apply(params NamedParams) {
+	n.got = params.Result
+}
+
+func (*NamedConsumer) String() string {
+	return "sdk.composite.NamedConsumer"
+}
+
+func TestCompositeNamed(t *testing.T) {
+	ctx := t.Context()
+	app := crib.NewTestApp(t)
+	ctx = internal.ContextWithConstruct(ctx, app.Chart)
+
+	consumer := &NamedConsumer{}
+	composite := crib.NewComposite(
+		crib.Named("first", NewSimpleProducer("first")),
+		crib.Named("second", NewSimpleProducer("second")),
+		func() *NamedConsumer { return consumer },
+	)
+
+	_, err := composite(ctx)
+	assert.NoError(t, err)
+	if assert.NotNil(t, consumer.got) {
+		assert.Equal(t, "second", consumer.got.Arg)
+	}
+}
+
+type ConfiguredConsumer struct {
+	got      any
+	replicas int
+}
+
+func (c *ConfiguredConsumer) Apply() {}
+
+func TestCompositeConstructorInjection(t *testing.T) {
+	replicas := crib.Param[int]("composite-replicas", 1)
+	ctx := internal.ContextWithParams(t.Context(), map[string]any{"composite-replicas": 3})
+	app := crib.NewTestApp(t)
+	ctx = internal.ContextWithConstruct(ctx, app.Chart)
+
+	var consumer *ConfiguredConsumer
+	newConsumer := func(ctx context.Context, res *SimpleResult, v *crib.Validator, params crib.ParamValues) (*ConfiguredConsumer, error) {
+		if ctx == nil || v == nil {
+			return nil, errors.New("missing builtins")
+		}
+		consumer = &ConfiguredConsumer{got: res.Arg, replicas: replicas.From(params)}
+		return consumer, nil
+	}
+
+	_, err := crib.NewComposite(NewSimpleProducer("upstream"), newConsumer)(ctx)
+	assert.NoError(t, err)
+	if assert.NotNil(t, consumer) {
+		assert.Equal(t, "upstream", consumer.got)
+		assert.Equal(t, 3, consumer.replicas)
+	}
+
+	failing := func(*SimpleResult) (*ConfiguredConsumer, error) {
+		return nil, errors.New("upstream is not ready")
+	}
+	ctx = internal.ContextWithConstruct(ctx, crib.NewTestApp(t).Chart)
+	_, err = crib.NewComposite(NewSimpleProducer("upstream"), failing)(ctx)
+	assert.ErrorContains(t, err, "upstream is not ready")
+}
+
+type (
+	Credentials struct {
+		User string
+	}
+
+	PublishingProducer struct{}
+
+	PublishedOutputs struct {
+		crib.Out
+		URL         string `name:"published-url"`
+		Credentials *Credentials
+	}
+
+	OptionalConsumer struct {
+		url         string
+		credentials *Credentials
+		missing     crib.Optional[*SimpleResult]
+	}
+
+	OptionalConsumerParams struct {
+		crib.In
+		URL         crib.Optional[string] `name:"published-url"`
+		Credentials *Credentials
+	}
+)
+
+func (*PublishingProducer) Apply() (PublishedOutputs, error) {
+	return PublishedOutputs{URL: "http://registry:5000", Credentials: &Credentials{User: "admin"}}, nil
+}
+
+func (*PublishingProducer) String() string {
+	return "sdk.composite.PublishingProducer"
+}
+
+func (c *OptionalConsumer) Apply(params OptionalConsumerParams, missing crib.Optional[*SimpleResult]) {
+	c.url, c.credentials, c.missing = params.URL.Value, params.Credentials, missing
+}
+
+func (*OptionalConsumer) String() string {
+	return "sdk.composite.OptionalConsumer"
+}
+
+func TestCompositeOptionalAndOut(t *testing.T) {
+	ctx := t.Context()
+	app := crib.NewTestApp(t)
+	ctx = internal.ContextWithConstruct(ctx, app.Chart)
+
+	consumer := &OptionalConsumer{}
+	res, err := crib.NewComposite(
+		func() *OptionalConsumer { return consumer },
+		func() *PublishingProducer { return &PublishingProducer{} },
+	)(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://registry:5000", consumer.url)
+	if assert.NotNil(t, consumer.credentials) {
+		assert.Equal(t, "admin", consumer.credentials.User)
+	}
+	assert.False(t, consumer.missing.Ok)
+	assert.Len(t, crib.Outputs[*Credentials](res), 1)
 }
@@ -8,6 +8,10 @@ import (
 	"github.com/smartcontractkit/crib-sdk/internal"
 )
 
+// Validator validates Props and sets their defaults. Constructors of Scalars within a Composite receive the
+// validator of the plan by declaring a *Validator parameter.
+type Validator = internal.Validator
+
 // ConstructFromContext retrieves the constructs.Construct from the context.
 func ConstructFromContext(ctx context.Context) constructs.Construct {
 	return internal.ConstructFromContext(ctx)
@@ -19,11 +23,11 @@ func ContextWithConstruct(ctx context.Context, c constructs.Construct) context.C
 }
 
 // ValidatorFromContext retrieves the validator from the context. If one does not exist, it is created.
-func ValidatorFromContext(ctx context.Context) *internal.Validator {
+func ValidatorFromContext(ctx context.Context) *Validator {
 	return internal.ValidatorFromContext(ctx)
 }
 
 // ContextWithValidator creates a new context with the supplied validator value.
-func ContextWithValidator(ctx context.Context, v *internal.Validator) context.Context {
+func ContextWithValidator(ctx context.Context, v *Validator) context.Context {
 	return internal.ContextWithValidator(ctx, v)
 }
@@ -0,0 +1,169 @@
+package crib
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/smartcontractkit/crib-sdk/internal"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+	"github.com/smartcontractkit/crib-sdk/internal/core/service"
+)
+
+type (
+	// Parameter is a typed parameter of a Plan, created with Param and declared on the Plan with Params.
+	Parameter = port.Parameter
+
+	// ParamValues holds the values of the parameters that a plan is applied with, by parameter name.
+	// Constructors of Scalars within a Composite receive it by declaring a ParamValues parameter.
+	ParamValues = service.ParamValues
+
+	// TypedParam is a plan parameter holding a value of type T. Its value is read inside a
+	// ComponentFunc with Value.
+	TypedParam[T any] struct {
+		name     string
+		def      T
+		usage    string
+		validate string
+	}
+
+	// ParamOpt is a function that modifies a TypedParam.
+	ParamOpt func(*paramOptions)
+
+	paramOptions struct {
+		usage    string
+		validate string
+	}
+)
+
+// Param creates a new plan parameter with the given name and default value. The parameter must be
+// declared on a Plan with Params, after which its value can be set when the plan is applied, e.g.
+// with `cribctl plan apply <plan> --set nodes=6`.
+//
+// Components read the value of the parameter from the context passed to their ComponentFunc, so
+// components that depend on a parameter are created lazily:
+//
+//	nodes := crib.Param[int]("nodes", 4, crib.ParamValidate("min=1,max=10"))
+//	plan := crib.NewPlan("my-plan",
+//		crib.Params(nodes),
+//		crib.ComponentSet(func(ctx context.Context) (crib.Component, error) {
+//			return nodesetv1.Component(&nodesetv1.Props{Size: nodes.Value(ctx)})(ctx)
+//		}),
+//	)
+func Param[T any](name string, def T, opts ...ParamOpt) *TypedParam[T] {
+	var o paramOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &TypedParam[T]{
+		name:     name,
+		def:      def,
+		usage:    o.usage,
+		validate: o.validate,
+	}
+}
+
+// ParamUsage sets a short description of the parameter, shown by `cribctl plan preview`.
+func ParamUsage(usage string) ParamOpt {
+	return func(o *paramOptions) {
+		o.usage = usage
+	}
+}
+
+// ParamValidate sets the validation tag that the value of the parameter must satisfy, using the same
+// syntax as the validate struct tag of Props, e.g. "min=1,max=10" or "oneof=debug info".
+func ParamValidate(tag string) ParamOpt {
+	return func(o *paramOptions) {
+		o.validate = tag
+	}
+}
+
+// Params is a PlanOpt that declares the parameters of the plan. Parameters declared by child plans
+// can be set through the parent plan. Invoking this method multiple times appends the parameters.
+func Params(params ...Parameter) PlanOpt {
+	return func(p *Plan) {
+		p.params = append(p.params, params...)
+	}
+}
+
+// Name returns the name of the parameter.
+func (p *TypedParam[T]) Name() string {
+	return p.name
+}
+
+// Type returns the name of the Go type of the parameter value.
+func (p *TypedParam[T]) Type() string {
+	return reflect.TypeFor[T]().String()
+}
+
+// Default returns the default value of the parameter.
+func (p *TypedParam[T]) Default() any {
+	return p.def
+}
+
+// Usage returns the description of the parameter.
+func (p *TypedParam[T]) Usage() string {
+	return p.usage
+}
+
+// Value returns the value of the parameter for the plan being rendered, or its default value if
+// the parameter was not set.
+func (p *TypedParam[T]) Value(ctx context.Context) T {
+	return p.From(internal.ParamsFromContext(ctx))
+}
+
+// From returns the value of the parameter held by values, or its default value if the parameter was not set.
+func (p *TypedParam[T]) From(values ParamValues) T {
+	if v, ok := values[p.name].(T); ok {
+		return v
+	}
+	return p.def
+}
+
+// Resolve converts the raw value to T and validates it. String values, e.g. from the command line,
+// are decoded as YAML, so that "6" sets an int and "[a, b]" sets a []string. Other values, e.g. from
+// a config file, are converted through their YAML representation. A nil raw value resolves to the default.
+func (p *TypedParam[T]) Resolve(ctx context.Context, raw any) (any, error) {
+	v := p.def
+	if raw != nil {
+		var err error
+		if v, err = decodeParam[T](raw); err != nil {
+			return nil, fmt.Errorf("parameter %q: invalid %s value %v: %w", p.name, p.Type(), raw, err)
+		}
+	}
+
+	validator := internal.ValidatorFromContext(ctx)
+	if p.validate != "" {
+		if err := validator.Var(v, p.validate); err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", p.name, err)
+		}
+	}
+	if reflect.TypeFor[T]().Kind() == reflect.Struct {
+		if err := validator.Struct(&v); err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", p.name, err)
+		}
+	}
+	return v, nil
+}
+
+// decodeParam converts a raw parameter value to T.
+func decodeParam[T any](raw any) (T, error) {
+	var v T
+	s, isString := raw.(string)
+	switch sp, ok := any(&v).(*string); {
+	case isString && ok:
+		// Strings are taken verbatim, so that values such as "0123" or "yes" are not reinterpreted.
+		*sp = s
+		return v, nil
+	case !isString:
+		b, err := yaml.Marshal(raw)
+		if err != nil {
+			return v, err
+		}
+		s = string(b)
+	}
+	err := yaml.Unmarshal([]byte(s), &v)
+	return v, err
+}
@@ -0,0 +1,66 @@
+package crib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal"
+)
+
+func TestParamResolve(t *testing.T) {
+	t.Parallel()
+
+	type settings struct {
+		Level string `yaml:"level" validate:"oneof=debug info"`
+	}
+
+	ctx := t.Context()
+	tests := []struct {
+		desc    string
+		param   Parameter
+		raw     any
+		want    any
+		wantErr string
+	}{
+		{desc: "default", param: Param("nodes", 4), want: 4},
+		{desc: "int from string", param: Param("nodes", 4), raw: "6", want: 6},
+		{desc: "int from config", param: Param("nodes", 4), raw: 6, want: 6},
+		{desc: "string verbatim", param: Param("tag", "latest"), raw: "0123", want: "0123"},
+		{desc: "slice from string", param: Param[[]string]("chains", nil), raw: "[a, b]", want: []string{"a", "b"}},
+		{desc: "struct from config", param: Param("log", settings{Level: "info"}), raw: map[string]any{"level": "debug"}, want: settings{Level: "debug"}},
+		{desc: "invalid value", param: Param("nodes", 4), raw: "many", wantErr: `parameter "nodes": invalid int value many`},
+		{desc: "validate tag", param: Param("nodes", 4, ParamValidate("min=1")), raw: "0", wantErr: `parameter "nodes"`},
+		{desc: "validate struct", param: Param("log", settings{Level: "info"}), raw: map[string]any{"level": "trace"}, wantErr: `parameter "log"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.param.Resolve(ctx, tc.raw)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParamValue(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	nodes := Param("nodes", 4, ParamUsage("Number of nodes"))
+	is.Equal("nodes", nodes.Name())
+	is.Equal("int", nodes.Type())
+	is.Equal("Number of nodes", nodes.Usage())
+
+	is.Equal(4, nodes.Value(t.Context()))
+	ctx := internal.ContextWithParams(t.Context(), map[string]any{"nodes": 6})
+	is.Equal(6, nodes.Value(ctx))
+
+	p := NewPlan("p", Params(nodes), Params(Param("tag", "latest")))
+	is.Len(p.Params(), 2)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"iter"
 	"strings"
+	"time"
 
 	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
 	"github.com/samber/lo"
@@ -24,6 +25,9 @@ type (
 
 	ComponentFuncs = []port.ComponentFunc
 
+	// BundleReport is the outcome of applying a single manifest bundle of a Plan.
+	BundleReport = domain.BundleReport
+
 	// A Plan is created by a call to NewPlan and is used to create an application release plan.
 	// It represents an intention to apply a set of resources in the prescribed order.
 	//
@@ -49,6 +53,10 @@ type (
 		childFuncs []func() *Plan
 		// resolvers is a list of resolvers that are part of the plan.
 		resolvers []cdk8s.IResolver
+		// params is a list of parameters declared by the plan.
+		params []Parameter
+		// wait are the defaults of the wait conditions of the plan, see WaitDefaults.
+		wait domain.WaitDefaults
 	}
 
 	PlanState struct {
@@ -131,6 +139,16 @@ func (p *Plan) ChildPlans() Plans {
 	})
 }
 
+// Params returns the parameters declared by the plan. Parameters of child plans are not included.
+func (p *Plan) Params() []Parameter {
+	return p.params
+}
+
+// WaitDefaults returns the timeout and poll interval of the wait conditions that do not set their own.
+func (p *Plan) WaitDefaults() domain.WaitDefaults {
+	return p.wait
+}
+
 // Resolvers returns a list of resolvers that are part of the plan.
 func (p *Plan) Resolvers() []cdk8s.IResolver {
 	return iresolver.Resolvers(p.resolvers)
@@ -163,6 +181,22 @@ func Namespace(ns string) PlanOpt {
 	}
 }
 
+// WaitDefaults sets the timeout and poll interval of the wait conditions that do not set their own,
+// such as the readiness checks of HelmCharts. A zero value keeps the default of cribctl. The defaults
+// of the applied plan apply to the wait conditions of its child plans, and can be overridden from the
+// command line with --wait-timeout and --wait-interval.
+//
+// Example:
+//
+//	plan := crib.NewPlan("my-plan",
+//		crib.WaitDefaults(20*time.Minute, 5*time.Second),
+//	)
+func WaitDefaults(timeout, interval time.Duration) PlanOpt {
+	return func(p *Plan) {
+		p.wait = domain.WaitDefaults{Timeout: timeout, Interval: interval}
+	}
+}
+
 // ComponentSet adds the components to add to the Plan. Components will be applied in the order they are added.
 // Invoking this method multiple times will append the components to the existing list.
 func ComponentSet(cs ...ComponentFunc) PlanOpt {
@@ -201,6 +235,9 @@ func ImagePullSecrets(secrets ...string) PlanOpt {
 // Apply applies a Plan on the target cluster. It first resolves all dependencies, finding
 // any cyclic dependencies and rendering the intent to a directory. It then attempts to
 // apply each intent on the cluster.
+//
+// If applying any intent fails, an error aggregating all failures is returned alongside the
+// PlanState, whose Report describes the outcome of each intent.
 func (p *Plan) Apply(ctx context.Context) (*PlanState, error) {
 	fh, err := filehandler.NewTempHandler(ctx, p.Name())
 	if err != nil {
@@ -215,17 +252,43 @@ func (p *Plan) Apply(ctx context.Context) (*PlanState, error) {
 		return nil, err
 	}
 	state, err := intent.Apply(ctx)
+	if state == nil {
+		return nil, err
+	}
+	return &PlanState{results: state}, err
+}
+
+// Destroy tears down a Plan on the target cluster. The plan is rendered exactly as it would be
+// for Apply, then each intent is reversed in the opposite order: Kubernetes resources are deleted
+// and ClientSideApply steps that declare an undo step, such as creating a kind cluster, are undone.
+func (p *Plan) Destroy(ctx context.Context) (*PlanState, error) {
+	fh, err := filehandler.NewTempHandler(ctx, p.Name())
+	if err != nil {
+		return nil, fmt.Errorf("creating file handler: %w", err)
+	}
+	svc, err := service.NewPlanService(ctx, fh)
+	if err != nil {
+		return nil, err
+	}
+	intent, err := svc.CreatePlan(ctx, p.Build())
+	if err != nil {
+		return nil, err
+	}
+	state, err := intent.Destroy(ctx)
 	return dry.Wrap2(&PlanState{results: state}, err)
 }
 
 // Build resolves the plan DAG by using lazy DFS traversal and cycle detection. That is, cycles are only detected
 // during resolution, not during plan creation. The resulting Plan has all child plans resolved and is ready for
 // application. If a cycle is detected, it will panic with a human-readable error message.
+//
+// Plans are identified by name, so a plan that is included by several parents (a diamond) is resolved
+// once and the same instance is shared by all of its parents.
 func (p *Plan) Build() port.Planner {
 	var (
-		visited = make(map[string]struct{})
-		stack   = make(map[string]bool)
-		frames  []string
+		resolved = make(map[string]*Plan)
+		stack    = make(map[string]bool)
+		frames   []string
 	)
 
 	var resolveFn func(p *Plan) *Plan
@@ -237,8 +300,8 @@ func (p *Plan) Build() port.Planner {
 			panic(renderCycle(cycle))
 		}
 
-		if _, ok := visited[p.Name()]; ok {
-			return p
+		if plan, ok := resolved[p.Name()]; ok {
+			return plan
 		}
 
 		stack[p.Name()] = true
@@ -246,13 +309,12 @@ func (p *Plan) Build() port.Planner {
 
 		for _, fn := range p.childFuncs {
 			child := fn() // Resolve the child plan.
-			resolved := resolveFn(child)
-			p.childPlans = append(p.childPlans, resolved)
+			p.childPlans = append(p.childPlans, resolveFn(child))
 		}
 
 		frames = frames[:len(frames)-1] // pop the current frame
 		stack[p.Name()] = false
-		visited[p.Name()] = struct{}{}
+		resolved[p.Name()] = p
 		p.childFuncs = nil // clear child functions after resolution
 		return p
 	}
@@ -264,10 +326,13 @@ func (p *Plan) Build() port.Planner {
 // IDs take the form of the following (any are valid):
 // - sdk.HelmChart#telepresence
 // - sdk.HelmChart#telepresence-1bbec390
+// - sdk.HelmChart-1bbec390
 // - sdk.Namespace
 //
 // If providing an ID with a hash, the hash will be stripped and the ID will be
-// extracted to match the ID of the component in the plan results.
+// extracted to match the ID of the component in the plan results. The name after
+// "#" narrows the match to the components that contain a construct with that ID,
+// or that render a Kubernetes resource or a Helm release with that name.
 //
 // Example:
 //
@@ -277,9 +342,8 @@ func (p *Plan) Build() port.Planner {
 //		// Use the component.
 //	}
 func (s *PlanState) ComponentByName(id string) iter.Seq[Component] {
-	id = ExtractResource(dry.ToPtr(id))
 	return func(yield func(Component) bool) {
-		for construct := range s.results.Get(id) {
+		for construct := range s.results.Match(id) {
 			if !yield(dry.As[Component](construct.Component())) {
 				return
 			}
@@ -312,6 +376,19 @@ func (s *PlanState) ComponentIDs() iter.Seq[string] {
 	}
 }
 
+// Report returns the outcome of each manifest bundle that was processed while applying the plan,
+// in the order in which they were processed.
+//
+// Example:
+//
+//	state, err := plan.Apply(ctx)
+//	for _, r := range state.Report() {
+//		fmt.Println(r.Bundle, r.Status, r.Duration)
+//	}
+func (s *PlanState) Report() []BundleReport {
+	return s.results.Report
+}
+
 // ComponentState returns the state of a component as a specific type T.
 //
 // Example:
@@ -324,6 +401,30 @@ func ComponentState[T any](c Component) T {
 	return dry.MustAs[T](c)
 }
 
+// CompositeOutputs returns the values of type T produced by the Scalars of every Composite in the plan, in the
+// order in which the Composites were applied. See Outputs for the order of the values of a single Composite.
+//
+// Example:
+//
+//	state, err := plan.Apply(ctx)
+//	for _, registry := range crib.CompositeOutputs[*DockerResults](state) {
+//		fmt.Println(registry.Host(), registry.Port())
+//	}
+func CompositeOutputs[T any](state *PlanState) []T {
+	var outputs []T
+	for c := range state.Components() {
+		outputs = append(outputs, Outputs[T](c)...)
+	}
+	return outputs
+}
+
+// Outputs returns the values of type T produced by the Scalars of the Composite that c is the result of, in the
+// order in which the Scalars were registered. If T is an interface, the values of every type implementing it are
+// returned. It returns nil if c is not the result of a Composite, see NewComposite.
+func Outputs[T any](c Component) []T {
+	return service.Outputs[T](c)
+}
+
 func renderCycle(frames []string) string {
 	var sb strings.Builder
 	sb.WriteString("Plan dependency cycle detected:\n")
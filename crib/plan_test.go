@@ -6,6 +6,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal"
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/plancache"
+	"github.com/smartcontractkit/crib-sdk/internal/core/service"
 )
 
 func TestChildCycle(t *testing.T) {
@@ -110,3 +114,96 @@ func TestBuild(t *testing.T) {
 	is.Len(plan.ChildPlans()[0].Components(), 1, "Child plan p2 should have 1 component")
 	is.Len(plan.ChildPlans()[1].Components(), 1, "Child plan p3 should have 1 component")
 }
+
+func TestBuildDiamond(t *testing.T) {
+	t.Parallel()
+
+	var (
+		p1, p2, p3, p4 func() *Plan
+
+		testComponent = func() ComponentFunc {
+			return func(ctx context.Context) (Component, error) {
+				return nil, nil
+			}
+		}
+	)
+
+	// p1 depends on p2 and p3, which both depend on p4.
+	{
+		p1 = func() *Plan {
+			return NewPlan("p1",
+				ComponentSet(testComponent()),
+				AddPlan(p2),
+				AddPlan(p3),
+			)
+		}
+		p2 = func() *Plan {
+			return NewPlan("p2",
+				ComponentSet(testComponent()),
+				AddPlan(p4),
+			)
+		}
+		p3 = func() *Plan {
+			return NewPlan("p3",
+				ComponentSet(testComponent()),
+				AddPlan(p4),
+			)
+		}
+		p4 = func() *Plan {
+			return NewPlan("p4",
+				ComponentSet(testComponent()),
+			)
+		}
+	}
+	is := assert.New(t)
+	must := require.New(t)
+
+	plan := p1()
+	is.NotPanics(func() {
+		plan.Build()
+	})
+
+	must.Len(plan.ChildPlans(), 2)
+	p2Plan, p3Plan := plan.ChildPlans()[0], plan.ChildPlans()[1]
+	must.Len(p2Plan.ChildPlans(), 1)
+	must.Len(p3Plan.ChildPlans(), 1)
+	is.Equal("p4", p2Plan.ChildPlans()[0].Name())
+	is.Same(p2Plan.ChildPlans()[0], p3Plan.ChildPlans()[0], "p4 should be resolved once and shared by p2 and p3")
+}
+
+type outputProducer struct {
+	value string
+}
+
+func (p *outputProducer) Apply() *string {
+	return &p.value
+}
+
+func (p *outputProducer) String() string {
+	return "sdk.composite.outputProducer." + p.value
+}
+
+func TestCompositeOutputs(t *testing.T) {
+	app := NewTestApp(t)
+	ctx := internal.ContextWithConstruct(t.Context(), app.Chart)
+
+	results := plancache.New()
+	for _, values := range [][]string{{"a", "b"}, {"c"}} {
+		var ctors []any
+		for _, v := range values {
+			ctors = append(ctors, func() *outputProducer { return &outputProducer{value: v} })
+		}
+		component, err := NewComposite(ctors...)(ctx)
+		require.NoError(t, err)
+		results.Add(component)
+	}
+	state := &PlanState{results: &service.PlanState{Results: results}}
+
+	var got []string
+	for _, v := range CompositeOutputs[*string](state) {
+		got = append(got, *v)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+	assert.Empty(t, CompositeOutputs[int](state))
+	assert.Nil(t, Outputs[*string](app.Chart))
+}
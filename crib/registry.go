@@ -0,0 +1,164 @@
+package crib
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// versionSuffix matches the version at the end of a component name, e.g. "v1" in "sdk.composite.fake.v1".
+var versionSuffix = regexp.MustCompile(`\.(v\d+)$`)
+
+type (
+	// ComponentRegistration describes a component that can be created by its name, for example from a
+	// declarative plan file. It is created by RegisterComponent.
+	ComponentRegistration struct {
+		name        string
+		version     string
+		description string
+		props       reflect.Type
+		ctor        func(Props) ComponentFunc
+	}
+
+	// RegisterOpt is a function that modifies a ComponentRegistration.
+	RegisterOpt func(*ComponentRegistration)
+
+	// PropsField describes a field of the props of a registered component.
+	PropsField struct {
+		// Name is the path of the field within the props, e.g. "JD.Image". Fields of the elements of
+		// slices and maps are written as "NodeProps[].Image".
+		Name string
+		// Type is the Go type of the field.
+		Type string
+		// Default is the value of the default struct tag of the field, if any.
+		Default string
+		// Validate is the value of the validate struct tag of the field, if any.
+		Validate string
+	}
+)
+
+// RegisterComponent describes the component with the given name, which must be the ComponentName of the
+// component, and the constructor that creates it from its props. The type of the props is taken from
+// the constructor:
+//
+//	crib.RegisterComponent(nodesetv1.ComponentName, nodesetv1.Component,
+//		crib.ComponentDescription("A set of Chainlink nodes sharing a PostgreSQL database."),
+//	)
+//
+// The version of the component defaults to the version at the end of its name.
+func RegisterComponent[P any, PP interface {
+	*P
+	Props
+}](name string, ctor func(PP) ComponentFunc, opts ...RegisterOpt) ComponentRegistration {
+	r := ComponentRegistration{
+		name:  name,
+		props: reflect.TypeFor[P](),
+		ctor: func(props Props) ComponentFunc {
+			return ctor(props.(PP))
+		},
+	}
+	if m := versionSuffix.FindStringSubmatch(name); m != nil {
+		r.version = m[1]
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// ComponentVersion sets the version of a registered component, for components whose name does not end
+// with their version.
+func ComponentVersion(version string) RegisterOpt {
+	return func(r *ComponentRegistration) {
+		r.version = version
+	}
+}
+
+// ComponentDescription sets a short description of a registered component.
+func ComponentDescription(description string) RegisterOpt {
+	return func(r *ComponentRegistration) {
+		r.description = description
+	}
+}
+
+// Name returns the name of the component.
+func (r ComponentRegistration) Name() string {
+	return r.name
+}
+
+// Version returns the version of the component.
+func (r ComponentRegistration) Version() string {
+	return r.version
+}
+
+// Description returns the description of the component.
+func (r ComponentRegistration) Description() string {
+	return r.description
+}
+
+// PropsType returns the struct type of the props of the component.
+func (r ComponentRegistration) PropsType() reflect.Type {
+	return r.props
+}
+
+// NewProps returns a pointer to new, zero valued props of the component.
+func (r ComponentRegistration) NewProps() Props {
+	return reflect.New(r.props).Interface().(Props)
+}
+
+// Component returns the ComponentFunc that creates the component from the given props, which must have
+// been created by NewProps.
+func (r ComponentRegistration) Component(props Props) ComponentFunc {
+	return r.ctor(props)
+}
+
+// Fields returns the exported fields of the props of the component, in declaration order. The fields of
+// nested structs, including the elements of slices and maps of structs, follow the field that holds them.
+func (r ComponentRegistration) Fields() []PropsField {
+	return propsFields(r.props, "", map[reflect.Type]bool{r.props: true})
+}
+
+// propsFields returns the fields of the struct type t, prefixing their names. Struct types that are
+// already being visited are not expanded again, so that recursive types terminate.
+func propsFields(t reflect.Type, prefix string, visiting map[reflect.Type]bool) []PropsField {
+	var fields []PropsField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name := prefix + f.Name
+		fields = append(fields, PropsField{
+			Name:     name,
+			Type:     f.Type.String(),
+			Default:  f.Tag.Get("default"),
+			Validate: f.Tag.Get("validate"),
+		})
+
+		nested, suffix := f.Type, ""
+		for {
+			switch nested.Kind() {
+			case reflect.Pointer:
+				nested = nested.Elem()
+				continue
+			case reflect.Slice, reflect.Array, reflect.Map:
+				nested, suffix = nested.Elem(), suffix+"[]"
+				continue
+			}
+			break
+		}
+		if nested.Kind() != reflect.Struct || nested.PkgPath() == "" || visiting[nested] || isStdlib(nested) {
+			continue
+		}
+		visiting[nested] = true
+		fields = append(fields, propsFields(nested, name+suffix+".", visiting)...)
+		delete(visiting, nested)
+	}
+	return fields
+}
+
+// isStdlib reports whether the type is declared in the standard library, e.g. time.Time, whose fields
+// are not configured individually.
+func isStdlib(t reflect.Type) bool {
+	first, _, _ := strings.Cut(t.PkgPath(), "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,48 @@
+package crib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	testRegistryProps struct {
+		Name     string `default:"test" validate:"required"`
+		Timeout  time.Duration
+		Children []*testRegistryChild
+		Next     *testRegistryProps
+		hidden   string
+	}
+
+	testRegistryChild struct {
+		Port int `validate:"min=1"`
+	}
+)
+
+func (p *testRegistryProps) Validate(context.Context) error { return nil }
+
+func TestRegisterComponent(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	ctor := func(*testRegistryProps) ComponentFunc { return nil }
+	r := RegisterComponent("sdk.composite.test.v2", ctor, ComponentDescription("A test component."))
+	is.Equal("sdk.composite.test.v2", r.Name())
+	is.Equal("v2", r.Version())
+	is.Equal("A test component.", r.Description())
+	is.IsType(&testRegistryProps{}, r.NewProps())
+
+	r = RegisterComponent("sdk.Test", ctor, ComponentVersion("v1"))
+	is.Equal("v1", r.Version())
+
+	is.Equal([]PropsField{
+		{Name: "Name", Type: "string", Default: "test", Validate: "required"},
+		{Name: "Timeout", Type: "time.Duration"},
+		{Name: "Children", Type: "[]*crib.testRegistryChild"},
+		{Name: "Children[].Port", Type: "int", Validate: "min=1"},
+		{Name: "Next", Type: "*crib.testRegistryProps"},
+	}, r.Fields())
+}
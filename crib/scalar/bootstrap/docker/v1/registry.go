@@ -4,6 +4,7 @@ import (
 	"context"
 	"embed"
 	"os"
+	"slices"
 
 	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
@@ -33,7 +34,8 @@ func (p *Props) Validate(ctx context.Context) error {
 }
 
 // Component returns a new docker registry component. This utilizes a client-side apply
-// to create a local docker registry container with the specified name and port.
+// to create a local docker registry container with the specified name and port. When the
+// plan is destroyed, the container is removed.
 func Component(name, port string) crib.ComponentFunc {
 	return func(ctx context.Context) (crib.Component, error) {
 		props := &Props{Name: name, Port: port}
@@ -46,14 +48,19 @@ func Component(name, port string) crib.ComponentFunc {
 
 func dockerRegistry(ctx context.Context, props crib.Props) (crib.Component, error) {
 	registryProps := dry.MustAs[*Props](props)
+	env := []string{
+		"REGISTRY_NAME=" + registryProps.Name,
+		"REGISTRY_PORT=" + registryProps.Port,
+	}
 	c, err := clientsideapply.New(ctx, &clientsideapply.Props{
 		Namespace: domain.DefaultNamespace,
 		OnFailure: domain.FailureAbort,
 		Action:    domain.ActionCmd,
-		Args: []string{
-			"REGISTRY_NAME=" + registryProps.Name,
-			"REGISTRY_PORT=" + registryProps.Port,
-			scriptPath,
+		Args:      append(slices.Clone(env), scriptPath),
+		// Destroying the plan removes the registry container.
+		Undo: &clientsideapply.Undo{
+			Action: domain.ActionCmd,
+			Args:   append(slices.Clone(env), scriptPath, "delete"),
 		},
 	})
 	return dry.Wrap2(c, err)
@@ -58,6 +58,15 @@ func TestRegistryComponent(t *testing.T) {
 			"REGISTRY_PORT=5001",
 			scriptPath,
 		},
+		"undo": map[string]any{
+			"action": "cmd",
+			"args": []any{
+				"REGISTRY_NAME=test-registry",
+				"REGISTRY_PORT=5001",
+				scriptPath,
+				"delete",
+			},
+		},
 	}
 	is.Equal(want, spec)
 }
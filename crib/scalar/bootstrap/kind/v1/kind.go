@@ -5,6 +5,7 @@ import (
 	"embed"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
@@ -39,7 +40,8 @@ func (p *Props) Validate(ctx context.Context) error {
 
 // Component returns a new kind cluster component. This utilizes a client-side apply
 // to create a kind cluster with the specified name. The cluster is created using the
-// `kind create cluster` command with a default configuration file.
+// `kind create cluster` command with a default configuration file. When the plan is
+// destroyed, the cluster is deleted.
 func Component(name string) crib.ComponentFunc {
 	return func(ctx context.Context) (crib.Component, error) {
 		props := &Props{Name: name}
@@ -52,14 +54,19 @@ func Component(name string) crib.ComponentFunc {
 
 func kindCluster(ctx context.Context, props crib.Props) (crib.Component, error) {
 	kindProps := dry.MustAs[*Props](props)
+	env := []string{
+		fmt.Sprintf("KIND_CONFIG_FILE=%s", defaultsPath),
+		fmt.Sprintf("KIND_CLUSTER_NAME=%s", kindProps.Name),
+	}
 	c, err := clientsideapply.New(ctx, &clientsideapply.Props{
 		Namespace: domain.DefaultNamespace,
 		OnFailure: domain.FailureAbort,
 		Action:    domain.ActionCmd,
-		Args: []string{
-			fmt.Sprintf("KIND_CONFIG_FILE=%s", defaultsPath),
-			fmt.Sprintf("KIND_CLUSTER_NAME=%s", kindProps.Name),
-			scriptPath,
+		Args:      append(slices.Clone(env), scriptPath),
+		// Destroying the plan deletes the kind cluster.
+		Undo: &clientsideapply.Undo{
+			Action: domain.ActionCmd,
+			Args:   append(slices.Clone(env), scriptPath, "delete"),
 		},
 	})
 	return dry.Wrap2(c, err)
@@ -67,6 +67,15 @@ func TestNew(t *testing.T) {
 			"KIND_CLUSTER_NAME=test-cluster",
 			scriptPath,
 		},
+		"undo": map[string]any{
+			"action": "cmd",
+			"args": []any{
+				fmt.Sprintf("KIND_CONFIG_FILE=%s", defaultsPath),
+				"KIND_CLUSTER_NAME=test-cluster",
+				scriptPath,
+				"delete",
+			},
+		},
 	}
 	is.Equal(want, spec)
 }
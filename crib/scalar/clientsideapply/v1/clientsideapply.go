@@ -14,13 +14,27 @@
 //	kind: ClientSideApply
 //	spec:
 //		onFailure: <action> # Oneof continue, abort
-//		action: <action> # Oneof task, cribctl, cmd, kubectl
+//		action: <action> # A registered action, e.g. task, cribctl, cmd or kubectl
 //		args: # cribctl shown below
 //	   		- action
 //	   		- contract
 //	   		- -f values.yaml
 //	   		- -w values.yaml=contract.address=/spec/contracts/0/address
 //			- -w config.toml=/config/node/0
+//		undo: # Optional, executed when the plan is destroyed.
+//			action: <action> # A registered action
+//			args:
+//				- delete
+//		retries: 3 # Optional, number of times the step is run again after it failed.
+//		backoff: 5s # Optional, delay before the first retry, doubled for every further retry.
+//		timeout: 2m # Optional, limits each attempt.
+//		env: # Optional, takes precedence over the environment of cribctl.
+//			AWS_PROFILE: staging
+//		workingDir: ./contracts # Optional.
+//		stdin: <input> # Optional, written to the standard input of the command.
+//		outputs: # Optional, referenced by later steps and manifests as ${{ outputs.<name> }}.
+//			- name: contractAddress
+//			  regex: 'deployed at (0x[0-9a-fA-F]+)' # Or jsonPath: '{.address}', or file: ./address.txt
 package clientsideapplyv1
 
 import (
@@ -35,6 +49,9 @@ import (
 )
 
 const (
+	// ComponentName is the name of the component in the component registry.
+	ComponentName = "sdk.ClientSideApply"
+
 	apiVersion = "crib.smartcontract.com/v1alpha1"
 	kind       = "ClientSideApply"
 )
@@ -45,8 +62,53 @@ type (
 
 		// OnFailure is the action to take if the apply fails.
 		OnFailure string `default:"abort" validate:"required,oneof=continue abort"`
+		// Action is the action to take. It must be registered, see crib.RegisterAction, and accept the Args.
+		Action string `validate:"required,csa_action"`
+		// Args are the arguments to pass to the action.
+		Args []string `validate:"required,dive"`
+		// Undo is an optional step that reverses this step when the plan is destroyed.
+		// It is omitted from the encoded props when unset so that resource IDs remain stable.
+		Undo *Undo `json:",omitempty" validate:"omitempty"`
+		// Retries is the number of times the step is run again after it failed, e.g. for steps
+		// that depend on the network. At most 20 retries are allowed.
+		Retries int `json:",omitempty" validate:"gte=0,lte=20"`
+		// Backoff is the delay before the first retry, e.g. 5s. It doubles with every further retry
+		// up to 5m, and defaults to 1s.
+		Backoff string `json:",omitempty" validate:"omitempty,duration"`
+		// Timeout limits the duration of each attempt, e.g. 2m.
+		Timeout string `json:",omitempty" validate:"omitempty,duration"`
+		// Env holds environment variables of the action, which take precedence over the environment of cribctl.
+		Env map[string]string `json:",omitempty"`
+		// WorkingDir is the directory the action runs in.
+		WorkingDir string `json:",omitempty"`
+		// Stdin is written to the standard input of the action.
+		Stdin string `json:",omitempty"`
+		// Outputs are extracted once the step succeeded. Later steps and components reference them
+		// with OutputRef, and the references are resolved when the plan is applied.
+		Outputs []Output `json:",omitempty" validate:"omitempty,dive"`
+	}
+
+	// Output describes how a named output is extracted from a ClientSideApply step. Exactly one of
+	// Regex, JSONPath and File is set.
+	Output struct {
+		Name string `validate:"required,alphanum"`
+		// Regex matches the standard output of the step. The output is the first capture group,
+		// or the whole match if the expression has none.
+		Regex string `json:",omitempty" validate:"required_without_all=JSONPath File,excluded_with=JSONPath File"`
+		// JSONPath is evaluated against the standard output of the step parsed as JSON, e.g. {.address}.
+		JSONPath string `json:",omitempty" validate:"required_without_all=Regex File,excluded_with=Regex File"`
+		// File is read once the step succeeded, relative to the working directory of the step.
+		File string `json:",omitempty" validate:"required_without_all=Regex JSONPath,excluded_with=Regex JSONPath"`
+		// Sensitive outputs are masked in the output of the plan, and are not stored with its record.
+		// The step cannot be skipped when applying steps that reference them, nor can undo steps
+		// reference them.
+		Sensitive bool `json:",omitempty"`
+	}
+
+	// Undo describes the action that reverses a ClientSideApply step.
+	Undo struct {
 		// Action is the action to take.
-		Action string `validate:"required,oneof=cmd cribctl docker kind kubectl task"`
+		Action string `validate:"required,csa_action"`
 		// Args are the arguments to pass to the action.
 		Args []string `validate:"required,dive"`
 	}
@@ -58,6 +120,15 @@ type (
 	}
 )
 
+// OutputRef returns the placeholder that is replaced with the named output of a ClientSideApply step
+// when the plan is applied. It can be used in the args, env, working directory and stdin of later
+// steps, and in the values of Kubernetes manifests, e.g. a ConfigMap or the values of a Helm chart.
+// Steps and manifests referencing the output are applied after the step producing it, which must be
+// rendered before them, e.g. by adding it as a dependency.
+func OutputRef(name string) string {
+	return domain.OutputPlaceholder(name)
+}
+
 func (p *Props) Validate(ctx context.Context) error {
 	return internal.ValidatorFromContext(ctx).Struct(p)
 }
@@ -79,7 +150,7 @@ func New(ctx context.Context, props crib.Props) (crib.Component, error) {
 	chartProps := dry.MustAs[*Props](props)
 
 	parent := internal.ConstructFromContext(ctx)
-	chart := cdk8s.NewChart(parent, crib.ResourceID("sdk.ClientSideApply", props), nil)
+	chart := cdk8s.NewChart(parent, crib.ResourceID(ComponentName, props), nil)
 
 	obj := cdk8s.NewApiObject(chart, crib.ResourceID(domain.CDK8sResource, props), &cdk8s.ApiObjectProps{
 		ApiVersion: dry.ToPtr(apiVersion),
@@ -88,11 +159,50 @@ func New(ctx context.Context, props crib.Props) (crib.Component, error) {
 			Namespace: dry.ToPtr(chartProps.Namespace),
 		},
 	})
-	obj.AddJsonPatch(cdk8s.JsonPatch_Add(dry.ToPtr("/spec"), map[string]any{
+	spec := map[string]any{
 		"onFailure": chartProps.OnFailure,
 		"action":    chartProps.Action,
 		"args":      chartProps.Args,
-	}))
+	}
+	if chartProps.Undo != nil {
+		spec["undo"] = map[string]any{
+			"action": chartProps.Undo.Action,
+			"args":   chartProps.Undo.Args,
+		}
+	}
+	if chartProps.Retries > 0 {
+		spec["retries"] = chartProps.Retries
+	}
+	if len(chartProps.Env) > 0 {
+		spec["env"] = chartProps.Env
+	}
+	if len(chartProps.Outputs) > 0 {
+		outputs := make([]map[string]any, 0, len(chartProps.Outputs))
+		for _, o := range chartProps.Outputs {
+			output := map[string]any{"name": o.Name}
+			for key, value := range map[string]string{"regex": o.Regex, "jsonPath": o.JSONPath, "file": o.File} {
+				if value != "" {
+					output[key] = value
+				}
+			}
+			if o.Sensitive {
+				output["sensitive"] = true
+			}
+			outputs = append(outputs, output)
+		}
+		spec["outputs"] = outputs
+	}
+	for key, value := range map[string]string{
+		"backoff":    chartProps.Backoff,
+		"timeout":    chartProps.Timeout,
+		"workingDir": chartProps.WorkingDir,
+		"stdin":      chartProps.Stdin,
+	} {
+		if value != "" {
+			spec[key] = value
+		}
+	}
+	obj.AddJsonPatch(cdk8s.JsonPatch_Add(dry.ToPtr("/spec"), spec))
 	return &Result{
 		Component: chart,
 		Args:      append([]string{chartProps.Action}, chartProps.Args...),
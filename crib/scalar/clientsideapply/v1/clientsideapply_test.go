@@ -60,3 +60,169 @@ func TestNewClientSideApply(t *testing.T) {
 
 	internal.SynthAndSnapYamls(t, app)
 }
+
+func TestNewClientSideApplyUndo(t *testing.T) {
+	t.Parallel()
+	internal.JSIIKernelMutex.Lock()
+	defer internal.JSIIKernelMutex.Unlock()
+	is := assert.New(t)
+
+	app := internal.NewTestApp(t)
+	ctx := internal.ContextWithConstruct(t.Context(), app.Chart)
+
+	testProps := &Props{
+		Namespace: "test-namespace",
+		OnFailure: "abort",
+		Action:    "kind",
+		Args:      []string{"create", "cluster"},
+		Undo: &Undo{
+			Action: "kind",
+			Args:   []string{"delete", "cluster"},
+		},
+	}
+	is.NoError(testProps.Validate(ctx))
+
+	component, err := New(ctx, testProps)
+	is.NoError(err)
+	is.NotNil(component)
+
+	apply := (*app.Charts())[1]
+	obj := cdk8s.ApiObject_Of(apply)
+	want := map[string]any{
+		"onFailure": "abort",
+		"action":    "kind",
+		"args":      []any{"create", "cluster"},
+		"undo": map[string]any{
+			"action": "kind",
+			"args":   []any{"delete", "cluster"},
+		},
+	}
+	is.Equal(want, dry.As[map[string]any](obj.ToJson())["spec"])
+}
+
+func TestNewClientSideApplyRetries(t *testing.T) {
+	t.Parallel()
+	internal.JSIIKernelMutex.Lock()
+	defer internal.JSIIKernelMutex.Unlock()
+	is := assert.New(t)
+
+	app := internal.NewTestApp(t)
+	ctx := internal.ContextWithConstruct(t.Context(), app.Chart)
+
+	testProps := &Props{
+		OnFailure:  "abort",
+		Action:     "cmd",
+		Args:       []string{"aws", "sso", "login"},
+		Retries:    3,
+		Backoff:    "5s",
+		Timeout:    "2m",
+		Env:        map[string]string{"AWS_PROFILE": "staging"},
+		WorkingDir: "/tmp",
+		Stdin:      "y",
+	}
+	is.NoError(testProps.Validate(ctx))
+
+	_, err := New(ctx, testProps)
+	is.NoError(err)
+
+	apply := (*app.Charts())[1]
+	obj := cdk8s.ApiObject_Of(apply)
+	want := map[string]any{
+		"onFailure":  "abort",
+		"action":     "cmd",
+		"args":       []any{"aws", "sso", "login"},
+		"retries":    float64(3),
+		"backoff":    "5s",
+		"timeout":    "2m",
+		"env":        map[string]any{"AWS_PROFILE": "staging"},
+		"workingDir": "/tmp",
+		"stdin":      "y",
+	}
+	is.Equal(want, dry.As[map[string]any](obj.ToJson())["spec"])
+
+	testProps.Retries = 21
+	is.Error(testProps.Validate(ctx), "too many retries")
+	testProps.Backoff, testProps.Retries = "soon", -1
+	is.Error(testProps.Validate(ctx))
+}
+
+func TestNewClientSideApplyOutputs(t *testing.T) {
+	t.Parallel()
+	internal.JSIIKernelMutex.Lock()
+	defer internal.JSIIKernelMutex.Unlock()
+	is := assert.New(t)
+
+	app := internal.NewTestApp(t)
+	ctx := internal.ContextWithConstruct(t.Context(), app.Chart)
+
+	testProps := &Props{
+		OnFailure: "abort",
+		Action:    "cribctl",
+		Args:      []string{"deploy", "contract"},
+		Outputs: []Output{
+			{Name: "contractAddress", Regex: "deployed at (0x[0-9a-fA-F]+)"},
+			{Name: "csaKey", JSONPath: "{.csaKey}", Sensitive: true},
+		},
+	}
+	is.NoError(testProps.Validate(ctx))
+
+	_, err := New(ctx, testProps)
+	is.NoError(err)
+
+	apply := (*app.Charts())[1]
+	obj := cdk8s.ApiObject_Of(apply)
+	spec := dry.As[map[string]any](obj.ToJson())["spec"]
+	is.Equal([]any{
+		map[string]any{"name": "contractAddress", "regex": "deployed at (0x[0-9a-fA-F]+)"},
+		map[string]any{"name": "csaKey", "jsonPath": "{.csaKey}", "sensitive": true},
+	}, dry.As[map[string]any](spec)["outputs"])
+	is.Equal("${{ outputs.contractAddress }}", OutputRef("contractAddress"))
+
+	for _, invalid := range []Output{
+		{Name: "none"},
+		{Name: "both", Regex: ".*", File: "out.txt"},
+		{Name: "not-alphanumeric", File: "out.txt"},
+	} {
+		testProps.Outputs = []Output{invalid}
+		is.Error(testProps.Validate(ctx), invalid.Name)
+	}
+}
+
+func init() {
+	crib.RegisterAction(crib.Action{Name: "test-vault", Binary: "vault", Args: crib.MinArgs(2)})
+}
+
+func TestPropsValidateAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		props   *Props
+		wantErr bool
+	}{
+		{name: "built-in", props: &Props{Action: "kubectx", Args: []string{"kind-crib"}}},
+		{name: "registered", props: &Props{Action: "test-vault", Args: []string{"kv", "put"}}},
+		{name: "registered undo", props: &Props{
+			Action: "cmd", Args: []string{"true"},
+			Undo: &Undo{Action: "test-vault", Args: []string{"kv", "delete"}},
+		}},
+		{name: "unknown", props: &Props{Action: "terraform", Args: []string{"apply"}}, wantErr: true},
+		{name: "invalid args", props: &Props{Action: "test-vault", Args: []string{"kv"}}, wantErr: true},
+		{name: "invalid undo args", props: &Props{
+			Action: "cmd", Args: []string{"true"},
+			Undo: &Undo{Action: "kubens", Args: []string{"crib", "default"}},
+		}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.props.Validate(t.Context())
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "'csa_action' tag")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
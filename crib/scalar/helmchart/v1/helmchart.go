@@ -3,10 +3,13 @@
 package helmchart
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
@@ -14,18 +17,25 @@ import (
 	"github.com/aws/jsii-runtime-go"
 	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
 	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
 
 	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/helm"
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 	"github.com/smartcontractkit/crib-sdk/internal/core/port"
 
-	clientsideapply "github.com/smartcontractkit/crib-sdk/crib/scalar/clientsideapply/v1"
 	namespace "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/namespace/v1"
+	wait "github.com/smartcontractkit/crib-sdk/crib/scalar/wait/v1"
 )
 
-const helmBinaryName = "helm"
+const (
+	// ComponentName is the name of the component in the component registry.
+	ComponentName = "sdk.HelmChart"
+
+	helmBinaryName = "helm"
+)
 
 var helmBinary = sync.OnceValues(func() (string, error) {
 	prog, err := exec.LookPath(helmBinaryName)
@@ -54,12 +64,19 @@ func (c *ChartProps) Validate(ctx context.Context) error {
 	return v.Struct(c)
 }
 
+// Component returns a crib.ComponentFunc that creates a new Helm chart scalar component.
+func Component(props *ChartProps) crib.ComponentFunc {
+	return func(ctx context.Context) (crib.Component, error) {
+		return New(ctx, props)
+	}
+}
+
 // New creates a new Helm chart scalar component. A Helm Chart scalar can represent any Helm Chart entity.
 // Typically, a custom Helm Chart scalar should be created that depends on this component for ease of use.
 // This method will attempt to resolve the chart using a locally installed version of Helm.
 func New(parentCtx context.Context, props crib.Props) (component crib.Component, retErr error) {
 	var errs error
-	// cdk8s.NewHelm can panic if the chart is not found, so we need to handle that.
+	// cdk8s.NewInclude can panic if the rendered chart cannot be parsed, so we need to handle that.
 	defer func() {
 		if r := recover(); r != nil {
 			retErr = errors.Join(errs, fmt.Errorf("failed to create helm chart: %v", r))
@@ -83,20 +100,26 @@ func New(parentCtx context.Context, props crib.Props) (component crib.Component,
 	// The HelmChart component needs to exist in a cdk8s chart so that it can own
 	// the namespace of the deployed chart.
 	// TODO: Need to append the parent node id to the resource id so that it is not lost.
-	chart := cdk8s.NewChart(parent, crib.ResourceID("sdk.HelmChart", props), &cdk8s.ChartProps{
+	chart := cdk8s.NewChart(parent, crib.ResourceID(ComponentName, props), &cdk8s.ChartProps{
 		Namespace: dry.ToPtr(chartProps.Namespace),
 		Labels:    commonLabels,
 	})
 	ctx := internal.ContextWithConstruct(parentCtx, chart)
 
-	cmdProps, err := helmProps(prog, chartProps)
+	// Important: Rendering resolves the full helm chart, which may make a network call. helm template is
+	// run outside of the JSII kernel with the JSII lock released, so that other components are created
+	// meanwhile, and its output is included like cdk8s.NewHelm does.
+	var manifests string
+	internal.WithoutJSIILock(parentCtx, func() {
+		manifests, err = renderChart(parentCtx, prog, chartProps)
+	})
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(errs, err)
 	}
-	// Important: This method resolves the full helm chart, making a network call
-	// when initialized. This method panics if the chart cannot be resolved.
-	// It's important to catch and handle the panic.
-	deployment := cdk8s.NewHelm(chart, crib.ResourceID(chartProps.Chart, props), cmdProps)
+	defer os.RemoveAll(filepath.Dir(manifests))
+	deployment := cdk8s.NewInclude(chart, crib.ResourceID(chartProps.Chart, props), &cdk8s.IncludeProps{
+		Url: jsii.String(manifests),
+	})
 
 	// Ensure that the namespace is created before the chart.
 	ns, err := namespace.New(ctx, &namespace.Props{
@@ -107,7 +130,7 @@ func New(parentCtx context.Context, props crib.Props) (component crib.Component,
 	}
 	chart.Node().AddDependency(ns)
 
-	// If the chart should wait for resources to be ready before returning, we add a client-side apply to wait.
+	// If the chart should wait for resources to be ready before returning, we add a Wait for its pods.
 	if chartProps.WaitForReady {
 		labels := lo.MapToSlice(dry.FromPtr(commonLabels), func(label string, value *string) string {
 			return fmt.Sprintf("%s=%s", label, dry.FromPtr(value))
@@ -116,18 +139,10 @@ func New(parentCtx context.Context, props crib.Props) (component crib.Component,
 
 		// If the chart is configured to wait for resources to be ready, we need to add a dependency
 		// that will wait for the resources to be ready before returning.
-		waitFor, err := clientsideapply.New(parentCtx, &clientsideapply.Props{
+		waitFor, err := wait.New(parentCtx, &wait.Props{
 			Namespace: chartProps.Namespace,
-			OnFailure: "abort",
-			Action:    "kubectl",
-			Args: []string{
-				"wait",
-				"-n", chartProps.Namespace,
-				"--for=condition=ready",
-				"pod",
-				fmt.Sprintf("-l=%s", strings.Join(labels, ",")),
-				"--timeout=600s",
-			},
+			Condition: domain.WaitPodReady,
+			Selector:  strings.Join(labels, ","),
 		})
 		if err != nil {
 			return nil, errors.Join(errs, err)
@@ -138,36 +153,66 @@ func New(parentCtx context.Context, props crib.Props) (component crib.Component,
 	return chart, nil
 }
 
-// helmProps converts ChartProps to cdk8s.HelmProps. Depending on whether the chart is an OCI chart or a
-// regular Helm chart, it will set the appropriate fields.
-func helmProps(executable string, props *ChartProps) (*cdk8s.HelmProps, error) {
+// renderChart renders the chart with helm template into a file in a new temporary directory, and returns
+// the path of the file. The arguments are the ones cdk8s.NewHelm passes. Depending on whether the chart
+// is an OCI chart or a regular Helm chart, the chart is referenced differently.
+func renderChart(ctx context.Context, executable string, props *ChartProps) (string, error) {
+	dir, err := os.MkdirTemp("", "crib-helm-")
+	if err != nil {
+		return "", err
+	}
+	args, err := templateArgs(dir, props)
+	if err != nil {
+		return "", errors.Join(err, os.RemoveAll(dir))
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Join(
+			fmt.Errorf("failed to create helm chart %s: %w: %s", props.Chart, err, strings.TrimSpace(stderr.String())),
+			os.RemoveAll(dir),
+		)
+	}
+	path := filepath.Join(dir, "chart.yaml")
+	if err := os.WriteFile(path, stdout.Bytes(), 0o600); err != nil {
+		return "", errors.Join(err, os.RemoveAll(dir))
+	}
+	return path, nil
+}
+
+// templateArgs returns the arguments of helm template for the chart. The values are written to a file in dir.
+func templateArgs(dir string, props *ChartProps) ([]string, error) {
 	r := &helm.Release{
 		Name:        props.Name,
 		ReleaseName: props.ReleaseName,
 		Repository:  props.Repo,
 		Version:     props.Version,
 	}
+	chart, repo, version := props.Chart, props.Repo, props.Version
 	if r.IsOCI() {
-		// OCI charts use the full repository URL as the chart name and don't use ReleaseName or Repo.
-		return &cdk8s.HelmProps{
-			Chart:          jsii.String(r.PullRef()),
-			Namespace:      jsii.String(props.Namespace),
-			ReleaseName:    jsii.String(props.ReleaseName),
-			Values:         dry.ToPtr(props.Values),
-			Version:        jsii.String(r.ChartVersion().Version),
-			HelmExecutable: jsii.String(executable),
-			HelmFlags:      dry.PtrSlice(props.Flags),
-		}, nil
+		// OCI charts use the full repository URL as the chart name and don't use Repo.
+		chart, repo, version = r.PullRef(), "", r.ChartVersion().Version
 	}
 
-	return &cdk8s.HelmProps{
-		Chart:          jsii.String(props.Chart),
-		Namespace:      jsii.String(props.Namespace),
-		ReleaseName:    jsii.String(props.ReleaseName),
-		Repo:           jsii.String(props.Repo),
-		Values:         dry.ToPtr(props.Values),
-		Version:        jsii.String(props.Version),
-		HelmExecutable: jsii.String(executable),
-		HelmFlags:      dry.PtrSlice(props.Flags),
-	}, nil
+	args := []string{"template"}
+	if len(props.Values) > 0 {
+		values, err := yaml.Marshal(props.Values)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling values of helm chart %s: %w", props.Chart, err)
+		}
+		path := filepath.Join(dir, "overrides.yaml")
+		if err := os.WriteFile(path, values, 0o600); err != nil {
+			return nil, err
+		}
+		args = append(args, "-f", path)
+	}
+	for _, flag := range [][2]string{{"--repo", repo}, {"--version", version}, {"--namespace", props.Namespace}} {
+		if flag[1] != "" {
+			args = append(args, flag[:]...)
+		}
+	}
+	args = append(args, props.Flags...)
+	return append(args, props.ReleaseName, chart), nil
 }
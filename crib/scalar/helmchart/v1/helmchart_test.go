@@ -2,6 +2,9 @@ package helmchart
 
 import (
 	"bytes"
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gkampitakis/go-snaps/match"
@@ -10,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"github.com/smartcontractkit/crib-sdk/crib"
 	"github.com/smartcontractkit/crib-sdk/internal"
 )
 
@@ -134,6 +138,64 @@ func TestNewOCIHelmChart(t *testing.T) {
 	}
 }
 
+// concurrentHelm is a helm binary that renders a ConfigMap named after the release once two charts are
+// being rendered at the same time. It fails after 5 seconds.
+const concurrentHelm = `#!/bin/sh
+dir=$(dirname "$0")/started
+mkdir -p "$dir" && touch "$dir/$$"
+i=0
+while [ "$(ls "$dir" | wc -l)" -lt 2 ]; do
+	i=$((i + 1))
+	if [ "$i" -gt 100 ]; then
+		echo "charts were not rendered concurrently" >&2
+		exit 1
+	fi
+	sleep 0.05
+done
+eval "release=\${$(($# - 1))}"
+printf 'apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n' "$release"
+`
+
+// chartScalar applies a HelmChart within a Composite.
+type chartScalar struct {
+	props *ChartProps
+}
+
+func (s *chartScalar) Apply(ctx context.Context) (crib.Component, error) {
+	return New(ctx, s.props)
+}
+
+func (*chartScalar) String() string {
+	return "sdk.test.HelmChart"
+}
+
+// TestNewHelmChartConcurrently verifies that independent HelmCharts of a Composite are rendered at the
+// same time, as helm template runs without the JSII lock.
+func TestNewHelmChartConcurrently(t *testing.T) {
+	internal.JSIIKernelMutex.Lock()
+	t.Cleanup(internal.JSIIKernelMutex.Unlock)
+
+	// Not parallel, as the helm binary is replaced for the whole package.
+	prog := filepath.Join(t.TempDir(), "helm")
+	require.NoError(t, os.WriteFile(prog, []byte(concurrentHelm), 0o700))
+	original := helmBinary
+	helmBinary = func() (string, error) { return prog, nil }
+	t.Cleanup(func() { helmBinary = original })
+
+	app := internal.NewTestApp(t)
+	chart := func(name string) func() *chartScalar {
+		return func() *chartScalar {
+			return &chartScalar{props: &ChartProps{Name: name, Chart: "chart-" + name, Namespace: "ns-" + name, ReleaseName: name}}
+		}
+	}
+	_, err := crib.NewComposite(crib.Named("a", chart("a")), crib.Named("b", chart("b")))(app.Context())
+	require.NoError(t, err)
+
+	raw := *app.DisableSnapshots().SynthYaml()
+	assert.Contains(t, raw, "name: a\n")
+	assert.Contains(t, raw, "name: b\n")
+}
+
 type genericManifest map[string]any
 
 func unmarshalManifests(m []byte) []genericManifest {
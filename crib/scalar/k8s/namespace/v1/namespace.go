@@ -13,6 +13,9 @@ import (
 	cdk8splus "github.com/cdk8s-team/cdk8s-plus-go/cdk8splus30/v2"
 )
 
+// ComponentName is the name of the component in the component registry.
+const ComponentName = "sdk.Namespace"
+
 type Props struct {
 	Namespace string `default:"default" validate:"omitempty,lte=63,dns_rfc1035_label"`
 }
@@ -38,7 +41,7 @@ func Component(namespace string) crib.ComponentFunc {
 func New(ctx context.Context, props crib.Props) (crib.Component, error) {
 	chartProps := dry.MustAs[*Props](props)
 	parent := internal.ConstructFromContext(ctx)
-	c := cdk8s.NewChart(parent, crib.ResourceID("sdk.Namespace", props), nil)
+	c := cdk8s.NewChart(parent, crib.ResourceID(ComponentName, props), nil)
 
 	cdk8splus.NewNamespace(c, crib.ResourceID(domain.CDK8sResource, props), &cdk8splus.NamespaceProps{
 		Metadata: &cdk8s.ApiObjectMetadata{
@@ -13,7 +13,8 @@ import (
 )
 
 type Props struct {
-	StringData map[string]*string `validate:"omitempty,dive,required"`
+	// StringData holds the data of the secret. Its values are registered as secrets, see crib.RegisterSecret.
+	StringData map[string]*string `sensitive:"true" validate:"omitempty,dive,required"`
 	Immutable  *bool              `validate:"omitempty"`
 	Name       string             `validate:"required"`
 	Namespace  string             `validate:"required"`
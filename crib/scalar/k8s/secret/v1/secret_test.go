@@ -99,6 +99,15 @@ func TestNewSecret_CustomizeCreatedScalar(t *testing.T) {
 	internal.SynthAndSnapYamls(t, app)
 }
 
+func TestPropsValidateRegistersStringData(t *testing.T) {
+	t.Parallel()
+
+	props := validProps()
+	props.StringData["token"] = dry.ToPtr("secretv1-test-token")
+	assert.NoError(t, props.Validate(t.Context()))
+	assert.Equal(t, crib.Redacted, crib.Redact("secretv1-test-token"))
+}
+
 func validProps() *Props {
 	return &Props{
 		Name:      "test-secret",
@@ -0,0 +1,112 @@
+// Package waitv1 provides a special client-side manifest that makes cribctl wait for a condition
+// in the cluster before the components that depend on it are applied. It replaces hand-built
+// ClientSideApply steps running kubectl wait or kubectl rollout status.
+//
+// The schema of the manifest is as follows:
+//
+//	apiVersion: crib.smartcontract.com/v1alpha1
+//	kind: Wait
+//	spec:
+//		condition: <condition> # Oneof rollout, pod-ready, job-complete, endpoints, http, crd-established
+//		namespace: <namespace> # Optional, defaults to the namespace of the current context.
+//		kind: Deployment # Only for rollout, oneof Deployment, StatefulSet, DaemonSet
+//		name: <name> # Either name or selector, except for http.
+//		selector: app.kubernetes.io/instance=<release>
+//		url: http://<host>/health # Only for http.
+//		timeout: 10m # Optional, defaults to the wait defaults of the plan.
+//		interval: 2s # Optional, defaults to the wait defaults of the plan.
+package waitv1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+
+	"github.com/smartcontractkit/crib-sdk/crib"
+	"github.com/smartcontractkit/crib-sdk/internal"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// ComponentName is the name of the component in the component registry.
+const ComponentName = "sdk.Wait"
+
+type (
+	Props struct {
+		// Namespace is the namespace of the objects to wait for.
+		Namespace string `json:",omitempty" validate:"omitempty,lte=63,dns_rfc1035_label"`
+		// Condition is the condition to wait for.
+		Condition string `validate:"required,oneof=rollout pod-ready job-complete endpoints http crd-established"`
+		// Kind is the kind of the workloads of a rollout condition.
+		Kind string `json:",omitempty" validate:"required_if=Condition rollout,omitempty,oneof=Deployment StatefulSet DaemonSet"`
+		// Name selects the object to wait for by name.
+		Name string `json:",omitempty"`
+		// Selector selects the objects to wait for by label, e.g. "app.kubernetes.io/name=chainlink".
+		Selector string `json:",omitempty"`
+		// URL is the URL requested by http conditions, which must respond with HTTP 200.
+		URL string `json:",omitempty" validate:"required_if=Condition http,omitempty,http_url"`
+		// Timeout is how long to wait for, e.g. "15m". It defaults to the wait defaults of the plan.
+		Timeout string `json:",omitempty" validate:"omitempty,duration"`
+		// Interval is how often the condition is checked, e.g. "5s". It defaults to the wait defaults of the plan.
+		Interval string `json:",omitempty" validate:"omitempty,duration"`
+	}
+
+	Result struct {
+		crib.Component
+	}
+)
+
+// Validate ensures that the Props are valid. Conditions on objects must select them by name or by label.
+func (p *Props) Validate(ctx context.Context) error {
+	if err := internal.ValidatorFromContext(ctx).Struct(p); err != nil {
+		return err
+	}
+	if p.Condition != domain.WaitHTTP && p.Name == "" && p.Selector == "" {
+		return errors.New("either Name or Selector is required")
+	}
+	return nil
+}
+
+// Component returns a crib.ComponentFunc that creates a new Wait component.
+func Component(props crib.Props) crib.ComponentFunc {
+	return func(ctx context.Context) (crib.Component, error) {
+		if err := props.Validate(ctx); err != nil {
+			return nil, err
+		}
+		return New(ctx, props)
+	}
+}
+
+// New creates a new Wait scalar component. Components that depend on it are only applied once the
+// condition is met. Like ClientSideApply, the manifest is handled by cribctl and never sent to the cluster.
+func New(ctx context.Context, props crib.Props) (*Result, error) {
+	waitProps := dry.MustAs[*Props](props)
+	if err := waitProps.Validate(ctx); err != nil {
+		return nil, err
+	}
+
+	parent := internal.ConstructFromContext(ctx)
+	chart := cdk8s.NewChart(parent, crib.ResourceID(ComponentName, props), nil)
+
+	obj := cdk8s.NewApiObject(chart, crib.ResourceID(domain.CDK8sResource, props), &cdk8s.ApiObjectProps{
+		ApiVersion: dry.ToPtr(domain.CribAPIVersion),
+		Kind:       dry.ToPtr(domain.Wait),
+	})
+	spec := map[string]any{"condition": waitProps.Condition}
+	for key, value := range map[string]string{
+		"namespace": waitProps.Namespace,
+		"kind":      waitProps.Kind,
+		"name":      waitProps.Name,
+		"selector":  waitProps.Selector,
+		"url":       waitProps.URL,
+		"timeout":   waitProps.Timeout,
+		"interval":  waitProps.Interval,
+	} {
+		if value != "" {
+			spec[key] = value
+		}
+	}
+	obj.AddJsonPatch(cdk8s.JsonPatch_Add(dry.ToPtr("/spec"), spec))
+	return &Result{Component: chart}, nil
+}
@@ -0,0 +1,72 @@
+package waitv1
+
+import (
+	"testing"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+)
+
+func TestNewWait(t *testing.T) {
+	t.Parallel()
+	internal.JSIIKernelMutex.Lock()
+	defer internal.JSIIKernelMutex.Unlock()
+
+	app := internal.NewTestApp(t)
+	ctx := internal.ContextWithConstruct(t.Context(), app.Chart)
+
+	component, err := New(ctx, &Props{
+		Namespace: "test-namespace",
+		Condition: "rollout",
+		Kind:      "Deployment",
+		Name:      "api",
+		Timeout:   "15m",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, component)
+
+	obj := cdk8s.ApiObject_Of((*app.Charts())[1])
+	assert.Equal(t, "Wait", *obj.Kind())
+	assert.Equal(t, map[string]any{
+		"condition": "rollout",
+		"namespace": "test-namespace",
+		"kind":      "Deployment",
+		"name":      "api",
+		"timeout":   "15m",
+	}, dry.As[map[string]any](obj.ToJson())["spec"])
+
+	internal.SynthAndSnapYamls(t, app)
+}
+
+func TestPropsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		props   Props
+		wantErr string
+	}{
+		{name: "pod-ready by selector", props: Props{Condition: "pod-ready", Selector: "app=api"}},
+		{name: "http", props: Props{Condition: "http", URL: "http://api.crib.local/health"}},
+		{name: "no target", props: Props{Condition: "job-complete"}, wantErr: "either Name or Selector is required"},
+		{name: "rollout without kind", props: Props{Condition: "rollout", Name: "api"}, wantErr: "Kind"},
+		{name: "http without url", props: Props{Condition: "http"}, wantErr: "URL"},
+		{name: "invalid timeout", props: Props{Condition: "pod-ready", Name: "api", Timeout: "600"}, wantErr: "Timeout"},
+		{name: "unknown condition", props: Props{Condition: "ready", Name: "api"}, wantErr: "Condition"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.props.Validate(t.Context())
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package crib
+
+import (
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// Redacted replaces the values of registered secrets in the output of plans.
+const Redacted = domain.Redacted
+
+// RegisterSecret registers values as secrets, so that they are masked in the output of the steps of a
+// plan, its preview, its apply report and its errors. Components register the secrets that they derive
+// themselves, e.g. a database URL built from a generated password:
+//
+//	crib.RegisterSecret(password, databaseURL)
+//
+// The fields of Props that are tagged as sensitive, and the StringData of Secrets, are registered when
+// the props are validated:
+//
+//	type Props struct {
+//		Password string `sensitive:"true" validate:"required"`
+//	}
+//
+// Values shorter than 4 characters, and fields still holding the value of their default tag, are not
+// registered. Secrets stay registered for the lifetime of the process. Rendered manifests are not
+// masked, as they are applied as is.
+func RegisterSecret(values ...string) {
+	domain.RegisterSecret(values...)
+}
+
+// Redact returns s with the values of all registered secrets replaced by Redacted.
+func Redact(s string) string {
+	return domain.Redact(s)
+}
@@ -3,6 +3,7 @@
 package clientsideapply
 
 import (
+	"context"
 	"errors"
 	"io"
 	"os"
@@ -20,32 +21,83 @@ type (
 	}
 
 	// CmdRunner is a client-side apply runner that executes commands using the command line.
-	CmdRunner struct{}
+	CmdRunner struct {
+		// stdout and stderr receive the output of the command while it runs, in addition
+		// to the output being captured in the result. They default to os.Stdout and os.Stderr.
+		stdout io.Writer
+		stderr io.Writer
+		// log receives the output of the command as well, see WithLog.
+		log io.Writer
+	}
 
 	// wrappedRunner is a client-side apply runner that wraps another runner and executes a command.
 	wrappedRunner struct {
 		// Path to the binary to execute.
 		path string
+		// opts are passed on to the CmdRunner that executes the binary.
+		opts []RunnerOpt
+	}
+
+	// funcRunner is a client-side apply runner that runs the Run func of a registered action.
+	funcRunner struct {
+		run func(context.Context, *domain.ClientSideApplyManifest) (*domain.RunnerResult, error)
+		// cmd holds the streams that the output of the action is written to.
+		cmd *CmdRunner
 	}
+
+	// RunnerOpt is a functional option for configuring the runners that execute commands.
+	RunnerOpt func(*CmdRunner)
 )
 
-// NewRunner creates a new ClientSideApplyRunner based on the manifest's action.
-func NewRunner(manifest *domain.ClientSideApplyManifest) (port.ClientSideApplyRunner, error) {
+// NewRunner creates a new ClientSideApplyRunner for the manifest's action, as registered with
+// domain.RegisterAction. It fails if the action is not registered or does not accept the args.
+func NewRunner(manifest *domain.ClientSideApplyManifest, opts ...RunnerOpt) (port.ClientSideApplyRunner, error) {
 	if manifest == nil {
 		return nil, errors.New("manifest cannot be nil")
 	}
+	if manifest.Spec.Action == "" {
+		return nil, domain.ErrEmptyAction
+	}
+	if err := domain.ValidateAction(manifest.Spec.Action, manifest.Spec.Args); err != nil {
+		return nil, err
+	}
+	action, err := domain.LookupAction(manifest.Spec.Action)
+	if err != nil {
+		return nil, err
+	}
 
-	switch manifest.Spec.Action {
-	case domain.ActionCmd:
-		return NewCmdRunner()
-	case domain.ActionKubectl:
-		return NewKubectlRunner()
-	case domain.ActionCribctl:
-		return NewCribctlRunner()
-	case domain.ActionTask:
-		return NewTaskRunner()
+	switch {
+	case action.Run != nil:
+		return newFuncRunner(action.Run, opts...), nil
+	case action.Binary == "":
+		return NewCmdRunner(opts...)
 	default:
-		return newWrappedRunner(manifest.Spec.Action)
+		return newWrappedRunner(action.Binary, opts...)
+	}
+}
+
+// WithOutput streams the output of the command to the given writers instead of os.Stdout and os.Stderr.
+func WithOutput(stdout, stderr io.Writer) RunnerOpt {
+	return func(c *CmdRunner) {
+		c.stdout, c.stderr = stdout, stderr
+	}
+}
+
+// WithOutputPrefix streams the output of the command to os.Stdout and os.Stderr one line at a time,
+// with each line prefixed by the given prefix. This keeps the output of commands that run at the
+// same time apart.
+func WithOutputPrefix(prefix string) RunnerOpt {
+	return func(c *CmdRunner) {
+		c.stdout = newPrefixWriter(os.Stdout, prefix)
+		c.stderr = newPrefixWriter(os.Stderr, prefix)
+	}
+}
+
+// WithLog writes the output of the command to w as well, e.g. a log file of the step. As the log keeps
+// the full output, only its last part is kept in memory and returned in the result.
+func WithLog(w io.Writer) RunnerOpt {
+	return func(c *CmdRunner) {
+		c.log = w
 	}
 }
 
@@ -55,8 +107,12 @@ func NewEchoRunner(w io.Writer) (port.ClientSideApplyRunner, error) {
 }
 
 // NewCmdRunner creates a new CmdRunner.
-func NewCmdRunner() (port.ClientSideApplyRunner, error) {
-	return &CmdRunner{}, nil
+func NewCmdRunner(opts ...RunnerOpt) (port.ClientSideApplyRunner, error) {
+	c := &CmdRunner{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // NewCribctlRunner creates a new CribctlRunner with the given path.
@@ -79,15 +135,23 @@ func NewHelmRunner() (port.ClientSideApplyRunner, error) {
 	return newWrappedRunner(domain.ActionHelm)
 }
 
-func newWrappedRunner(executable string) (port.ClientSideApplyRunner, error) {
+func newFuncRunner(run func(context.Context, *domain.ClientSideApplyManifest) (*domain.RunnerResult, error), opts ...RunnerOpt) *funcRunner {
+	c := &CmdRunner{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return &funcRunner{run: run, cmd: c}
+}
+
+func newWrappedRunner(executable string, opts ...RunnerOpt) (port.ClientSideApplyRunner, error) {
 	// If we're running under test, prefix the binary with "echo " to avoid executing it.
 	if testing.Testing() && os.Getenv("CRIB_ENABLE_COMMAND_EXECUTION") == "" {
-		return &wrappedRunner{path: "echo " + executable}, nil
+		return &wrappedRunner{path: "echo " + executable, opts: opts}, nil
 	}
 
 	path, err := exec.LookPath(executable)
 	if err != nil {
 		return nil, domain.NewNotFoundInPathError(executable)
 	}
-	return &wrappedRunner{path: path}, nil
+	return &wrappedRunner{path: path, opts: opts}, nil
 }
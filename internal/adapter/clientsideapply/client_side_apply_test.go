@@ -1,7 +1,10 @@
 package clientsideapply
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -187,3 +190,48 @@ func TestCribctlExecute(t *testing.T) {
 	resStr := string(result.Output)
 	assert.Contains(t, resStr, "apply plan example", "Expected output to contain 'apply plan example'")
 }
+
+func init() {
+	if err := domain.RegisterAction(domain.ActionDefinition{
+		Name: "test-greet",
+		Args: domain.ExactArgs(1),
+		Run: func(_ context.Context, m *domain.ClientSideApplyManifest) (*domain.RunnerResult, error) {
+			return &domain.RunnerResult{Output: []byte("Hello, " + m.Spec.Args[0] + "!\n")}, nil
+		},
+	}); err != nil {
+		panic(err)
+	}
+}
+
+func TestNewRunnerRegisteredActions(t *testing.T) {
+	t.Parallel()
+
+	newManifest := func(action string, args ...string) *domain.ClientSideApplyManifest {
+		return &domain.ClientSideApplyManifest{
+			Spec: domain.ClientSideApplySpec{OnFailure: "abort", Action: action, Args: args},
+		}
+	}
+
+	var stdout bytes.Buffer
+	m := newManifest("test-greet", "World")
+	runner, err := NewRunner(m, WithOutput(&stdout, io.Discard))
+	require.NoError(t, err)
+	result, err := runner.Execute(t.Context(), m)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!\n", string(result.Output))
+	assert.Equal(t, "Hello, World!\n", stdout.String(), "the output of the action is streamed like that of a binary")
+
+	_, err = NewRunner(newManifest("test-greet", "World", "again"))
+	require.EqualError(t, err, "invalid args of action test-greet: accepts 1 arg(s), received 2")
+
+	_, err = NewRunner(newManifest("terraform", "apply"))
+	require.ErrorIs(t, err, domain.ErrUnknownAction)
+
+	// kubectx was previously only reachable through a lookup in the PATH.
+	m = newManifest(domain.ActionKubectx, "kind-crib")
+	runner, err = NewRunner(m, WithOutput(io.Discard, io.Discard))
+	require.NoError(t, err)
+	result, err = runner.Execute(t.Context(), m)
+	require.NoError(t, err)
+	assert.Equal(t, "kubectx kind-crib\n", string(result.Output))
+}
@@ -1,12 +1,17 @@
 package clientsideapply
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/samber/lo"
 
@@ -15,9 +20,13 @@ import (
 	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 )
 
-// Provide a global mutex to ensure that only one command is executed at a time.
-// This is because we overwrite the stdin/stdout/stderr of the process while the command is running.
-var mu sync.Mutex
+const (
+	// waitDelay is how long a killed command may keep its output open before it is closed.
+	waitDelay = 5 * time.Second
+	// maxLoggedOutput is the number of trailing output bytes kept in memory for commands whose output
+	// is written to a log.
+	maxLoggedOutput = 64 << 10
+)
 
 func (c *CmdRunner) Execute(ctx context.Context, input *domain.ClientSideApplyManifest) (*domain.RunnerResult, error) {
 	const cmd = "/bin/bash"
@@ -33,39 +42,117 @@ func (c *CmdRunner) Execute(ctx context.Context, input *domain.ClientSideApplyMa
 	input.Spec.Args = lo.Compact(append([]string{action}, input.Spec.Args...))
 	args := []string{"-c", strings.Join(input.Spec.Args, " ")}
 
+	// The output of every attempt is collected, so that the failures leading up to a retry are reported.
+	var output []byte
+	for attempt := 0; ; attempt++ {
+		res, stdout, err := c.run(ctx, &input.Spec, cmd, args)
+		output = append(output, res.Output...)
+		if err == nil {
+			res.Outputs, err = extractOutputs(&input.Spec, stdout)
+			res.Output = output
+			return res, err
+		}
+		if attempt == input.Spec.Retries {
+			res.Output = output
+			return res, err
+		}
+
+		delay := input.Spec.RetryDelay(attempt + 1)
+		msg := fmt.Sprintf("Attempt %d of %d failed, retrying in %s: %v\n", attempt+1, input.Spec.Retries+1, delay, err)
+		output = append(output, msg...)
+		_, stderr := c.streams()
+		_, _ = io.WriteString(stderr, domain.Redact(msg))
+		flush(stderr)
+		if c.log != nil {
+			_, _ = io.WriteString(c.log, domain.Redact(msg))
+		}
+
+		select {
+		case <-ctx.Done():
+			res.Output = output
+			return res, errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// run runs the command once, within the timeout, environment, working directory and stdin of the spec.
+// Next to the result, it returns the standard output of the command.
+func (c *CmdRunner) run(ctx context.Context, spec *domain.ClientSideApplySpec, cmd string, args []string) (*domain.RunnerResult, []byte, error) {
+	if spec.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, spec.Timeout)
+		defer cancel()
+	}
+
 	e := exec.CommandContext(ctx, cmd, args...) //nolint:gosec // Needed for command execution.
-	// Copy the environment variables from the current process.
+	// Copy the environment variables from the current process, the ones of the spec take precedence.
 	e.Env = os.Environ()
+	for _, name := range slices.Sorted(maps.Keys(spec.Env)) {
+		e.Env = append(e.Env, name+"="+spec.Env[name])
+	}
+	e.Dir = spec.WorkingDir
+	if spec.Stdin != "" {
+		e.Stdin = strings.NewReader(spec.Stdin)
+	}
+	// Children of the shell may keep its output open after it was killed.
+	e.WaitDelay = waitDelay
 
-	// Capture the current process's stdin, stdout, and stderr.
-	// And scaffold a restore mechanism.
-	// This is necessary because we will overwrite these with our own pipe.
-	mu.Lock()
-	defer mu.Unlock()
-
+	// Each command captures its own output, so several commands may run at the same time.
 	// Run the command, collecting the output of the command.
 	// Note: The output should have also been streamed to stdout/stderr.
 	// Possible gotcha here, we may need to inspect the output of the command
 	// to fully determine success or failure and not just the exit code.
-	res, err := combinedOutput(e)
-	if err != nil {
-		return nil, err
+	// The result is returned even when the command fails, so that callers can report its output.
+	res, stdout, err := c.combinedOutput(e, spec)
+	if err != nil && spec.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s: %w", spec.Timeout, err)
 	}
-
 	return &domain.RunnerResult{
-		Output: res,
-	}, nil
+		Output:   res,
+		ExitCode: e.ProcessState.ExitCode(),
+	}, stdout, err
 }
 
-// combinedOutput runs cmd, writing its stdout to [os.Stdout] and
-// stderr to [os.Stderr], while also capturing both into one [*bytes.Buffer].
-func combinedOutput(cmd *exec.Cmd) ([]byte, error) {
+// combinedOutput runs cmd, writing its stdout and stderr to the streams of the runner
+// ([os.Stdout] and [os.Stderr] by default) and to its log, while also capturing both into one buffer.
+// With a log, only the last maxLoggedOutput bytes are captured. The standard output is also returned
+// on its own if the outputs of the step are extracted from it.
+func (c *CmdRunner) combinedOutput(cmd *exec.Cmd, spec *domain.ClientSideApplySpec) (output, stdout []byte, err error) {
 	buf, reset := mempools.BytesBuffer.Get()
 	defer reset()
-	// tee stdout to both os.Stdout and buf
-	cmd.Stdout = io.MultiWriter(os.Stdout, buf)
-	// tee stderr to both os.Stderr and buf
-	cmd.Stderr = io.MultiWriter(os.Stderr, buf)
-	err := cmd.Run()
-	return dry.Wrapf2(buf.Bytes(), err, "running command %q", strings.Join(cmd.Args, " "))
+	out, resetOut := mempools.BytesBuffer.Get()
+	defer resetOut()
+	// Registered secrets are masked in the streamed and logged output. The captured output is kept as
+	// is, e.g. for the manifests rendered by helm, and is masked by whoever reports it.
+	streamOut, streamErr := c.streams()
+	streamOut, streamErr = domain.NewRedactWriter(streamOut), domain.NewRedactWriter(streamErr)
+
+	var captured interface {
+		io.Writer
+		Bytes() []byte
+	} = buf
+	combined := []io.Writer{captured}
+	var log *domain.RedactWriter
+	if c.log != nil {
+		log = domain.NewRedactWriter(c.log)
+		captured = &tailBuffer{max: maxLoggedOutput}
+		combined = []io.Writer{log, captured}
+	}
+	// The standard output and error are copied at the same time, so the writes to the writers
+	// receiving both are serialized.
+	combinedW := &syncWriter{w: io.MultiWriter(combined...)}
+	outW := []io.Writer{streamOut, combinedW}
+	if len(spec.Outputs) > 0 {
+		outW = append(outW, out)
+	}
+	cmd.Stdout = io.MultiWriter(outW...)
+	cmd.Stderr = io.MultiWriter(streamErr, combinedW)
+	err = cmd.Run()
+	flush(streamOut, streamErr)
+	if log != nil {
+		_ = log.Flush()
+	}
+	// Copy the output, the buffers are returned to the pool.
+	return bytes.Clone(captured.Bytes()), bytes.Clone(out.Bytes()), dry.Wrapf(err, "running command %q", strings.Join(cmd.Args, " "))
 }
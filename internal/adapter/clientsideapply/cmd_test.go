@@ -1,7 +1,12 @@
 package clientsideapply
 
 import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,3 +38,181 @@ func TestCmdExecute(t *testing.T) {
 	assert.Contains(t, resStr, "Iteration", "Expected output to contain 'Iteration'")
 	assert.Contains(t, resStr, "1", "Expected output to contain '1'")
 }
+
+func TestCmdExecuteFailure(t *testing.T) {
+	t.Parallel()
+
+	input := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: "abort",
+			Action:    "cmd",
+			Args: []string{
+				"echo 'about to fail'; exit 3",
+			},
+		},
+	}
+
+	runner, err := NewCmdRunner()
+	require.NoError(t, err)
+
+	result, err := runner.Execute(t.Context(), input)
+	require.Error(t, err)
+	require.NotNil(t, result, "Expected the result to be returned alongside the error")
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Contains(t, string(result.Output), "about to fail")
+}
+
+func TestCmdExecuteWithOutput(t *testing.T) {
+	t.Parallel()
+
+	input := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: "abort",
+			Action:    "cmd",
+			Args: []string{
+				"echo 'to stdout'; echo 'to stderr' >&2",
+			},
+		},
+	}
+
+	var stdout, stderr bytes.Buffer
+	runner, err := NewCmdRunner(WithOutput(&stdout, &stderr))
+	require.NoError(t, err)
+
+	result, err := runner.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "to stdout\n", stdout.String())
+	assert.Equal(t, "to stderr\n", stderr.String())
+	assert.Contains(t, string(result.Output), "to stdout")
+	assert.Contains(t, string(result.Output), "to stderr")
+}
+
+func TestCmdExecuteWithLog(t *testing.T) {
+	t.Parallel()
+
+	input := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: "abort",
+			Action:    "cmd",
+			Args:      []string{"echo 'to stderr' >&2; sleep 0.1; head -c 100000 /dev/zero | tr '\\0' 'x'; echo; echo 'last line'"},
+		},
+	}
+
+	var log bytes.Buffer
+	runner, err := NewCmdRunner(WithOutput(io.Discard, io.Discard), WithLog(&log))
+	require.NoError(t, err)
+
+	result, err := runner.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.Equal(t, len("to stderr\n")+100000+len("\nlast line\n"), log.Len(), "the log has the full output")
+	assert.Contains(t, log.String(), "to stderr\n")
+	assert.Len(t, result.Output, maxLoggedOutput, "only the tail is kept in memory")
+	assert.True(t, strings.HasSuffix(string(result.Output), "x\nlast line\n"))
+}
+
+func TestCmdExecuteRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	domain.RegisterSecret("cmd-redact-test-token")
+	input := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: "abort",
+			Action:    "cmd",
+			Args:      []string{"printf 'token: cmd-redact-'; printf 'test-token\\n'; echo 'cmd-redact-test-token' >&2"},
+		},
+	}
+
+	var stdout, stderr, log bytes.Buffer
+	runner, err := NewCmdRunner(WithOutput(&stdout, &stderr), WithLog(&log))
+	require.NoError(t, err)
+
+	result, err := runner.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "token: ******\n", stdout.String(), "secrets split across writes are masked")
+	assert.Equal(t, "******\n", stderr.String())
+	assert.NotContains(t, log.String(), "cmd-redact-test-token")
+	assert.Contains(t, string(result.Output), "cmd-redact-test-token", "the captured output is kept as is")
+}
+
+func TestCmdExecuteRetries(t *testing.T) {
+	t.Parallel()
+
+	// The command fails until it ran three times.
+	counter := filepath.Join(t.TempDir(), "attempts")
+	input := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: "abort",
+			Action:    "cmd",
+			Args: []string{
+				"echo x >> " + counter + "; [ $(wc -l < " + counter + ") -ge 3 ]",
+			},
+			Retries: 2,
+			Backoff: time.Millisecond,
+		},
+	}
+
+	var stderr bytes.Buffer
+	runner, err := NewCmdRunner(WithOutput(io.Discard, &stderr))
+	require.NoError(t, err)
+
+	result, err := runner.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Contains(t, stderr.String(), "Attempt 1 of 3 failed, retrying in 1ms")
+	assert.Contains(t, string(result.Output), "Attempt 2 of 3 failed, retrying in 2ms")
+
+	// The last failure is returned once the retries are exhausted.
+	input.Spec.Args = []string{"exit 4"}
+	input.Spec.Retries = 1
+	result, err = runner.Execute(t.Context(), input)
+	require.Error(t, err)
+	assert.Equal(t, 4, result.ExitCode)
+	assert.Contains(t, string(result.Output), "Attempt 1 of 2 failed")
+}
+
+func TestCmdExecuteTimeout(t *testing.T) {
+	t.Parallel()
+
+	input := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: "abort",
+			Action:    "cmd",
+			Args:      []string{"sleep 5"},
+			Timeout:   50 * time.Millisecond,
+		},
+	}
+
+	runner, err := NewCmdRunner(WithOutput(io.Discard, io.Discard))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = runner.Execute(t.Context(), input)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "timed out after 50ms")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestCmdExecuteEnvironment(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	input := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: "abort",
+			Action:    "cmd",
+			Args: []string{
+				`echo "$GREETING from $(pwd)"; cat`,
+			},
+			Env:        map[string]string{"GREETING": "hello"},
+			WorkingDir: dir,
+			Stdin:      "from stdin\n",
+		},
+	}
+
+	runner, err := NewCmdRunner(WithOutput(io.Discard, io.Discard))
+	require.NoError(t, err)
+
+	result, err := runner.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "hello from "+dir+"\nfrom stdin\n", string(result.Output))
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/mempools"
 	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
@@ -21,6 +23,25 @@ func (e *EchoRunner) Execute(ctx context.Context, input *domain.ClientSideApplyM
 	for _, arg := range input.Spec.Args {
 		fmt.Fprintf(buf, "    - %s\n", arg)
 	}
+	if input.Spec.Retries > 0 {
+		fmt.Fprintf(buf, "  Retries: %d\n", input.Spec.Retries)
+		fmt.Fprintf(buf, "  Backoff: %s\n", input.Spec.RetryDelay(1))
+	}
+	if input.Spec.Timeout > 0 {
+		fmt.Fprintf(buf, "  Timeout: %s\n", input.Spec.Timeout)
+	}
+	if len(input.Spec.Env) > 0 {
+		buf.WriteString("  Env:\n")
+		for _, name := range slices.Sorted(maps.Keys(input.Spec.Env)) {
+			fmt.Fprintf(buf, "    %s: %s\n", name, input.Spec.Env[name])
+		}
+	}
+	if input.Spec.WorkingDir != "" {
+		fmt.Fprintf(buf, "  WorkingDir: %s\n", input.Spec.WorkingDir)
+	}
+	if input.Spec.Stdin != "" {
+		fmt.Fprintf(buf, "  Stdin: %d bytes\n", len(input.Spec.Stdin))
+	}
 	_, err := io.Copy(e.w, bytes.NewReader(buf.Bytes()))
 	return &domain.RunnerResult{
 		Output: buf.Bytes(),
@@ -0,0 +1,27 @@
+package clientsideapply
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// Execute runs the action and writes its output to the stdout stream and the log of the runner, as if
+// it had been run by a binary.
+func (f *funcRunner) Execute(ctx context.Context, input *domain.ClientSideApplyManifest) (*domain.RunnerResult, error) {
+	res, err := f.run(ctx, input)
+	if res == nil {
+		res = &domain.RunnerResult{ExitCode: -1}
+		if err == nil {
+			res.ExitCode = 0
+		}
+	}
+	output := domain.RedactBytes(res.Output)
+	stdout, _ := f.cmd.streams()
+	_, _ = stdout.Write(output)
+	flush(stdout)
+	if f.cmd.log != nil {
+		_, _ = f.cmd.log.Write(output)
+	}
+	return res, err
+}
@@ -0,0 +1,117 @@
+package clientsideapply
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+// outputMu serializes the lines written by prefixWriters, so that lines of commands running at the
+// same time are never interleaved.
+var outputMu sync.Mutex
+
+// prefixWriter is an io.Writer that writes complete lines to the underlying writer, each prefixed
+// with a fixed string. Incomplete lines are buffered until they are terminated or flushed.
+type prefixWriter struct {
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(w io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{
+		w:      w,
+		prefix: []byte("[" + prefix + "] "),
+	}
+}
+
+// Write implements io.Writer. It always consumes all of p.
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	err := w.writeLines(w.buf[:i+1])
+	w.buf = append(w.buf[:0], w.buf[i+1:]...)
+	return len(p), err
+}
+
+// Flush writes any buffered incomplete line, terminating it with a newline.
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLines(append(w.buf, '\n'))
+	w.buf = w.buf[:0]
+	return err
+}
+
+// writeLines writes the newline terminated lines in b, each prefixed.
+func (w *prefixWriter) writeLines(b []byte) error {
+	var out bytes.Buffer
+	for line := range bytes.Lines(b) {
+		out.Write(w.prefix)
+		out.Write(line)
+	}
+
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	_, err := w.w.Write(out.Bytes())
+	return err
+}
+
+// tailBuffer is an io.Writer that keeps the last max bytes written to it.
+type tailBuffer struct {
+	max int
+	buf []byte
+}
+
+// Write implements io.Writer. It always consumes all of p.
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if over := len(t.buf) - t.max; over > 0 {
+		// Move the tail to the front, so that the buffer does not keep growing.
+		t.buf = t.buf[:copy(t.buf, t.buf[over:])]
+	}
+	return len(p), nil
+}
+
+// Bytes returns the last bytes written, at most max.
+func (t *tailBuffer) Bytes() []byte {
+	return t.buf
+}
+
+// syncWriter is an io.Writer that serializes the writes to the underlying writer.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// streams returns the writers that receive the output of the command while it runs.
+func (c *CmdRunner) streams() (stdout, stderr io.Writer) {
+	stdout, stderr = c.stdout, c.stderr
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+	return stdout, stderr
+}
+
+// flush flushes any of the writers that buffer their output.
+func flush(writers ...io.Writer) {
+	for _, w := range writers {
+		if f, ok := w.(interface{ Flush() error }); ok {
+			_ = f.Flush()
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package clientsideapply
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var out bytes.Buffer
+	w := newPrefixWriter(&out, "bundle")
+
+	n, err := w.Write([]byte("first line\nsecond "))
+	require.NoError(t, err)
+	is.Equal(18, n)
+	is.Equal("[bundle] first line\n", out.String(), "incomplete lines are buffered")
+
+	_, err = w.Write([]byte("line\nthird"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	is.Equal("[bundle] first line\n[bundle] second line\n[bundle] third\n", out.String())
+
+	require.NoError(t, w.Flush())
+	is.Equal("[bundle] first line\n[bundle] second line\n[bundle] third\n", out.String(), "flushing twice writes nothing")
+}
+
+func TestTailBuffer(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	w := &tailBuffer{max: 8}
+	n, err := w.Write([]byte("0123"))
+	require.NoError(t, err)
+	is.Equal(4, n)
+	is.Equal("0123", string(w.Bytes()))
+
+	_, _ = w.Write([]byte("456789"))
+	is.Equal("23456789", string(w.Bytes()))
+
+	_, _ = w.Write([]byte("abcdefghijkl"))
+	is.Equal("efghijkl", string(w.Bytes()))
+	is.LessOrEqual(cap(w.buf), 32, "the buffer does not keep growing")
+}
@@ -0,0 +1,81 @@
+package clientsideapply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// extractOutputs extracts the outputs declared by the step from its standard output, or from the
+// files it wrote. The values of sensitive outputs are registered as secrets.
+func extractOutputs(spec *domain.ClientSideApplySpec, stdout []byte) (map[string]string, error) {
+	if len(spec.Outputs) == 0 {
+		return nil, nil
+	}
+	outputs := make(map[string]string, len(spec.Outputs))
+	for _, o := range spec.Outputs {
+		value, err := extractOutput(&o, spec.WorkingDir, stdout)
+		if err != nil {
+			return nil, fmt.Errorf("extracting output %q: %w", o.Name, err)
+		}
+		if o.Sensitive {
+			domain.RegisterSecret(value)
+		}
+		outputs[o.Name] = value
+	}
+	return outputs, nil
+}
+
+func extractOutput(o *domain.ClientSideApplyOutput, workingDir string, stdout []byte) (string, error) {
+	switch {
+	case o.Regex != "":
+		re, err := regexp.Compile(o.Regex)
+		if err != nil {
+			return "", err
+		}
+		match := re.FindSubmatch(stdout)
+		if match == nil {
+			return "", fmt.Errorf("no match for %q in the output of the step", o.Regex)
+		}
+		// The first capture group, or the whole match.
+		return string(match[min(1, len(match)-1)]), nil
+	case o.JSONPath != "":
+		var data any
+		if err := json.Unmarshal(stdout, &data); err != nil {
+			return "", fmt.Errorf("the output of the step is not JSON: %w", err)
+		}
+		template := o.JSONPath
+		if !strings.HasPrefix(template, "{") {
+			template = "{" + template + "}"
+		}
+		jp := jsonpath.New(o.Name)
+		if err := jp.Parse(template); err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := jp.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	case o.File != "":
+		path := o.File
+		if !filepath.IsAbs(path) && workingDir != "" {
+			path = filepath.Join(workingDir, path)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	return "", errors.New("one of regex, jsonPath or file is required")
+}
@@ -0,0 +1,124 @@
+package clientsideapply
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+func TestExtractOutputs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.pub"), []byte("csa-public-key\n"), 0o600))
+	stdout := []byte(`{"address":"0xabc","nodes":[{"name":"a"},{"name":"b"}]}`)
+
+	tests := []struct {
+		name    string
+		output  domain.ClientSideApplyOutput
+		stdout  []byte
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "regex capture group",
+			output: domain.ClientSideApplyOutput{Name: "address", Regex: `"address":"(0x[0-9a-f]+)"`},
+			stdout: stdout,
+			want:   "0xabc",
+		},
+		{
+			name:   "regex whole match",
+			output: domain.ClientSideApplyOutput{Name: "address", Regex: `0x[0-9a-f]+`},
+			stdout: stdout,
+			want:   "0xabc",
+		},
+		{
+			name:    "regex without match",
+			output:  domain.ClientSideApplyOutput{Name: "address", Regex: `0y[0-9a-f]+`},
+			stdout:  stdout,
+			wantErr: `extracting output "address": no match for "0y[0-9a-f]+" in the output of the step`,
+		},
+		{
+			name:   "jsonpath",
+			output: domain.ClientSideApplyOutput{Name: "address", JSONPath: "{.address}"},
+			stdout: stdout,
+			want:   "0xabc",
+		},
+		{
+			name:   "jsonpath without braces",
+			output: domain.ClientSideApplyOutput{Name: "names", JSONPath: ".nodes[*].name"},
+			stdout: stdout,
+			want:   "a b",
+		},
+		{
+			name:    "jsonpath of text",
+			output:  domain.ClientSideApplyOutput{Name: "address", JSONPath: "{.address}"},
+			stdout:  []byte("address: 0xabc"),
+			wantErr: "the output of the step is not JSON",
+		},
+		{
+			name:   "file",
+			output: domain.ClientSideApplyOutput{Name: "key", File: "key.pub"},
+			want:   "csa-public-key",
+		},
+		{
+			name:   "sensitive",
+			output: domain.ClientSideApplyOutput{Name: "token", Regex: `"token":"([a-z-]+)"`, Sensitive: true},
+			stdout: []byte(`{"token":"extract-test-token"}`),
+			want:   "extract-test-token",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := &domain.ClientSideApplySpec{WorkingDir: dir, Outputs: []domain.ClientSideApplyOutput{tc.output}}
+			got, err := extractOutputs(spec, tc.stdout)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{tc.output.Name: tc.want}, got)
+			if tc.output.Sensitive {
+				assert.Equal(t, domain.Redacted, domain.Redact(tc.want), "sensitive outputs are registered as secrets")
+			}
+		})
+	}
+}
+
+func TestCmdExecuteOutputs(t *testing.T) {
+	t.Parallel()
+
+	input := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: "abort",
+			Action:    "cmd",
+			Args: []string{
+				"echo 'deploying' >&2; echo 'deployed at 0x1234'",
+			},
+			Outputs: []domain.ClientSideApplyOutput{
+				{Name: "address", Regex: `at (0x[0-9]+)`},
+			},
+		},
+	}
+
+	runner, err := NewCmdRunner(WithOutput(io.Discard, io.Discard))
+	require.NoError(t, err)
+
+	result, err := runner.Execute(t.Context(), input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"address": "0x1234"}, result.Outputs)
+	assert.Contains(t, string(result.Output), "deploying\n", "the output still contains stderr")
+
+	// A declared output that cannot be extracted fails the step.
+	input.Spec.Args = []string{"echo 'nothing deployed'"}
+	_, err = runner.Execute(t.Context(), input)
+	assert.ErrorContains(t, err, `extracting output "address"`)
+}
@@ -15,10 +15,10 @@ func (w *wrappedRunner) Execute(ctx context.Context, input *domain.ClientSideApp
 	// Rewrite the args to be a single string.
 	input.Spec.Args = []string{strings.Join(input.Spec.Args, " ")}
 
-	runner, err := NewCmdRunner()
+	runner, err := NewCmdRunner(w.opts...)
 	if err != nil {
 		return dry.Wrapf2((*domain.RunnerResult)(nil), err, "failed to create command runner")
 	}
 	res, err := runner.Execute(ctx, input)
-	return dry.Wrapf2(res, err, "failed to execute command")
+	return res, dry.Wrapf(err, "failed to execute command")
 }
@@ -0,0 +1,43 @@
+package cribctl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/kubeclient"
+	"github.com/smartcontractkit/crib-sdk/internal/core/service"
+)
+
+// Appliers of the Kubernetes resources of a plan.
+const (
+	// ApplierKubectl applies the resources by running the kubectl binary of the user.
+	ApplierKubectl = "kubectl"
+	// ApplierNative applies the resources with server-side apply through the Kubernetes API.
+	ApplierNative = "native"
+)
+
+// Appliers are the supported appliers of the Kubernetes resources of a plan.
+var Appliers = []string{ApplierKubectl, ApplierNative}
+
+// PlanApplier returns the option that applies the Kubernetes resources of a plan with the named applier.
+// The native applier connects to the current context of the kubeconfig, and also reads the objects that
+// the Wait bundles of the plan wait for.
+func PlanApplier(name string) (service.PlanServiceOpt, error) {
+	switch name {
+	case "", ApplierKubectl:
+		return func(p *service.PlanService) {
+			service.WithKubernetesApplier(nil)(p)
+			service.WithKubernetesReader(nil)(p)
+		}, nil
+	case ApplierNative:
+		applier, err := kubeclient.NewFromKubeconfig()
+		if err != nil {
+			return nil, err
+		}
+		return func(p *service.PlanService) {
+			service.WithKubernetesApplier(applier)(p)
+			service.WithKubernetesReader(applier)(p)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported applier %q, expected one of %s", name, strings.Join(Appliers, ", "))
+}
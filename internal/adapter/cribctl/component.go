@@ -0,0 +1,49 @@
+package cribctl
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/smartcontractkit/crib-sdk/contrib"
+	"github.com/smartcontractkit/crib-sdk/crib"
+)
+
+// LookupComponent returns the registered component with the given name.
+func LookupComponent(name string) (crib.ComponentRegistration, error) {
+	c, ok := contrib.Component(name)
+	if !ok {
+		return c, fmt.Errorf("no component found with name %q, see `cribctl component list`", name)
+	}
+	return c, nil
+}
+
+// WriteComponentList writes a table of the registered components to w, sorted by name.
+func WriteComponentList(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tVERSION\tDESCRIPTION")
+	for _, name := range contrib.Components() {
+		c, _ := contrib.Component(name)
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Name(), cmp.Or(c.Version(), "-"), c.Description())
+	}
+	return tw.Flush()
+}
+
+// WriteComponentDescription writes the description of the component to w, followed by a table of
+// every field of its props with the type, default value and validation rules of the field.
+func WriteComponentDescription(w io.Writer, c crib.ComponentRegistration) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Component:\t%s\n", c.Name())
+	fmt.Fprintf(tw, "Version:\t%s\n", cmp.Or(c.Version(), "-"))
+	fmt.Fprintf(tw, "Props:\t%s\n", c.PropsType())
+	if c.Description() != "" {
+		fmt.Fprintf(tw, "Description:\t%s\n", c.Description())
+	}
+
+	fmt.Fprintln(tw, "\nFIELD\tTYPE\tDEFAULT\tVALIDATE")
+	for _, f := range c.Fields() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", f.Name, f.Type, cmp.Or(f.Default, "-"), cmp.Or(f.Validate, "-"))
+	}
+	return tw.Flush()
+}
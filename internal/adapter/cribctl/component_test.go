@@ -0,0 +1,34 @@
+package cribctl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteComponentList(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteComponentList(&buf))
+	assert.Regexp(t, `(?m)^sdk\.composite\.chainlink\.nodeset\.v1\s+v1\s+A set of Chainlink nodes`, buf.String())
+}
+
+func TestWriteComponentDescription(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+	is := assert.New(t)
+
+	_, err := LookupComponent("sdk.Missing")
+	is.ErrorContains(err, `no component found with name "sdk.Missing"`)
+
+	c, err := LookupComponent("sdk.ClientSideApply")
+	must.NoError(err)
+	var buf bytes.Buffer
+	must.NoError(WriteComponentDescription(&buf, c))
+	is.Contains(buf.String(), "Props:        clientsideapplyv1.Props")
+	is.Regexp(`(?m)^OnFailure\s+string\s+abort\s+required,oneof=continue abort$`, buf.String())
+	is.Regexp(`(?m)^Undo\.Action\s+string\s+-\s+required,`, buf.String())
+}
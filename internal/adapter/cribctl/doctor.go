@@ -12,48 +12,32 @@ import (
 	"regexp"
 	"time"
 
-	"github.com/expr-lang/expr"
 	"github.com/theckman/yacspin"
 	"golang.org/x/mod/semver"
 
 	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/mempools"
-	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 )
 
-var cribDependencies = []dependency{
-	{Name: "kubectl"},
+// tools are the dependencies of cribctl that are not the binary of a ClientSideApply action. The
+// binaries of the registered actions are added to them, see dependencies.
+var tools = []dependency{
 	{
-		Name:           "helm",
-		VersionMatcher: "'%s'>='3.18.4'",
-		VersionCommand: []string{"helm", "version", "--template", "{{.Version}}"},
+		Name:              "node",
+		VersionConstraint: "==22.17.0",
+		VersionCommand:    []string{"node", "-v"},
 	},
 	{
-		Name:           "task",
-		VersionMatcher: "'%s'>='3.40.0'",
-		VersionCommand: []string{"task", "--version"},
+		Name:              "asdf",
+		Optional:          true,
+		VersionConstraint: ">=0.15.0",
+		VersionCommand:    []string{"asdf", "--version"},
 	},
 	{
-		Name:           "kind",
-		VersionMatcher: "'%s'>='0.20.0'",
-		VersionCommand: []string{"kind", "--version"},
-	},
-	{Name: "telepresence"},
-	{
-		Name:           "node",
-		VersionMatcher: "'%s'=='22.17.0'",
-		VersionCommand: []string{"node", "-v"},
-	},
-	{
-		Name:           "asdf",
-		Optional:       true,
-		VersionMatcher: "'%s'>='0.15.0'",
-		VersionCommand: []string{"asdf", "--version"},
-	},
-	{
-		Name:           "go",
-		VersionMatcher: "'%s'>='1.24.0'",
-		VersionCommand: []string{"go", "version"},
+		Name:              "go",
+		VersionConstraint: ">=1.24.0",
+		VersionCommand:    []string{"go", "version"},
 	},
 }
 
@@ -80,11 +64,29 @@ var (
 )
 
 type dependency struct {
-	Name           string   `validate:"required"`
-	VersionMatcher string   `validate:"omitempty,expr"`
-	Instructions   string   `validate:"-"`
-	VersionCommand []string `validate:"omitempty,required_with=VersionMatcher,dive,required"`
-	Optional       bool
+	Name              string                   `validate:"required"`
+	VersionConstraint domain.VersionConstraint `validate:"-"`
+	Instructions      string                   `validate:"-"`
+	VersionCommand    []string                 `validate:"omitempty,required_with=VersionConstraint,dive,required"`
+	Optional          bool
+}
+
+// dependencies returns the binaries of the registered ClientSideApply actions, sorted by name, followed
+// by the other tools that cribctl depends on.
+func dependencies() []dependency {
+	var deps []dependency
+	for _, a := range domain.Actions() {
+		if a.Binary == "" {
+			continue
+		}
+		deps = append(deps, dependency{
+			Name:              a.Binary,
+			VersionConstraint: a.VersionConstraint,
+			VersionCommand:    a.VersionCommand,
+			Optional:          a.Optional,
+		})
+	}
+	return append(deps, tools...)
 }
 
 // DoctorCommand checks for the availability and version of cribctl dependencies.
@@ -93,10 +95,10 @@ func DoctorCommand(ctx context.Context) {
 	defer ret()
 
 	spinner, done := showProgress()
-	for _, dep := range cribDependencies {
+	for _, dep := range dependencies() {
 		localBuf, ret := mempools.BytesBuffer.Get()
 		spinner.Message(fmt.Sprintf("Checking for %s...", dep.Name))
-		if err := dep.checkAvailability(ctx, localBuf); err == nil && dep.VersionMatcher != "" {
+		if err := dep.checkAvailability(ctx, localBuf); err == nil && dep.VersionCommand != nil {
 			dep.checkVersion(ctx, localBuf)
 		}
 
@@ -159,39 +161,18 @@ func (d *dependency) checkVersion(ctx context.Context, buf *bytes.Buffer) {
 		return
 	}
 
-	if err := d.validateVersion(version); err != nil {
-		wantedVersion := fmt.Sprintf(d.VersionMatcher, version)
+	if err := d.VersionConstraint.Check(version); err != nil {
 		if d.Optional {
-			fmt.Fprintf(buf, "  ⚠️  %s is optional but should be upgraded: (have) %s (need)\n", d.Name, wantedVersion)
+			fmt.Fprintf(buf, "  ⚠️  %s is optional but should be upgraded: (have) %s (need) %s\n", d.Name, version, d.VersionConstraint)
 			return
 		}
-		fmt.Fprintf(buf, "  ❌ Needs upgrading: (have) %s (need)\n", wantedVersion)
+		fmt.Fprintf(buf, "  ❌ Needs upgrading: (have) %s (need) %s\n", version, d.VersionConstraint)
 		return
 	}
 
 	fmt.Fprintf(buf, "  ✅ Found version %s\n", version)
 }
 
-func (d *dependency) validateVersion(version string) error {
-	if d.VersionMatcher == "" {
-		return nil // No version matcher to validate against.
-	}
-
-	matcher := fmt.Sprintf(d.VersionMatcher, version)
-	prog, err := expr.Compile(matcher)
-	if err != nil {
-		return fmt.Errorf("failed to compile version matcher %q: %w", matcher, err)
-	}
-	res, err := expr.Run(prog, nil)
-	if err != nil {
-		return fmt.Errorf("failed to evaluate version matcher %q: %w", matcher, err)
-	}
-	if res == nil || !dry.As[bool](res) {
-		return fmt.Errorf("installed version %q does not match required version matcher %q", version, matcher)
-	}
-	return nil
-}
-
 func (d *dependency) extractVersion(output []byte) (string, error) {
 	// Loop over each word in the output and attempt to parse out a version.
 	var version string
@@ -228,8 +209,8 @@ func (d *dependency) extractVersion(output []byte) (string, error) {
 func init() {
 	v := internal.ValidatorFromContext(context.Background())
 	var err error
-	for _, dep := range cribDependencies {
-		err = errors.Join(err, v.Struct(&dep))
+	for _, dep := range tools {
+		err = errors.Join(err, v.Struct(&dep), dep.VersionConstraint.Validate())
 	}
 	if err != nil {
 		panic(fmt.Errorf("cribctl doctor: failed to validate dependencies: %w", err))
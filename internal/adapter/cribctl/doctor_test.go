@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 )
 
 func Test_extractVersion(t *testing.T) {
@@ -71,3 +73,21 @@ The connection to the server localhost:8080 was refused - did you specify the ri
 		})
 	}
 }
+
+func TestDependencies(t *testing.T) {
+	t.Parallel()
+
+	deps := make(map[string]dependency)
+	var names []string
+	for _, dep := range dependencies() {
+		deps[dep.Name] = dep
+		names = append(names, dep.Name)
+	}
+	assert.Equal(t, []string{
+		"aws", "cribctl", "docker", "helm", "kind", "kubectl", "kubectx", "kubens", "task", "telepresence",
+		"node", "asdf", "go",
+	}, names, "the binaries of the registered actions come first")
+	assert.Equal(t, domain.VersionConstraint(">=3.18.4"), deps["helm"].VersionConstraint)
+	assert.False(t, deps["kubectl"].Optional)
+	assert.True(t, deps["kubectx"].Optional)
+}
@@ -10,14 +10,23 @@ import (
 
 	"github.com/smartcontractkit/crib-sdk/contrib"
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
 	"github.com/smartcontractkit/crib-sdk/internal/core/service"
 )
 
 // ValidatePlanArgs validates the arguments for plan-related commands.
-// It ensures exactly one argument is provided and that the plan exists.
+// It ensures exactly one argument is provided and that the plan exists, unless the plan
+// is read from a plan file with the --file flag, in which case no argument is accepted.
 func ValidatePlanArgs(command string) func(*cobra.Command, []string) error {
 	const errMsg = "command requires exactly one argument: the name of the plan"
 	return func(cmd *cobra.Command, args []string) (err error) {
+		if f := cmd.Flags().Lookup("file"); f != nil && f.Changed {
+			if len(args) > 0 {
+				return fmt.Errorf("%s: the name of the plan cannot be combined with --file", command)
+			}
+			return nil
+		}
 		availablePlans := contrib.Plans()
 		msg := fmt.Sprintf("\n\nAvailable plans:\n- %s\n\n", strings.Join(availablePlans, "\n- "))
 
@@ -37,51 +46,135 @@ func ValidatePlanArgs(command string) func(*cobra.Command, []string) error {
 // PreviewPlan previews a CRIB-SDK Plan by its name and returns the DAG as a tree.
 // If outputDir is provided, the generated files will be dumped to that directory.
 // If outputDir is empty, a temporary directory will be used.
-func PreviewPlan(ctx context.Context, fh *filehandler.Handler, name string) (preview, outputPath string, err error) {
+// Options, such as service.WithParams, configure the PlanService.
+func PreviewPlan(ctx context.Context, fh *filehandler.Handler, name string, opts ...service.PlanServiceOpt) (preview, outputPath string, err error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	appPlan, err := previewPlan(ctx, fh, name, opts...)
+	if err != nil {
+		return "", "", err
+	}
+	// Return the preview and the output directory path
+	return appPlan.Preview(ctx), fh.Name(), nil
+}
+
+// PreviewPlanGraph renders a CRIB-SDK Plan by its name like PreviewPlan, and returns its full construct
+// tree with the dependencies between constructs, see WritePlanGraph.
+func PreviewPlanGraph(ctx context.Context, fh *filehandler.Handler, name string, opts ...service.PlanServiceOpt) (graph *domain.PlanGraph, outputPath string, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	appPlan, err := previewPlan(ctx, fh, name, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	return appPlan.Graph(), fh.Name(), nil
+}
+
+// previewPlan renders the named plan with a PlanService that keeps no state.
+func previewPlan(ctx context.Context, fh *filehandler.Handler, name string, opts ...service.PlanServiceOpt) (*service.AppPlan, error) {
 	plan := contrib.Plan(name)
 	if plan == nil {
-		return "", "", fmt.Errorf("no plan found with name %s", name)
+		return nil, fmt.Errorf("no plan found with name %s", name)
 	}
 
 	// Create a new PlanService with a temporary directory.
-	svc, err := service.NewPlanService(ctx, fh)
+	svc, err := service.NewPlanService(ctx, fh, opts...)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to create plan service: %w", err)
+		return nil, fmt.Errorf("failed to create plan service: %w", err)
 	}
 
 	fmt.Fprintf(os.Stderr, "Resolving plan dependencies for plan %q.\n", name)
 	appPlan, err := svc.CreatePlan(ctx, plan)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to create plan: %w", err)
+		return nil, fmt.Errorf("failed to create plan: %w", err)
 	}
+	return appPlan, nil
+}
 
-	// Return the preview and the output directory path
-	return appPlan.Preview(ctx), fh.Name(), nil
+// ApplyPlan applies a CRIB-SDK Plan by its name. A record of the applied plan is kept in the store.
+// The report describes the outcome of each processed bundle and is returned even if applying failed.
+// The service options configure the PlanService, e.g. service.WithConcurrency, and the apply options
+// select the bundles to apply, e.g. service.WithResume.
+func ApplyPlan(ctx context.Context, fh *filehandler.Handler, store port.PlanStateStore, name string, svcOpts []service.PlanServiceOpt, opts ...service.ApplyOpt) (domain.ApplyReport, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	appPlan, err := createPlan(ctx, fh, store, name, svcOpts...)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "Applying plan %q.\n", name)
+	state, err := appPlan.Apply(ctx, opts...)
+	if state == nil {
+		return nil, err
+	}
+	return state.Report, err
 }
 
-// ApplyPlan applies a CRIB-SDK Plan by its name.
-func ApplyPlan(ctx context.Context, fh *filehandler.Handler, name string) error {
+// DestroyPlan tears down a CRIB-SDK Plan by its name. The record of the plan is removed from the store.
+func DestroyPlan(ctx context.Context, fh *filehandler.Handler, store port.PlanStateStore, name string, opts ...service.PlanServiceOpt) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	appPlan, err := createPlan(ctx, fh, store, name, opts...)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Destroying plan %q.\n", name)
+	_, err = appPlan.Destroy(ctx)
+	return err
+}
+
+// DriftPlan renders a CRIB-SDK Plan by its name and compares it against the record of its last apply
+// and against the live objects in the cluster.
+func DriftPlan(ctx context.Context, fh *filehandler.Handler, store port.PlanStateStore, name string, opts ...service.PlanServiceOpt) (*domain.PlanDrift, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	appPlan, err := createPlan(ctx, fh, store, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "Detecting drift of plan %q.\n", name)
+	return appPlan.Drift(ctx)
+}
+
+// PlanParams returns the parameters declared by a CRIB-SDK Plan and its child plans, by the name of the plan.
+func PlanParams(name string) []port.Parameter {
 	plan := contrib.Plan(name)
 	if plan == nil {
-		return fmt.Errorf("no plan found with name %s", name)
+		return nil
+	}
+	return service.PlanParams(plan.Build())
+}
+
+// PlanNamespace returns the namespace of a CRIB-SDK Plan by the name of the plan. Records of applied plans
+// are kept per plan and namespace.
+func PlanNamespace(name string) string {
+	plan := contrib.Plan(name)
+	if plan == nil {
+		return ""
+	}
+	return plan.Namespace()
+}
+
+// createPlan resolves and renders the named plan with a PlanService backed by the given store.
+func createPlan(ctx context.Context, fh *filehandler.Handler, store port.PlanStateStore, name string, opts ...service.PlanServiceOpt) (*service.AppPlan, error) {
+	plan := contrib.Plan(name)
+	if plan == nil {
+		return nil, fmt.Errorf("no plan found with name %s", name)
 	}
 	// Create a new PlanService.
-	svc, err := service.NewPlanService(ctx, fh)
+	svc, err := service.NewPlanService(ctx, fh, append([]service.PlanServiceOpt{service.WithPlanStateStore(store)}, opts...)...)
 	if err != nil {
-		return fmt.Errorf("failed to create plan service: %w", err)
+		return nil, fmt.Errorf("failed to create plan service: %w", err)
 	}
 	fmt.Fprintf(os.Stderr, "Resolving plan dependencies for plan %q.\n", name)
 	appPlan, err := svc.CreatePlan(ctx, plan)
 	if err != nil {
-		return fmt.Errorf("failed to create plan: %w", err)
+		return nil, fmt.Errorf("failed to create plan: %w", err)
 	}
-	fmt.Fprintf(os.Stderr, "Applying plan %q.\n", name)
-	_, err = appPlan.Apply(ctx)
-	return err
+	return appPlan, nil
 }
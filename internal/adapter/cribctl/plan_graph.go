@@ -0,0 +1,93 @@
+package cribctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// Output formats of a plan preview.
+const (
+	PreviewFormatTree    = "tree"
+	PreviewFormatJSON    = "json"
+	PreviewFormatDOT     = "dot"
+	PreviewFormatMermaid = "mermaid"
+)
+
+// PreviewFormats are the supported output formats of a plan preview.
+var PreviewFormats = []string{PreviewFormatTree, PreviewFormatJSON, PreviewFormatDOT, PreviewFormatMermaid}
+
+// WritePlanGraph writes the plan graph to w in the given format, one of json, dot or mermaid. In the
+// dot and mermaid formats, parents are linked to their children with plain lines and dependencies
+// are drawn as dashed arrows.
+func WritePlanGraph(w io.Writer, graph *domain.PlanGraph, format string) error {
+	switch format {
+	case PreviewFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(graph)
+	case PreviewFormatDOT:
+		return writeDOT(w, graph)
+	case PreviewFormatMermaid:
+		return writeMermaid(w, graph)
+	}
+	return fmt.Errorf("unsupported preview format %q, expected one of %s", format, strings.Join(PreviewFormats, ", "))
+}
+
+func writeDOT(w io.Writer, graph *domain.PlanGraph) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", strconv.Quote(graph.Plan))
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range graph.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s];\n", strconv.Quote(n.ID), strconv.Quote(strings.Join(nodeLines(n), "\n")))
+	}
+	for _, n := range graph.Nodes {
+		if n.Parent != "" {
+			fmt.Fprintf(&b, "  %s -> %s [arrowhead=none];\n", strconv.Quote(n.Parent), strconv.Quote(n.ID))
+		}
+	}
+	for _, e := range graph.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [style=dashed, label=\"depends on\"];\n", strconv.Quote(e.From), strconv.Quote(e.To))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMermaid(w io.Writer, graph *domain.PlanGraph) error {
+	// Mermaid identifiers cannot hold the characters of construct paths, so nodes are numbered.
+	ids := make(map[string]string, len(graph.Nodes))
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	for i, n := range graph.Nodes {
+		ids[n.ID] = "n" + strconv.Itoa(i)
+		label := strings.ReplaceAll(strings.Join(nodeLines(n), "<br/>"), `"`, "#quot;")
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[n.ID], label)
+	}
+	for _, n := range graph.Nodes {
+		if n.Parent != "" {
+			fmt.Fprintf(&b, "  %s --- %s\n", ids[n.Parent], ids[n.ID])
+		}
+	}
+	for _, e := range graph.Edges {
+		fmt.Fprintf(&b, "  %s -.->|depends on| %s\n", ids[e.From], ids[e.To])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// nodeLines returns the lines of the label of a node in a diagram.
+func nodeLines(n domain.PlanNode) []string {
+	lines := []string{n.Name, n.Kind}
+	if n.Namespace != "" {
+		lines = append(lines, "namespace: "+n.Namespace)
+	}
+	if n.Resource != "" {
+		lines = append(lines, "renders: "+n.Resource)
+	}
+	return lines
+}
@@ -0,0 +1,95 @@
+package cribctl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+func TestWritePlanGraph(t *testing.T) {
+	t.Parallel()
+
+	graph := &domain.PlanGraph{
+		Plan:      "root",
+		Namespace: "crib",
+		Nodes: []domain.PlanNode{
+			{ID: "plan:root", Name: "root", Kind: domain.PlanNodeKindPlan, Namespace: "crib"},
+			{ID: "app/db", Name: "db", Parent: "plan:root", Kind: domain.PlanNodeKindChart, Namespace: "crib"},
+			{ID: "app/api", Name: "api", Parent: "plan:root", Kind: domain.PlanNodeKindChart, Namespace: "crib"},
+			{ID: "app/api/cm", Name: "cm", Parent: "app/api", Kind: "ConfigMap", Namespace: "crib", Resource: `ConfigMap/"api"`},
+		},
+		Edges: []domain.PlanEdge{{From: "app/api", To: "app/db"}},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: PreviewFormatDOT,
+			want: `digraph "root" {
+  node [shape=box];
+  "plan:root" [label="root\nPlan\nnamespace: crib"];
+  "app/db" [label="db\nChart\nnamespace: crib"];
+  "app/api" [label="api\nChart\nnamespace: crib"];
+  "app/api/cm" [label="cm\nConfigMap\nnamespace: crib\nrenders: ConfigMap/\"api\""];
+  "plan:root" -> "app/db" [arrowhead=none];
+  "plan:root" -> "app/api" [arrowhead=none];
+  "app/api" -> "app/api/cm" [arrowhead=none];
+  "app/api" -> "app/db" [style=dashed, label="depends on"];
+}
+`,
+		},
+		{
+			format: PreviewFormatMermaid,
+			want: `flowchart TD
+  n0["root<br/>Plan<br/>namespace: crib"]
+  n1["db<br/>Chart<br/>namespace: crib"]
+  n2["api<br/>Chart<br/>namespace: crib"]
+  n3["cm<br/>ConfigMap<br/>namespace: crib<br/>renders: ConfigMap/#quot;api#quot;"]
+  n0 --- n1
+  n0 --- n2
+  n2 --- n3
+  n2 -.->|depends on| n1
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, WritePlanGraph(&buf, graph, tc.format))
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+
+	t.Run(PreviewFormatJSON, func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, WritePlanGraph(&buf, graph, PreviewFormatJSON))
+		assert.JSONEq(t, `{
+			"plan": "root",
+			"namespace": "crib",
+			"nodes": [
+				{"id": "plan:root", "name": "root", "kind": "Plan", "namespace": "crib"},
+				{"id": "app/db", "name": "db", "parent": "plan:root", "kind": "Chart", "namespace": "crib"},
+				{"id": "app/api", "name": "api", "parent": "plan:root", "kind": "Chart", "namespace": "crib"},
+				{"id": "app/api/cm", "name": "cm", "parent": "app/api", "kind": "ConfigMap", "namespace": "crib", "resource": "ConfigMap/\"api\""}
+			],
+			"edges": [{"from": "app/api", "to": "app/db"}]
+		}`, buf.String())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		err := WritePlanGraph(&bytes.Buffer{}, graph, PreviewFormatTree)
+		assert.ErrorContains(t, err, `unsupported preview format "tree"`)
+	})
+}
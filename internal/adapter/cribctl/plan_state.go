@@ -0,0 +1,95 @@
+package cribctl
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+const pendingStatus = "pending"
+
+// WritePlanStatus writes a human-readable summary of the plan record to w.
+func WritePlanStatus(w io.Writer, record *domain.PlanRecord) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Plan:\t%s\n", record.Name)
+	fmt.Fprintf(tw, "Namespace:\t%s\n", cmp.Or(record.Namespace, "-"))
+	fmt.Fprintf(tw, "Applied at:\t%s\n", record.AppliedAt.Local().Format(time.RFC3339))
+
+	if len(record.Charts) > 0 {
+		fmt.Fprintln(tw, "\nCHART\tVERSION")
+		for _, c := range record.Charts {
+			fmt.Fprintf(tw, "%s\t%s\n", c.Name, c.Version)
+		}
+	}
+
+	fmt.Fprintln(tw, "\nBUNDLE\tACTION\tSTATUS")
+	var failed []domain.BundleRecord
+	for _, b := range record.Bundles {
+		action, status := "kubectl", pendingStatus
+		if b.IsLocal {
+			action = "client-side"
+		}
+		if b.Step != nil {
+			status = b.Step.Status
+			if b.Step.Error != "" {
+				failed = append(failed, b)
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", b.Key, action, status)
+	}
+	for _, b := range failed {
+		fmt.Fprintf(tw, "\n%s %s: %s\n", b.Key, b.Step.Status, b.Step.Error)
+	}
+	return tw.Flush()
+}
+
+// WriteApplyReport writes a summary table of the apply report to w, followed by the errors of
+// the bundles that failed.
+func WriteApplyReport(w io.Writer, report domain.ApplyReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BUNDLE\tACTION\tSTATUS\tEXIT\tDURATION")
+	for _, r := range report {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", r.Bundle, cmp.Or(r.Action, "-"), r.Status, r.ExitCode, r.Duration.Round(time.Millisecond))
+	}
+	for _, r := range report {
+		if r.Failed() {
+			fmt.Fprintf(tw, "\n%s %s: %v\n", r.Bundle, r.Status, r.Err)
+		}
+	}
+	return tw.Flush()
+}
+
+// WritePlanDrift writes a human-readable summary of the plan drift to w.
+func WritePlanDrift(w io.Writer, name string, drift *domain.PlanDrift) error {
+	if !drift.HasDrift() {
+		_, err := fmt.Fprintf(w, "No drift detected for plan %q.\n", name)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Drift detected for plan %q:\n\n", name)
+	fmt.Fprintln(tw, "BUNDLE\tDRIFT")
+	for _, key := range drift.Added {
+		fmt.Fprintf(tw, "%s\tnot applied\n", key)
+	}
+	for _, key := range drift.Removed {
+		fmt.Fprintf(tw, "%s\tno longer rendered\n", key)
+	}
+	for _, key := range drift.Changed {
+		fmt.Fprintf(tw, "%s\tchanged since apply\n", key)
+	}
+	for _, key := range drift.Live {
+		fmt.Fprintf(tw, "%s\tdiffers from cluster\n", key)
+	}
+	if len(drift.Charts) > 0 {
+		fmt.Fprintln(tw, "\nCHART\tAPPLIED\tRENDERED")
+		for _, c := range drift.Charts {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Name, c.Applied, c.Rendered)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,97 @@
+package cribctl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+func TestWritePlanStatus(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	record := &domain.PlanRecord{
+		Name:      "test",
+		AppliedAt: time.Now(),
+		Bundles: []domain.BundleRecord{
+			{Key: "ns/Namespace.a.k8s.yaml", Step: &domain.StepRecord{Status: domain.StepStatusSucceeded}},
+			{Key: "csa/ClientSideApply.b.k8s.yaml", IsLocal: true, Step: &domain.StepRecord{Status: domain.StepStatusContinued, Error: "boom"}},
+			{Key: "svc/Service.c.k8s.yaml"},
+			{Key: "csa/ClientSideApply.d.k8s.yaml", IsLocal: true, Step: &domain.StepRecord{Status: domain.StepStatusSkipped}},
+			{Key: "csa/ClientSideApply.e.k8s.yaml", IsLocal: true, Step: &domain.StepRecord{Status: domain.StepStatusAborted, Error: "bad manifest"}},
+		},
+		Charts: domain.HelmChartVersions{{Name: "anvil", Version: "0.1.0"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePlanStatus(&buf, record))
+	out := buf.String()
+	is.Contains(out, "Plan:        test\n")
+	is.Contains(out, "Namespace:   -\n")
+	is.Contains(out, "anvil  0.1.0\n")
+	is.Contains(out, "ns/Namespace.a.k8s.yaml         kubectl      succeeded\n")
+	is.Contains(out, "csa/ClientSideApply.b.k8s.yaml  client-side  continued\n")
+	is.Contains(out, "svc/Service.c.k8s.yaml          kubectl      pending\n")
+	is.Contains(out, "csa/ClientSideApply.d.k8s.yaml  client-side  skipped\n")
+	is.Contains(out, "\ncsa/ClientSideApply.b.k8s.yaml continued: boom\n")
+	is.Contains(out, "\ncsa/ClientSideApply.e.k8s.yaml aborted: bad manifest\n")
+	is.NotContains(out, "failed")
+	is.NotContains(out, "csa/ClientSideApply.d.k8s.yaml skipped")
+}
+
+func TestWritePlanDrift(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePlanDrift(&buf, "test", &domain.PlanDrift{}))
+	is.Equal("No drift detected for plan \"test\".\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WritePlanDrift(&buf, "test", &domain.PlanDrift{
+		Added:   []string{"a"},
+		Removed: []string{"b"},
+		Changed: []string{"c"},
+		Live:    []string{"d"},
+		Charts:  []domain.ChartDrift{{Name: "anvil", Applied: "0.1.0", Rendered: "0.2.0"}},
+	}))
+	is.Equal(`Drift detected for plan "test":
+
+BUNDLE  DRIFT
+a       not applied
+b       no longer rendered
+c       changed since apply
+d       differs from cluster
+
+CHART  APPLIED  RENDERED
+anvil  0.1.0    0.2.0
+`, buf.String())
+}
+
+func TestWriteApplyReport(t *testing.T) {
+	t.Parallel()
+
+	report := domain.ApplyReport{
+		{Bundle: "ns/Namespace.a.k8s.yaml", Action: "kubectl", Status: domain.StepStatusSucceeded, Duration: 1500 * time.Millisecond},
+		{Bundle: "csa/ClientSideApply.b.k8s.yaml", Action: "cmd", Status: domain.StepStatusContinued, ExitCode: 2, Duration: time.Second, Err: errors.New("boom")},
+		{Bundle: "csa/ClientSideApply.c.k8s.yaml", Status: domain.StepStatusAborted, ExitCode: -1, Err: errors.New("bad manifest")},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteApplyReport(&buf, report))
+	assert.Equal(t, `BUNDLE                          ACTION   STATUS     EXIT  DURATION
+ns/Namespace.a.k8s.yaml         kubectl  succeeded  0     1.5s
+csa/ClientSideApply.b.k8s.yaml  cmd      continued  2     1s
+csa/ClientSideApply.c.k8s.yaml  -        aborted    -1    0s
+
+csa/ClientSideApply.b.k8s.yaml continued: boom
+
+csa/ClientSideApply.c.k8s.yaml aborted: bad manifest
+`, buf.String())
+}
@@ -0,0 +1,128 @@
+package cribctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+// Formats of the progress written while a plan is applied.
+const (
+	// LogFormatText writes a line per started and finished bundle, for terminals.
+	LogFormatText = "text"
+	// LogFormatJSON writes an event per started and finished bundle as a line of JSON, e.g. for CI.
+	LogFormatJSON = "json"
+)
+
+// DefaultTailLines is the number of trailing lines of the output of a failed bundle that are shown.
+const DefaultTailLines = 20
+
+type (
+	// textProgress writes the progress of an apply as a line per bundle.
+	textProgress struct {
+		w         io.Writer
+		tailLines int
+	}
+
+	// jsonProgress writes the progress of an apply as a JSON event per line.
+	jsonProgress struct {
+		enc       *json.Encoder
+		tailLines int
+		now       func() time.Time
+	}
+
+	// progressEvent is an event written by jsonProgress.
+	progressEvent struct {
+		Time     time.Time `json:"time"`
+		Event    string    `json:"event"`
+		Bundle   string    `json:"bundle"`
+		Action   string    `json:"action,omitempty"`
+		Status   string    `json:"status,omitempty"`
+		ExitCode int       `json:"exitCode,omitempty"`
+		Duration string    `json:"duration,omitempty"`
+		Log      string    `json:"log,omitempty"`
+		Error    string    `json:"error,omitempty"`
+		// Tail holds the last lines of the output of failed bundles.
+		Tail []string `json:"tail,omitempty"`
+	}
+)
+
+// NewApplyProgress returns the progress that is written to w while a plan is applied, in the given
+// format. The last tailLines lines of the output of failed bundles are written along with them.
+func NewApplyProgress(w io.Writer, format string, tailLines int) (port.ApplyProgress, error) {
+	switch format {
+	case LogFormatText, "":
+		return &textProgress{w: w, tailLines: tailLines}, nil
+	case LogFormatJSON:
+		return &jsonProgress{enc: json.NewEncoder(w), tailLines: tailLines, now: time.Now}, nil
+	}
+	return nil, fmt.Errorf("unsupported log format %q: must be one of %s, %s", format, LogFormatText, LogFormatJSON)
+}
+
+func (p *textProgress) BundleStarted(r domain.BundleReport) {
+	fmt.Fprintf(p.w, "▸ %s\n", r.Bundle)
+}
+
+func (p *textProgress) BundleFinished(r domain.BundleReport) {
+	switch {
+	case r.Status == domain.StepStatusSkipped:
+		fmt.Fprintf(p.w, "- %s skipped\n", r.Bundle)
+		return
+	case !r.Failed():
+		fmt.Fprintf(p.w, "✓ %s (%s, %s)\n", r.Bundle, r.Action, r.Duration.Round(time.Millisecond))
+		return
+	}
+	fmt.Fprintf(p.w, "✗ %s %s (%s, exit %d, %s): %v\n", r.Bundle, r.Status, r.Action, r.ExitCode, r.Duration.Round(time.Millisecond), r.Err)
+	for _, line := range lastLines(r.Output, p.tailLines) {
+		fmt.Fprintf(p.w, "  │ %s\n", line)
+	}
+	if r.LogFile != "" {
+		fmt.Fprintf(p.w, "  Full output: %s\n", r.LogFile)
+	}
+}
+
+func (p *jsonProgress) BundleStarted(r domain.BundleReport) {
+	_ = p.enc.Encode(progressEvent{Time: p.now(), Event: "started", Bundle: r.Bundle})
+}
+
+func (p *jsonProgress) BundleFinished(r domain.BundleReport) {
+	e := progressEvent{
+		Time:     p.now(),
+		Event:    "finished",
+		Bundle:   r.Bundle,
+		Action:   r.Action,
+		Status:   r.Status,
+		ExitCode: r.ExitCode,
+		Log:      r.LogFile,
+	}
+	if r.Status != domain.StepStatusSkipped {
+		e.Duration = r.Duration.Round(time.Millisecond).String()
+	}
+	if r.Failed() {
+		e.Tail = lastLines(r.Output, p.tailLines)
+	}
+	if r.Err != nil {
+		e.Error = r.Err.Error()
+	}
+	_ = p.enc.Encode(e)
+}
+
+// lastLines returns at most the last n lines of the output, without their line endings.
+func lastLines(output []byte, n int) []string {
+	output = bytes.TrimRight(output, "\r\n")
+	if n <= 0 || len(output) == 0 {
+		return nil
+	}
+	lines := bytes.Split(output, []byte("\n"))
+	lines = lines[max(len(lines)-n, 0):]
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = string(bytes.TrimSuffix(line, []byte("\r")))
+	}
+	return out
+}
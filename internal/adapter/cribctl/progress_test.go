@@ -0,0 +1,81 @@
+package cribctl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+var progressReports = []domain.BundleReport{
+	{Bundle: "ns/Namespace.a.k8s.yaml", Action: "kubectl", Status: domain.StepStatusSucceeded, Duration: 1500 * time.Millisecond},
+	{Bundle: "csa/ClientSideApply.b.k8s.yaml", Status: domain.StepStatusSkipped},
+	{
+		Bundle:   "csa/ClientSideApply.c.k8s.yaml",
+		Action:   "cmd",
+		Status:   domain.StepStatusAborted,
+		ExitCode: 2,
+		Duration: time.Second,
+		Output:   []byte("first\nsecond\nthird\n"),
+		LogFile:  "/tmp/render/logs/02-csa/00-cmd.log",
+		Err:      errors.New("boom"),
+	},
+}
+
+func TestTextProgress(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	progress, err := NewApplyProgress(&buf, LogFormatText, 2)
+	require.NoError(t, err)
+	for _, r := range progressReports {
+		if r.Status != domain.StepStatusSkipped {
+			progress.BundleStarted(r)
+		}
+		progress.BundleFinished(r)
+	}
+	assert.Equal(t, `▸ ns/Namespace.a.k8s.yaml
+✓ ns/Namespace.a.k8s.yaml (kubectl, 1.5s)
+- csa/ClientSideApply.b.k8s.yaml skipped
+▸ csa/ClientSideApply.c.k8s.yaml
+✗ csa/ClientSideApply.c.k8s.yaml aborted (cmd, exit 2, 1s): boom
+  │ second
+  │ third
+  Full output: /tmp/render/logs/02-csa/00-cmd.log
+`, buf.String())
+}
+
+func TestJSONProgress(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	progress, err := NewApplyProgress(&buf, LogFormatJSON, 2)
+	require.NoError(t, err)
+	progress.(*jsonProgress).now = func() time.Time { return time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC) }
+	progress.BundleStarted(progressReports[0])
+	for _, r := range progressReports {
+		progress.BundleFinished(r)
+	}
+	assert.Equal(t, `{"time":"2025-07-01T12:00:00Z","event":"started","bundle":"ns/Namespace.a.k8s.yaml"}
+{"time":"2025-07-01T12:00:00Z","event":"finished","bundle":"ns/Namespace.a.k8s.yaml","action":"kubectl","status":"succeeded","duration":"1.5s"}
+{"time":"2025-07-01T12:00:00Z","event":"finished","bundle":"csa/ClientSideApply.b.k8s.yaml","status":"skipped"}
+{"time":"2025-07-01T12:00:00Z","event":"finished","bundle":"csa/ClientSideApply.c.k8s.yaml","action":"cmd","status":"aborted","exitCode":2,"duration":"1s","log":"/tmp/render/logs/02-csa/00-cmd.log","error":"boom","tail":["second","third"]}
+`, buf.String())
+
+	_, err = NewApplyProgress(&buf, "yaml", 2)
+	assert.EqualError(t, err, `unsupported log format "yaml": must be one of text, json`)
+}
+
+func TestLastLines(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"b", "c"}, lastLines([]byte("a\nb\r\nc\n\n"), 2))
+	assert.Equal(t, []string{"a"}, lastLines([]byte("a"), 5))
+	assert.Nil(t, lastLines(nil, 5))
+	assert.Nil(t, lastLines([]byte("a\n"), 0))
+}
@@ -0,0 +1,122 @@
+package cribctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/smartcontractkit/crib-sdk/contrib"
+	"github.com/smartcontractkit/crib-sdk/crib"
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/jsonschema"
+)
+
+// PlanSchemaName is the name of the JSON Schema of YAML plan files.
+const PlanSchemaName = "plan"
+
+// ComponentSchema returns the JSON Schema of the props of the component.
+func ComponentSchema(c crib.ComponentRegistration) *jsonschema.Schema {
+	s := jsonschema.For(c.PropsType())
+	s.Title = c.Name()
+	s.Description = c.Description()
+	return s
+}
+
+// PlanSchema returns the JSON Schema of YAML plan files, see contrib.PlanFile. The props of each
+// component are checked against the schema of the component it names.
+func PlanSchema() *jsonschema.Schema {
+	s := jsonschema.For(reflect.TypeFor[contrib.PlanFile]())
+	s.Schema = jsonschema.Draft
+	s.Title = "CRIB-SDK: Plan"
+	s.Required = []string{"name"}
+	s.Defs = make(map[string]*jsonschema.Schema)
+
+	plans := s.Properties["plans"].Items
+	for _, name := range contrib.Plans() {
+		plans.Enum = append(plans.Enum, name)
+	}
+
+	component := s.Properties["components"].Items
+	component.Required = []string{"component"}
+	for _, name := range contrib.Components() {
+		c, _ := contrib.Component(name)
+		component.Properties["component"].Enum = append(component.Properties["component"].Enum, name)
+		s.Defs[name] = ComponentSchema(c)
+		component.AllOf = append(component.AllOf, &jsonschema.Schema{
+			If: &jsonschema.Schema{
+				Properties: map[string]*jsonschema.Schema{"component": {Const: name}},
+			},
+			Then: &jsonschema.Schema{
+				Properties: map[string]*jsonschema.Schema{"props": {Ref: "#/$defs/" + name}},
+			},
+		})
+	}
+	return s
+}
+
+// WriteSchema writes the JSON Schema with the given name to w: the schema of plan files for
+// PlanSchemaName, and otherwise the schema of the props of the named component.
+func WriteSchema(w io.Writer, name string) error {
+	s, err := schema(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// WriteSchemas writes the JSON Schema of plan files to dir/plan.json and the schema of every registered
+// component to dir/components/<name>.json.
+func WriteSchemas(dir string) error {
+	names := append([]string{PlanSchemaName}, contrib.Components()...)
+	if err := os.MkdirAll(filepath.Join(dir, "components"), 0o755); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := writeSchemaFile(schemaPath(dir, name), name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schema returns the JSON Schema with the given name, see WriteSchema.
+func schema(name string) (*jsonschema.Schema, error) {
+	if name == PlanSchemaName {
+		return PlanSchema(), nil
+	}
+	c, err := LookupComponent(name)
+	if err != nil {
+		return nil, err
+	}
+	s := ComponentSchema(c)
+	s.Schema = jsonschema.Draft
+	return s, nil
+}
+
+// schemaPath returns the path of the file of the named schema within dir.
+func schemaPath(dir, name string) string {
+	if name == PlanSchemaName {
+		return filepath.Join(dir, name+".json")
+	}
+	return filepath.Join(dir, "components", name+".json")
+}
+
+func writeSchemaFile(path, name string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	if err := WriteSchema(f, name); err != nil {
+		return fmt.Errorf("writing schema %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cribctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaDir is the directory of the JSON Schemas checked into the repository.
+const schemaDir = "../../../api/json-schemas"
+
+func TestSchemasUpToDate(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+
+	dir := t.TempDir()
+	must.NoError(WriteSchemas(dir))
+
+	want, err := os.ReadDir(filepath.Join(dir, "components"))
+	must.NoError(err)
+	got, err := os.ReadDir(filepath.Join(schemaDir, "components"))
+	must.NoError(err)
+	must.Equal(len(want), len(got), "run `task generate:schemas` to update the schemas")
+
+	for _, name := range append([]string{PlanSchemaName + ".json"}, entryNames(want, "components")...) {
+		generated, err := os.ReadFile(filepath.Join(dir, name))
+		must.NoError(err)
+		checkedIn, err := os.ReadFile(filepath.Join(schemaDir, name))
+		must.NoError(err, "run `task generate:schemas` to update the schemas")
+		assert.Equal(t, string(generated), string(checkedIn), "%s is out of date, run `task generate:schemas`", name)
+	}
+}
+
+func TestWriteSchema(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+
+	var buf bytes.Buffer
+	must.NoError(WriteSchema(&buf, "sdk.ClientSideApply"))
+	var s map[string]any
+	must.NoError(json.Unmarshal(buf.Bytes(), &s))
+	assert.Equal(t, "sdk.ClientSideApply", s["title"])
+	assert.Equal(t, []any{"action", "args"}, s["required"])
+
+	assert.ErrorContains(t, WriteSchema(&buf, "sdk.Missing"), "no component found")
+}
+
+func entryNames(entries []os.DirEntry, dir string) []string {
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = filepath.Join(dir, e.Name())
+	}
+	return names
+}
@@ -248,6 +248,14 @@ func (h *Handler) Create(name string) (*os.File, error) {
 	return h.root.Create(name)
 }
 
+// Remove removes the named file or empty directory relative to the Handler's root directory.
+func (h *Handler) Remove(name string) error {
+	if h.root == nil {
+		return domain.ErrReadOnlyFileSystem
+	}
+	return h.root.Remove(name)
+}
+
 // RemoveAll deletes the entire file tree rooted at the Handler's root directory.
 // This can be a highly destructive action if used incorrectly. It's provided primarily
 // for the TempHandler, which is used for temporary file operations.
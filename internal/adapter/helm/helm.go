@@ -7,6 +7,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/clientsideapply"
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
@@ -188,12 +189,16 @@ func (c *Client) searchRepo(ctx context.Context, release port.ChartReleaser, all
 	return versions, nil
 }
 
-func (c *Client) runCommand(ctx context.Context, args ...string) (*domain.RunnerResult, error) {
+func (c *Client) runCommand(ctx context.Context, args ...string) (res *domain.RunnerResult, err error) {
 	input := &domain.ClientSideApplyManifest{
 		Spec: domain.ClientSideApplySpec{
 			Action: domain.ActionHelm,
 			Args:   args,
 		},
 	}
-	return c.executor.Execute(ctx, input)
+	// Helm makes no JSII calls, so components of a Composite that are applied concurrently may proceed meanwhile.
+	internal.WithoutJSIILock(ctx, func() {
+		res, err = c.executor.Execute(ctx, input)
+	})
+	return res, err
 }
@@ -0,0 +1,289 @@
+// Package jsonschema generates JSON Schemas from Go types by reflection. It is used to describe the props
+// of components, so that editors can complete and check YAML plan files. The validate and default struct
+// tags of the fields are translated into the matching JSON Schema keywords.
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Patterns for the validate tags without a JSON Schema keyword of their own.
+const (
+	dnsLabelPattern    = `^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	durationPattern    = `^(0|(\d+(\.\d+)?(ns|us|µs|ms|s|m|h))+)$`
+	hexadecimalPattern = `^(0[xX])?[0-9a-fA-F]+$`
+	imageURIPattern    = `^[^\s@]+(@[a-z0-9]+:[a-fA-F0-9]+)?$`
+)
+
+// Schema is a JSON Schema. Only the keywords used by the generator are supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	If                   *Schema            `json:"if,omitempty"`
+	Then                 *Schema            `json:"then,omitempty"`
+}
+
+// For returns the schema of the Go type t. Struct fields are named as they are decoded by encoding/json,
+// taking the name from the json tag if any, and otherwise writing the Go name in lower camel case.
+func For(t reflect.Type) *Schema {
+	return forType(t, map[reflect.Type]bool{})
+}
+
+// PropertyName returns the name of the property of the struct field in a generated schema.
+func PropertyName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return lowerCamel(f.Name)
+}
+
+// forType returns the schema of t. Struct types that are already being visited are not expanded again,
+// so that recursive types terminate.
+func forType(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings.
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: forType(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: forType(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return &Schema{Type: "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		return forStruct(t, visiting)
+	}
+	// Interfaces and functions accept any value.
+	return &Schema{}
+}
+
+// forStruct returns the schema of the struct type t.
+func forStruct(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous || f.Tag.Get("json") == "-" {
+			continue
+		}
+		name := PropertyName(f)
+		prop := forType(f.Type, visiting)
+		def, hasDefault := f.Tag.Lookup("default")
+		if hasDefault {
+			prop.Default = defaultValue(def, prop.Type)
+		}
+		// Defaults are set before the props are validated, so fields with a default can be left out.
+		if applyRules(prop, f.Tag.Get("validate")) && !hasDefault {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+	return s
+}
+
+// applyRules translates the rules of a validate tag into keywords of the schema. Rules following dive
+// apply to the items of the schema. Rules without a JSON Schema equivalent, such as alternatives
+// separated by "|", are left to the validator. It reports whether the value is required.
+func applyRules(s *Schema, tag string) (required bool) {
+	if tag == "" {
+		return false
+	}
+	rules, itemRules, dive := strings.Cut(tag, ",dive")
+	if dive {
+		if items := s.Items; items != nil {
+			applyRules(items, strings.TrimPrefix(itemRules, ","))
+		} else if values, ok := s.AdditionalProperties.(*Schema); ok {
+			applyRules(values, strings.TrimPrefix(itemRules, ","))
+		}
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		if strings.Contains(rule, "|") {
+			continue
+		}
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+			if s.Type == "string" && s.MinLength == nil {
+				// The validator rejects the zero value of required fields.
+				s.setBound("1", nil, &s.MinLength, nil, nil)
+			}
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				s.Enum = append(s.Enum, typedValue(v, s.Type))
+			}
+		case "csa_action":
+			for _, v := range domain.ActionNames() {
+				s.Enum = append(s.Enum, v)
+			}
+		case "min", "gte":
+			s.setBound(param, &s.Minimum, &s.MinLength, &s.MinItems, &s.MinProperties)
+		case "max", "lte":
+			s.setBound(param, &s.Maximum, &s.MaxLength, &s.MaxItems, &s.MaxProperties)
+		case "gt":
+			s.setExclusiveBound(param, &s.ExclusiveMinimum, &s.MinLength, &s.MinItems, &s.MinProperties, 1)
+		case "lt":
+			s.setExclusiveBound(param, &s.ExclusiveMaximum, &s.MaxLength, &s.MaxItems, &s.MaxProperties, -1)
+		case "len":
+			s.setBound(param, nil, &s.MinLength, &s.MinItems, &s.MinProperties)
+			s.setBound(param, nil, &s.MaxLength, &s.MaxItems, &s.MaxProperties)
+		case "dns_rfc1035_label":
+			s.Pattern = dnsLabelPattern
+			s.setBound("63", nil, &s.MaxLength, nil, nil)
+		case "hexadecimal":
+			s.Pattern = hexadecimalPattern
+		case "image_uri":
+			s.Pattern = imageURIPattern
+		case "duration":
+			s.Pattern = durationPattern
+		case "url", "uri", "http_url":
+			s.Format = "uri"
+		case "email":
+			s.Format = "email"
+		case "hostname", "hostname_rfc1123":
+			s.Format = "hostname"
+		case "ip":
+			s.Format = "ipv4"
+		case "semver":
+			s.Pattern = `^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`
+		case "eq":
+			s.Const = typedValue(param, s.Type)
+		}
+	}
+	return required
+}
+
+// setBound sets the bound given by param on the keyword that matches the type of the schema. A keyword
+// passed as nil is not set.
+func (s *Schema) setBound(param string, number **float64, length, items, properties **int) {
+	s.setExclusiveBound(param, number, length, items, properties, 0)
+}
+
+// setExclusiveBound is like setBound, shifting the bound by offset for the keywords that are inclusive.
+func (s *Schema) setExclusiveBound(param string, number **float64, length, items, properties **int, offset int) {
+	switch s.Type {
+	case "integer", "number":
+		if v, err := strconv.ParseFloat(param, 64); err == nil && number != nil {
+			*number = &v
+		}
+		return
+	}
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+	n += offset
+	var target **int
+	switch s.Type {
+	case "string":
+		target = length
+	case "array":
+		target = items
+	case "object":
+		target = properties
+	}
+	if target != nil {
+		*target = &n
+	}
+}
+
+// defaultValue returns the value of a default tag. Defaults of values other than strings are written as
+// JSON by convention, e.g. `default:"[\"--skip-tests\"]"`.
+func defaultValue(def, typ string) any {
+	if typ == "string" {
+		return def
+	}
+	var v any
+	if err := json.Unmarshal([]byte(def), &v); err == nil {
+		return v
+	}
+	return def
+}
+
+// typedValue converts a value of a validate tag to the type of the schema.
+func typedValue(v, typ string) any {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return v
+}
+
+// lowerCamel writes a Go identifier in lower camel case, keeping initialisms together,
+// e.g. "CSAEncryptionKey" becomes "csaEncryptionKey" and "JD" becomes "jd".
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		// The last upper case letter of an initialism starts the next word.
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
@@ -0,0 +1,66 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testProps struct {
+		Namespace string            `validate:"omitempty,lte=63,dns_rfc1035_label"`
+		Image     string            `validate:"required,image_uri"`
+		Action    string            `default:"abort" validate:"required,oneof=continue abort"`
+		Replicas  int32             `default:"1"     validate:"min=1,max=5"`
+		Args      []string          `validate:"required,gt=0,dive,required"`
+		Limits    map[string]string `default:"{\"cpu\": \"1\"}"`
+		Version   string            `validate:"omitempty,semver|eq=main"`
+		Child     *testProps
+		Renamed   string `json:"name,omitempty"`
+		Skipped   string `json:"-"`
+		hidden    string
+	}
+)
+
+func TestFor(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+
+	got, err := json.Marshal(For(reflect.TypeFor[testProps]()))
+	must.NoError(err)
+	must.JSONEq(`{
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["image", "args"],
+		"properties": {
+			"namespace": {"type": "string", "maxLength": 63, "pattern": "^[a-z]([-a-z0-9]*[a-z0-9])?$"},
+			"image": {"type": "string", "minLength": 1, "pattern": "^[^\\s@]+(@[a-z0-9]+:[a-fA-F0-9]+)?$"},
+			"action": {"type": "string", "minLength": 1, "default": "abort", "enum": ["continue", "abort"]},
+			"replicas": {"type": "integer", "default": 1, "minimum": 1, "maximum": 5},
+			"args": {"type": "array", "minItems": 1, "items": {"type": "string", "minLength": 1}},
+			"limits": {"type": "object", "default": {"cpu": "1"}, "additionalProperties": {"type": "string"}},
+			"version": {"type": "string"},
+			"child": {"type": "object"},
+			"name": {"type": "string"}
+		}
+	}`, string(got))
+}
+
+func TestLowerCamel(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"Namespace":        "namespace",
+		"JD":               "jd",
+		"ChainID":          "chainID",
+		"CSAEncryptionKey": "csaEncryptionKey",
+		"DB":               "db",
+		"URL":              "url",
+		"a":                "a",
+	} {
+		assert.Equal(t, want, lowerCamel(in), in)
+	}
+}
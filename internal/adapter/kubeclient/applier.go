@@ -0,0 +1,402 @@
+package kubeclient
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// Defaults of the Applier.
+const (
+	// DefaultFieldManager is the field manager that owns the fields applied by cribctl.
+	DefaultFieldManager = "cribctl"
+	// DefaultTimeout is how long to wait for an object to be established or deleted.
+	DefaultTimeout = 5 * time.Minute
+	// DefaultPollInterval is how often the state of an object is checked while waiting.
+	DefaultPollInterval = time.Second
+)
+
+// crdKind is the kind of CustomResourceDefinitions, whose custom resources can only be applied once
+// the definition is established.
+var crdKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+type (
+	// Applier applies objects with server-side apply, and deletes and lists them, through the Kubernetes API.
+	// It implements [port.KubernetesApplier] and [port.KubernetesReader].
+	Applier struct {
+		client       dynamic.Interface
+		discovery    discovery.DiscoveryInterface
+		mapper       meta.ResettableRESTMapper
+		namespace    string
+		fieldManager string
+		timeout      time.Duration
+		interval     time.Duration
+	}
+
+	// Opt is a functional option for configuring an Applier.
+	Opt func(*Applier)
+)
+
+// New creates an Applier that uses the given clients, e.g. fakes in tests.
+func New(client dynamic.Interface, disc discovery.DiscoveryInterface, opts ...Opt) *Applier {
+	a := &Applier{
+		client:       client,
+		discovery:    disc,
+		mapper:       restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(disc)),
+		namespace:    metav1.NamespaceDefault,
+		fieldManager: DefaultFieldManager,
+		timeout:      DefaultTimeout,
+		interval:     DefaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// NewFromKubeconfig creates an Applier for the current context of the kubeconfig, which is loaded the
+// same way kubectl loads it, honoring $KUBECONFIG. Objects without a namespace are applied to the
+// namespace of the context.
+func NewFromKubeconfig(opts ...Opt) (*Applier, error) {
+	cfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+	rest, err := cfg.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	namespace, _, err := cfg.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	client, err := dynamic.NewForConfig(rest)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kubernetes client: %w", err)
+	}
+	disc, err := discovery.NewDiscoveryClientForConfig(rest)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kubernetes discovery client: %w", err)
+	}
+	return New(client, disc, append([]Opt{WithNamespace(namespace)}, opts...)...), nil
+}
+
+// WithNamespace sets the namespace of the objects that do not set their own.
+func WithNamespace(namespace string) Opt {
+	return func(a *Applier) {
+		if namespace != "" {
+			a.namespace = namespace
+		}
+	}
+}
+
+// WithFieldManager sets the field manager that owns the applied fields, see DefaultFieldManager.
+func WithFieldManager(name string) Opt {
+	return func(a *Applier) {
+		a.fieldManager = name
+	}
+}
+
+// WithTimeout sets how long to wait for an object to be established or deleted, see DefaultTimeout.
+func WithTimeout(timeout time.Duration) Opt {
+	return func(a *Applier) {
+		a.timeout = timeout
+	}
+}
+
+// WithPollInterval sets how often the state of an object is checked while waiting, see DefaultPollInterval.
+func WithPollInterval(interval time.Duration) Opt {
+	return func(a *Applier) {
+		a.interval = interval
+	}
+}
+
+// Apply applies the objects in order with server-side apply, forcing the ownership of conflicting fields
+// to the field manager of the Applier. Like kubectl apply, it does not wait for workloads to become ready,
+// but custom resources can be applied right after their CustomResourceDefinition, since the definitions
+// are waited for until they are established.
+func (a *Applier) Apply(ctx context.Context, objects []domain.GenericManifest) ([]domain.ObjectResult, error) {
+	results := make([]domain.ObjectResult, 0, len(objects))
+	var errs []error
+	for _, doc := range objects {
+		r := a.apply(ctx, doc)
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("applying %s: %w", r.Object, r.Err))
+		}
+		results = append(results, r)
+	}
+	return results, errors.Join(errs...)
+}
+
+// Delete deletes the objects in reverse order, waiting for each object to be gone before deleting the next.
+// Objects whose type is no longer served by the cluster do not exist either, and are reported as not found.
+func (a *Applier) Delete(ctx context.Context, objects []domain.GenericManifest) ([]domain.ObjectResult, error) {
+	results := make([]domain.ObjectResult, 0, len(objects))
+	var errs []error
+	for _, doc := range slices.Backward(objects) {
+		r := a.delete(ctx, doc)
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("deleting %s: %w", r.Object, r.Err))
+		}
+		results = append(results, r)
+	}
+	return results, errors.Join(errs...)
+}
+
+// List lists the objects matching the label selector, across every namespace and every resource type that
+// can be listed and deleted. Objects created by controllers are left out, see domain.IsControlled. Like kubectl, resource types that cannot be listed, e.g. because their API
+// service is unavailable, are skipped.
+func (a *Applier) List(ctx context.Context, selector string) ([]domain.ObjectRef, error) {
+	lists, err := discovery.ServerPreferredResources(a.discovery)
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("discovering resource types: %w", err)
+	}
+	lists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list", "delete"}}, lists)
+
+	var objs []domain.ObjectRef
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue // Subresources are listed with their resource.
+			}
+			items, err := a.client.Resource(gv.WithResource(r.Name)).List(ctx, metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				continue
+			}
+			for _, item := range items.Items {
+				if !domain.IsControlled(item.Object) {
+					objs = append(objs, objectRef(&item))
+				}
+			}
+		}
+	}
+	return objs, nil
+}
+
+// Read returns the objects that the wait condition is evaluated against, see port.KubernetesReader.
+// Namespaced objects are read from the namespace of the Applier if the condition does not set one.
+func (a *Applier) Read(ctx context.Context, spec *domain.WaitSpec) ([]domain.GenericManifest, error) {
+	apiVersion, kind := spec.Object()
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	return a.readKind(ctx, gv.WithKind(kind), spec)
+}
+
+// readKind returns the objects of the kind that match the name or label selector of the wait condition.
+func (a *Applier) readKind(ctx context.Context, gvk schema.GroupVersionKind, spec *domain.WaitSpec) ([]domain.GenericManifest, error) {
+	mapping, err := a.mapping(gvk)
+	if err != nil {
+		return nil, err
+	}
+	var client dynamic.ResourceInterface = a.client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		client = a.client.Resource(mapping.Resource).Namespace(cmp.Or(spec.Namespace, a.namespace))
+	}
+
+	if spec.Name != "" {
+		obj, err := client.Get(ctx, spec.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []domain.GenericManifest{obj.Object}, nil
+	}
+	list, err := client.List(ctx, metav1.ListOptions{LabelSelector: spec.Selector})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]domain.GenericManifest, len(list.Items))
+	for i, item := range list.Items {
+		objects[i] = item.Object
+	}
+	return objects, nil
+}
+
+// apply applies a single object and reports the outcome.
+func (a *Applier) apply(ctx context.Context, doc domain.GenericManifest) domain.ObjectResult {
+	r := domain.ObjectResult{Object: domain.NewObjectRef(doc)}
+	obj, err := newObject(doc)
+	if err != nil {
+		return failed(r, err)
+	}
+	client, err := a.resource(obj)
+	if err != nil {
+		return failed(r, err)
+	}
+	r.Object = objectRef(obj)
+
+	live, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return failed(r, err)
+	}
+	applied, err := client.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: a.fieldManager, Force: true})
+	if err != nil {
+		return failed(r, err)
+	}
+	switch {
+	case live == nil:
+		r.Action = domain.ObjectCreated
+	case live.GetResourceVersion() == applied.GetResourceVersion():
+		r.Action = domain.ObjectUnchanged
+	default:
+		r.Action = domain.ObjectConfigured
+	}
+
+	if obj.GroupVersionKind().GroupKind() == crdKind {
+		if err := a.poll(ctx, func(ctx context.Context) (bool, error) {
+			crd, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+			return err == nil && established(crd), err
+		}); err != nil {
+			return failed(r, fmt.Errorf("waiting to be established: %w", err))
+		}
+		// Forget the served types, so that the new custom resources can be mapped.
+		a.mapper.Reset()
+	}
+	return r
+}
+
+// delete deletes a single object and reports the outcome.
+func (a *Applier) delete(ctx context.Context, doc domain.GenericManifest) domain.ObjectResult {
+	r := domain.ObjectResult{Object: domain.NewObjectRef(doc)}
+	obj, err := newObject(doc)
+	if err != nil {
+		return failed(r, err)
+	}
+	client, err := a.resource(obj)
+	if meta.IsNoMatchError(err) {
+		r.Action = domain.ObjectNotFound
+		return r
+	}
+	if err != nil {
+		return failed(r, err)
+	}
+	r.Object = objectRef(obj)
+
+	propagation := metav1.DeletePropagationBackground
+	err = client.Delete(ctx, obj.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if apierrors.IsNotFound(err) {
+		r.Action = domain.ObjectNotFound
+		return r
+	}
+	if err != nil {
+		return failed(r, err)
+	}
+	if err := a.poll(ctx, func(ctx context.Context) (bool, error) {
+		_, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}); err != nil {
+		return failed(r, fmt.Errorf("waiting to be deleted: %w", err))
+	}
+	r.Action = domain.ObjectDeleted
+	return r
+}
+
+// newObject converts the manifest into an unstructured object.
+func newObject(doc domain.GenericManifest) (*unstructured.Unstructured, error) {
+	// A round trip through JSON converts the nested manifests and numbers into the types expected by
+	// unstructured objects.
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	obj := new(unstructured.Unstructured)
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, err
+	}
+	if obj.GetName() == "" {
+		return nil, errors.New("object has no name")
+	}
+	return obj, nil
+}
+
+// resource returns the client for the resource of the object. The namespace of namespaced objects is
+// set to the namespace of the Applier if they do not have one.
+func (a *Applier) resource(obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	mapping, err := a.mapping(obj.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return a.client.Resource(mapping.Resource), nil
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(a.namespace)
+	}
+	return a.client.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// mapping returns the resource of the kind.
+func (a *Applier) mapping(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// The type may have been added since the served types were discovered.
+		a.mapper.Reset()
+		mapping, err = a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	return mapping, err
+}
+
+// poll calls condition until it returns true, an error, or the timeout of the Applier expires.
+func (a *Applier) poll(ctx context.Context, condition wait.ConditionWithContextFunc) error {
+	return wait.PollUntilContextTimeout(ctx, a.interval, a.timeout, true, condition)
+}
+
+// failed marks the result as failed with the given error.
+func failed(r domain.ObjectResult, err error) domain.ObjectResult {
+	r.Action, r.Err = domain.ObjectFailed, err
+	return r
+}
+
+// established reports whether the CustomResourceDefinition has the Established condition.
+func established(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		c, _ := c.(map[string]any)
+		if c["type"] == "Established" && c["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+// objectRef returns the reference to the object.
+func objectRef(obj *unstructured.Unstructured) domain.ObjectRef {
+	return domain.ObjectRef{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
@@ -0,0 +1,277 @@
+package kubeclient
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+var (
+	configMaps     = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	namespaces     = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	crds           = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	endpointSlices = schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}
+)
+
+func TestApplierApply(t *testing.T) {
+	t.Parallel()
+
+	a, _ := newFakeApplier(t)
+	objects := []domain.GenericManifest{
+		manifest("v1", "Namespace", "", "crib"),
+		manifest("v1", "ConfigMap", "", "config"),
+		manifest("v1", "ConfigMap", "crib", "other"),
+	}
+
+	results, err := a.Apply(t.Context(), objects)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Namespace/crib created",
+		"default/ConfigMap/config created",
+		"crib/ConfigMap/other created",
+	}, lines(results))
+
+	objects[1]["data"] = map[string]any{"key": "value"}
+	results, err = a.Apply(t.Context(), objects)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Namespace/crib unchanged",
+		"default/ConfigMap/config configured",
+		"crib/ConfigMap/other unchanged",
+	}, lines(results))
+}
+
+func TestApplierApplyErrors(t *testing.T) {
+	t.Parallel()
+
+	a, _ := newFakeApplier(t)
+	results, err := a.Apply(t.Context(), []domain.GenericManifest{
+		manifest("example.com/v1", "Widget", "", "unknown"),
+		manifest("v1", "ConfigMap", "", ""),
+		manifest("v1", "ConfigMap", "", "config"),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "applying ConfigMap/: object has no name")
+	require.Len(t, results, 3)
+	assert.Equal(t, domain.ObjectFailed, results[0].Action)
+	assert.Equal(t, domain.ObjectFailed, results[1].Action)
+	assert.Equal(t, "default/ConfigMap/config created", results[2].String(), "failures do not stop the remaining objects")
+}
+
+func TestApplierApplyCRD(t *testing.T) {
+	t.Parallel()
+
+	established := manifest("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "widgets.example.com")
+	established["status"] = map[string]any{
+		"conditions": []any{map[string]any{"type": "Established", "status": "True"}},
+	}
+	a, _ := newFakeApplier(t, established)
+
+	results, err := a.Apply(t.Context(), []domain.GenericManifest{
+		manifest("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "widgets.example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CustomResourceDefinition/widgets.example.com unchanged"}, lines(results))
+
+	// Nothing establishes definitions in the fake cluster.
+	results, err = a.Apply(t.Context(), []domain.GenericManifest{
+		manifest("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "gadgets.example.com"),
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "waiting to be established")
+	assert.Equal(t, domain.ObjectFailed, results[0].Action)
+}
+
+func TestApplierDelete(t *testing.T) {
+	t.Parallel()
+
+	a, client := newFakeApplier(t,
+		manifest("v1", "Namespace", "", "crib"),
+		manifest("v1", "ConfigMap", "crib", "config"),
+	)
+	var deleted []string
+	client.PrependReactor("delete", "*", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		deleted = append(deleted, action.(clienttesting.DeleteAction).GetName())
+		return false, nil, nil
+	})
+
+	results, err := a.Delete(t.Context(), []domain.GenericManifest{
+		manifest("v1", "Namespace", "", "crib"),
+		manifest("v1", "ConfigMap", "crib", "config"),
+		manifest("v1", "ConfigMap", "crib", "missing"),
+		manifest("example.com/v1", "Widget", "crib", "unknown"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"crib/Widget/unknown not found",
+		"crib/ConfigMap/missing not found",
+		"crib/ConfigMap/config deleted",
+		"Namespace/crib deleted",
+	}, lines(results))
+	assert.Equal(t, []string{"missing", "config", "crib"}, deleted)
+
+	_, err = client.Resource(configMaps).Namespace("crib").Get(t.Context(), "config", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestApplierList(t *testing.T) {
+	t.Parallel()
+
+	owned := func(doc domain.GenericManifest) domain.GenericManifest {
+		doc["metadata"].(map[string]any)["labels"] = map[string]any{domain.LabelPlan: "test"}
+		return doc
+	}
+	// Objects created by controllers carry the labels of the objects they were created for.
+	controlled := owned(manifest("v1", "ConfigMap", "crib", "controlled"))
+	controlled["metadata"].(map[string]any)["ownerReferences"] = []any{
+		map[string]any{"apiVersion": "v1", "kind": "ConfigMap", "name": "config", "uid": "1"},
+	}
+	a, _ := newFakeApplier(t,
+		owned(manifest("v1", "Namespace", "", "crib")),
+		owned(manifest("v1", "ConfigMap", "crib", "config")),
+		manifest("v1", "ConfigMap", "crib", "other"),
+		owned(manifest("discovery.k8s.io/v1", "EndpointSlice", "crib", "api-x7k2p")),
+		controlled,
+	)
+
+	objs, err := a.List(t.Context(), domain.LabelPlan+"=test")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []domain.ObjectRef{
+		{APIVersion: "v1", Kind: "Namespace", Name: "crib"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "crib", Name: "config"},
+	}, objs)
+}
+
+func TestApplierRead(t *testing.T) {
+	t.Parallel()
+
+	labelled := manifest("v1", "ConfigMap", "crib", "a")
+	labelled["metadata"].(map[string]any)["labels"] = map[string]any{"app": "api"}
+	a, _ := newFakeApplier(t, labelled, manifest("v1", "ConfigMap", "crib", "b"), manifest("v1", "ConfigMap", "default", "c"))
+
+	// ConfigMaps stand in for the kinds of the conditions, which the fake cluster does not serve.
+	read := func(spec domain.WaitSpec) []string {
+		t.Helper()
+		objects, err := a.readKind(t.Context(), schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, &spec)
+		require.NoError(t, err)
+		var names []string
+		for _, obj := range objects {
+			names = append(names, domain.NewObjectRef(obj).Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"a"}, read(domain.WaitSpec{Namespace: "crib", Selector: "app=api"}))
+	assert.Equal(t, []string{"b"}, read(domain.WaitSpec{Namespace: "crib", Name: "b"}))
+	assert.Equal(t, []string{"c"}, read(domain.WaitSpec{Name: "c"}), "the namespace defaults to the namespace of the applier")
+	assert.Empty(t, read(domain.WaitSpec{Namespace: "crib", Name: "missing"}))
+
+	objects, err := a.Read(t.Context(), &domain.WaitSpec{Condition: domain.WaitCRDEstablished, Name: "missing"})
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+// newFakeApplier returns an Applier for a fake cluster that serves ConfigMaps, Namespaces,
+// CustomResourceDefinitions and EndpointSlices, and contains the given objects.
+func newFakeApplier(t *testing.T, objects ...domain.GenericManifest) (*Applier, *fakedynamic.FakeDynamicClient) {
+	t.Helper()
+
+	var objs []runtime.Object
+	for _, doc := range objects {
+		obj, err := newObject(doc)
+		require.NoError(t, err)
+		objs = append(objs, obj)
+	}
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		configMaps:     "ConfigMapList",
+		namespaces:     "NamespaceList",
+		crds:           "CustomResourceDefinitionList",
+		endpointSlices: "EndpointSliceList",
+	}, objs...)
+	client.PrependReactor("patch", "*", applyReactor(client.Tracker()))
+
+	verbs := metav1.Verbs{"get", "list", "patch", "delete"}
+	disc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: verbs},
+			{Name: "namespaces", Kind: "Namespace", Verbs: verbs},
+			{Name: "namespaces/status", Kind: "Namespace", Verbs: metav1.Verbs{"get"}},
+		}},
+		{GroupVersion: "apiextensions.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition", Verbs: verbs},
+		}},
+		{GroupVersion: "discovery.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "endpointslices", Kind: "EndpointSlice", Namespaced: true, Verbs: verbs},
+		}},
+	}}}
+	return New(client, disc, WithTimeout(50*time.Millisecond), WithPollInterval(time.Millisecond)), client
+}
+
+// applyReactor handles server-side apply like the API server, which the fake client does not: missing
+// objects are created, and the resource version only changes when the object changes. The status of
+// existing objects is kept.
+func applyReactor(tracker clienttesting.ObjectTracker) clienttesting.ReactionFunc {
+	return func(action clienttesting.Action) (bool, runtime.Object, error) {
+		patch := action.(clienttesting.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		obj := new(unstructured.Unstructured)
+		if err := yaml.Unmarshal(patch.GetPatch(), &obj.Object); err != nil {
+			return true, nil, err
+		}
+		gvr, ns := patch.GetResource(), patch.GetNamespace()
+		obj.SetNamespace(ns)
+
+		live, err := tracker.Get(gvr, ns, patch.GetName())
+		if apierrors.IsNotFound(err) {
+			obj.SetResourceVersion("1")
+			return true, obj, tracker.Create(gvr, obj, ns)
+		}
+		if err != nil {
+			return true, nil, err
+		}
+		old := live.(*unstructured.Unstructured)
+		if status, ok := old.Object["status"]; ok {
+			obj.Object["status"] = status
+		}
+		obj.SetResourceVersion(old.GetResourceVersion())
+		if equality.Semantic.DeepEqual(old.Object, obj.Object) {
+			return true, old, nil
+		}
+		version, _ := strconv.Atoi(old.GetResourceVersion())
+		obj.SetResourceVersion(strconv.Itoa(version + 1))
+		return true, obj, tracker.Update(gvr, obj, ns)
+	}
+}
+
+func manifest(apiVersion, kind, namespace, name string) domain.GenericManifest {
+	metadata := map[string]any{"name": name}
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	return domain.GenericManifest{"apiVersion": apiVersion, "kind": kind, "metadata": metadata}
+}
+
+func lines(results []domain.ObjectResult) []string {
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.String()
+	}
+	return out
+}
@@ -0,0 +1,3 @@
+// Package kubeclient applies, deletes and lists Kubernetes objects with a Go Kubernetes client, without
+// depending on the kubectl binary of the user. It implements [port.KubernetesApplier].
+package kubeclient
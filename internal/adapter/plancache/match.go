@@ -0,0 +1,66 @@
+package plancache
+
+import (
+	"iter"
+	"strings"
+
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/infra"
+)
+
+// Match returns the nodes of the components matching the selector, see MatchComponent.
+func (r *Results) Match(selector string) iter.Seq[*Node] {
+	id, _, _ := strings.Cut(selector, "#")
+	return func(yield func(*Node) bool) {
+		for node := range r.Get(infra.ExtractResource(&id)) {
+			if MatchComponent(node.Component(), selector) && !yield(node) {
+				return
+			}
+		}
+	}
+}
+
+// MatchComponent reports whether the component matches the selector. Selectors take one of the forms:
+//
+//	sdk.HelmChart           every component with this ID
+//	sdk.HelmChart-1bbec390  the same, the hash of the ID is stripped
+//	sdk.HelmChart#anvil     the components with this ID that contain something named anvil
+//
+// A component contains something named by the part after "#" if the component, or any construct nested
+// in it, has that ID, ignoring hashes, or renders a Kubernetes resource or a Helm release of that name.
+func MatchComponent(c constructs.IConstruct, selector string) bool {
+	if c == nil || c.Node() == nil {
+		return false
+	}
+	id, name, qualified := strings.Cut(selector, "#")
+	if infra.ExtractResource(c.Node().Id()) != infra.ExtractResource(&id) {
+		return false
+	}
+	if !qualified {
+		return true
+	}
+	for _, construct := range *c.Node().FindAll(constructs.ConstructOrder_PREORDER) {
+		if hasName(construct, name, infra.ExtractResource(&name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasName reports whether the construct has the given ID without its hash, or renders a Kubernetes
+// resource or a Helm release with the given name.
+func hasName(c constructs.IConstruct, name, id string) bool {
+	if infra.ExtractResource(c.Node().Id()) == id {
+		return true
+	}
+	if *cdk8s.ApiObject_IsApiObject(c) {
+		return dry.FromPtr(c.(cdk8s.ApiObject).Name()) == name
+	}
+	if helm, ok := c.(cdk8s.Helm); ok {
+		return dry.FromPtr(helm.ReleaseName()) == name
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package plancache
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/infra"
+)
+
+func TestMatchComponent(t *testing.T) {
+	t.Parallel()
+
+	app := cdk8s.NewApp(nil)
+	chart := cdk8s.NewChart(app, infra.ResourceID("sdk.HelmChart", "anvil"), nil)
+	cdk8s.NewApiObject(chart, infra.ResourceID("component-chart", "anvil"), &cdk8s.ApiObjectProps{
+		ApiVersion: dry.ToPtr("v1"),
+		Kind:       dry.ToPtr("Service"),
+		Metadata:   &cdk8s.ApiObjectMetadata{Name: dry.ToPtr("anvil-rpc")},
+	})
+	other := cdk8s.NewChart(app, infra.ResourceID("sdk.HelmChart", "jd"), nil)
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "sdk.HelmChart", want: true},
+		{selector: *chart.Node().Id(), want: true},
+		{selector: "sdk.HelmChart-00000000", want: true},
+		{selector: "sdk.HelmChart#component-chart", want: true},
+		{selector: "sdk.HelmChart#component-chart-00000000", want: true},
+		{selector: "sdk.HelmChart#anvil-rpc", want: true},
+		{selector: "sdk.HelmChart#jd", want: false},
+		{selector: "sdk.Namespace", want: false},
+		{selector: "sdk.Namespace#anvil-rpc", want: false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, MatchComponent(chart, tc.selector), tc.selector)
+	}
+
+	results := New()
+	results.Add(chart)
+	results.Add(other)
+	assert.Len(t, slices.Collect(results.Match("sdk.HelmChart")), 2)
+	if matched := slices.Collect(results.Match("sdk.HelmChart#anvil-rpc")); assert.Len(t, matched, 1) {
+		assert.Equal(t, *chart.Node().Path(), *matched[0].Component().Node().Path())
+	}
+}
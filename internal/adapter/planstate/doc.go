@@ -0,0 +1,3 @@
+// Package planstate provides stores for the records of applied plans. The records are used
+// to report the status of a plan and to detect drift between applies.
+package planstate
@@ -0,0 +1,72 @@
+package planstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+const recordExt = ".yaml"
+
+// FileStore stores plan records as YAML files in a local directory, one file per plan and namespace,
+// named after the instance of the plan, see domain.InstanceLabelValue. It implements [port.PlanStateStore].
+type FileStore struct {
+	fh *filehandler.Handler
+}
+
+// NewFileStore creates a new FileStore rooted at dir. The directory is created if it does not exist.
+func NewFileStore(ctx context.Context, dir string) (*FileStore, error) {
+	fh, err := filehandler.New(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening plan state directory %q: %w", dir, err)
+	}
+	return &FileStore{fh: fh}, nil
+}
+
+// Save writes the record to the file of the plan, replacing any existing record.
+func (s *FileStore) Save(_ context.Context, record *domain.PlanRecord) error {
+	if record == nil || record.Name == "" {
+		return errors.New("plan record must have a name")
+	}
+	raw, err := yaml.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshaling record of plan %q: %w", record.Name, err)
+	}
+	return s.fh.WriteFile(recordFile(record.Name, record.Namespace), raw)
+}
+
+// Load reads the record of the named plan applied to the namespace.
+func (s *FileStore) Load(_ context.Context, name, namespace string) (*domain.PlanRecord, error) {
+	raw, err := s.fh.ReadFile(recordFile(name, namespace))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrPlanRecordNotFound, domain.InstanceLabelValue(name, namespace))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading record of plan %q: %w", name, err)
+	}
+	record := new(domain.PlanRecord)
+	if err := yaml.Unmarshal(raw, record); err != nil {
+		return nil, fmt.Errorf("unmarshaling record of plan %q: %w", name, err)
+	}
+	return record, nil
+}
+
+// Delete removes the record of the named plan applied to the namespace.
+func (s *FileStore) Delete(_ context.Context, name, namespace string) error {
+	err := s.fh.Remove(recordFile(name, namespace))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// recordFile returns the name of the file holding the record of the plan applied to the namespace.
+func recordFile(name, namespace string) string {
+	return domain.InstanceLabelValue(name, namespace) + recordExt
+}
@@ -0,0 +1,73 @@
+package planstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+var _ port.PlanStateStore = (*FileStore)(nil)
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	must := require.New(t)
+	ctx := t.Context()
+
+	store, err := NewFileStore(ctx, t.TempDir())
+	must.NoError(err)
+
+	_, err = store.Load(ctx, "test", "crib")
+	is.ErrorIs(err, domain.ErrPlanRecordNotFound)
+
+	record := &domain.PlanRecord{
+		Name:      "test",
+		Namespace: "crib",
+		AppliedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Bundles: []domain.BundleRecord{
+			{Key: "ns/Namespace.crib.k8s.yaml", Hash: "abc"},
+			{
+				Key:     "csa/ClientSideApply.crib.k8s.yaml",
+				Hash:    "def",
+				IsLocal: true,
+				Step: &domain.StepRecord{
+					Action: domain.ActionCmd,
+					Status: domain.StepStatusAborted,
+					Output: "output",
+					Error:  "boom",
+				},
+			},
+		},
+		Charts: domain.HelmChartVersions{{Name: "anvil", Version: "0.1.0"}},
+	}
+	must.NoError(store.Save(ctx, record))
+
+	got, err := store.Load(ctx, "test", "crib")
+	must.NoError(err)
+	is.Equal(record, got)
+
+	// The same plan applied to another namespace has its own record.
+	_, err = store.Load(ctx, "test", "other")
+	is.ErrorIs(err, domain.ErrPlanRecordNotFound)
+	other := &domain.PlanRecord{Name: "test", Namespace: "other", AppliedAt: record.AppliedAt, Bundles: []domain.BundleRecord{}}
+	must.NoError(store.Save(ctx, other))
+	got, err = store.Load(ctx, "test", "other")
+	must.NoError(err)
+	is.Equal(other, got)
+	got, err = store.Load(ctx, "test", "crib")
+	must.NoError(err)
+	is.Equal(record, got)
+	must.NoError(store.Delete(ctx, "test", "other"))
+
+	must.NoError(store.Delete(ctx, "test", "crib"))
+	_, err = store.Load(ctx, "test", "crib")
+	is.ErrorIs(err, domain.ErrPlanRecordNotFound)
+	is.NoError(store.Delete(ctx, "test", "crib"), "deleting a missing record is not an error")
+
+	is.Error(store.Save(ctx, &domain.PlanRecord{}))
+}
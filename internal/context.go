@@ -11,6 +11,7 @@ import (
 type (
 	constructKey struct{}
 	validatorKey struct{}
+	paramsKey    struct{}
 )
 
 // ConstructFromContext retrieves the constructs.Construct from the context.
@@ -48,3 +49,20 @@ func ContextWithValidator(ctx context.Context, v *Validator) context.Context {
 	}
 	return context.WithValue(ctx, validatorKey{}, v)
 }
+
+// ParamsFromContext retrieves the resolved plan parameter values, keyed by parameter name, from the context.
+// It returns nil if no parameters were resolved.
+func ParamsFromContext(ctx context.Context) map[string]any {
+	if ctx == nil {
+		return nil
+	}
+	return dry.As[map[string]any](ctx.Value(paramsKey{}))
+}
+
+// ContextWithParams creates a new context with the supplied resolved plan parameter values.
+func ContextWithParams(ctx context.Context, params map[string]any) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, paramsKey{}, params)
+}
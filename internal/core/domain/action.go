@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/semver"
+)
+
+// ErrUnknownAction is an error that indicates that no action is registered with the name of a step.
+var ErrUnknownAction = errors.New("unknown action")
+
+type (
+	// ActionDefinition describes an action of ClientSideApply steps: the binary that runs the steps,
+	// the version of the binary that cribctl doctor requires, and the args that the steps may have.
+	// Actions are registered with RegisterAction.
+	ActionDefinition struct {
+		// Name is the action of the steps, e.g. "kubectl".
+		Name string
+		// Binary is the executable that is run with the args of the steps, and that cribctl doctor
+		// looks for. The cmd action has no binary, its args are run by the shell.
+		Binary string
+		// VersionCommand prints the version of the binary, which is matched against VersionConstraint.
+		VersionCommand []string
+		// VersionConstraint is the version of the binary that is required, e.g. ">=3.18.4".
+		VersionConstraint VersionConstraint
+		// Optional actions are only needed by the plans that use them, so cribctl doctor does not
+		// report their binary as missing.
+		Optional bool
+		// Args validates the args of the steps, e.g. MinArgs(1). Any args are accepted when nil.
+		Args ArgsValidator
+		// Run runs the steps in process instead of a binary. It receives the manifest with its outputs
+		// resolved, and is responsible for its own retries and timeout.
+		Run func(ctx context.Context, m *ClientSideApplyManifest) (*RunnerResult, error)
+	}
+
+	// ArgsValidator validates the args of the steps of an action.
+	ArgsValidator func(args []string) error
+
+	// VersionConstraint is a version prefixed by one of the operators >=, >, <=, < or ==, e.g. ">=3.18.4".
+	// A version without an operator must match exactly.
+	VersionConstraint string
+)
+
+// actions holds the registered actions by name.
+var actions = struct {
+	sync.RWMutex
+	m map[string]ActionDefinition
+}{m: make(map[string]ActionDefinition)}
+
+// RegisterAction registers an action, so that ClientSideApply steps can use it. It fails if an action
+// with the same name is already registered.
+func RegisterAction(a ActionDefinition) error {
+	switch {
+	case a.Name == "":
+		return errors.New("action must have a name")
+	case a.Binary != "" && a.Run != nil:
+		return fmt.Errorf("action %s must have either a binary or a Run func, not both", a.Name)
+	case a.Binary == "" && a.Run == nil && a.Name != ActionCmd:
+		return fmt.Errorf("action %s must have a binary or a Run func", a.Name)
+	case a.VersionConstraint != "" && len(a.VersionCommand) == 0:
+		return fmt.Errorf("action %s must have a version command to check its version constraint", a.Name)
+	}
+	if err := a.VersionConstraint.Validate(); err != nil {
+		return fmt.Errorf("action %s: %w", a.Name, err)
+	}
+
+	actions.Lock()
+	defer actions.Unlock()
+	if _, ok := actions.m[a.Name]; ok {
+		return fmt.Errorf("action %s is already registered", a.Name)
+	}
+	actions.m[a.Name] = a
+	return nil
+}
+
+// LookupAction returns the registered action with the given name.
+func LookupAction(name string) (ActionDefinition, error) {
+	actions.RLock()
+	defer actions.RUnlock()
+	a, ok := actions.m[name]
+	if !ok {
+		return a, fmt.Errorf("%w %q", ErrUnknownAction, name)
+	}
+	return a, nil
+}
+
+// Actions returns the registered actions, sorted by name.
+func Actions() []ActionDefinition {
+	actions.RLock()
+	defer actions.RUnlock()
+	return slices.SortedFunc(maps.Values(actions.m), func(a, b ActionDefinition) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// ActionNames returns the names of the registered actions, sorted.
+func ActionNames() []string {
+	actions.RLock()
+	defer actions.RUnlock()
+	return slices.Sorted(maps.Keys(actions.m))
+}
+
+// ValidateAction returns an error if no action with the given name is registered, or if the action
+// does not accept the args.
+func ValidateAction(name string, args []string) error {
+	a, err := LookupAction(name)
+	if err != nil {
+		return err
+	}
+	if a.Args == nil {
+		return nil
+	}
+	if err := a.Args(args); err != nil {
+		return fmt.Errorf("invalid args of action %s: %w", name, err)
+	}
+	return nil
+}
+
+// MinArgs returns an ArgsValidator that requires at least n args.
+func MinArgs(n int) ArgsValidator {
+	return func(args []string) error {
+		if len(args) < n {
+			return fmt.Errorf("requires at least %d arg(s), only received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// ExactArgs returns an ArgsValidator that requires exactly n args.
+func ExactArgs(n int) ArgsValidator {
+	return func(args []string) error {
+		if len(args) != n {
+			return fmt.Errorf("accepts %d arg(s), received %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+// parse splits the constraint into its operator and its version, prefixed by "v" as semver expects.
+func (c VersionConstraint) parse() (op, version string) {
+	s := strings.TrimSpace(string(c))
+	for _, op := range []string{">=", "<=", "==", ">", "<"} {
+		if v, ok := strings.CutPrefix(s, op); ok {
+			return op, "v" + strings.TrimPrefix(strings.TrimSpace(v), "v")
+		}
+	}
+	return "==", "v" + strings.TrimPrefix(s, "v")
+}
+
+// Validate returns an error if the constraint is set and its version is not a semantic version.
+func (c VersionConstraint) Validate() error {
+	if c == "" {
+		return nil
+	}
+	if _, version := c.parse(); !semver.IsValid(version) {
+		return fmt.Errorf("invalid version constraint %q: %s is not a semantic version", string(c), version[1:])
+	}
+	return nil
+}
+
+// Check returns an error if the version does not satisfy the constraint. Any version satisfies an
+// empty constraint.
+func (c VersionConstraint) Check(version string) error {
+	if c == "" {
+		return nil
+	}
+	op, want := c.parse()
+	have := "v" + strings.TrimPrefix(version, "v")
+	if !semver.IsValid(have) {
+		return fmt.Errorf("%s is not a semantic version", version)
+	}
+	n := semver.Compare(have, want)
+	var ok bool
+	switch op {
+	case ">=":
+		ok = n >= 0
+	case ">":
+		ok = n > 0
+	case "<=":
+		ok = n <= 0
+	case "<":
+		ok = n < 0
+	default:
+		ok = n == 0
+	}
+	if !ok {
+		return fmt.Errorf("version %s does not satisfy %s", version, string(c))
+	}
+	return nil
+}
+
+// builtinActions are the actions that cribctl supports out of the box.
+var builtinActions = []ActionDefinition{
+	{
+		Name:           ActionAws,
+		Binary:         "aws",
+		Optional:       true,
+		VersionCommand: []string{"aws", "--version"},
+		Args:           MinArgs(1),
+	},
+	{Name: ActionCmd, Args: MinArgs(1)},
+	{Name: ActionCribctl, Binary: "cribctl", Optional: true, Args: MinArgs(1)},
+	{Name: ActionDocker, Binary: "docker", Optional: true, Args: MinArgs(1)},
+	{
+		Name:              ActionHelm,
+		Binary:            "helm",
+		VersionCommand:    []string{"helm", "version", "--template", "{{.Version}}"},
+		VersionConstraint: ">=3.18.4",
+		Args:              MinArgs(1),
+	},
+	{
+		Name:              ActionKind,
+		Binary:            "kind",
+		VersionCommand:    []string{"kind", "--version"},
+		VersionConstraint: ">=0.20.0",
+		Args:              MinArgs(1),
+	},
+	{Name: ActionKubectl, Binary: "kubectl", Args: MinArgs(1)},
+	{Name: ActionKubectx, Binary: "kubectx", Optional: true, Args: ExactArgs(1)},
+	{Name: ActionKubens, Binary: "kubens", Optional: true, Args: ExactArgs(1)},
+	{
+		Name:              ActionTask,
+		Binary:            "task",
+		VersionCommand:    []string{"task", "--version"},
+		VersionConstraint: ">=3.40.0",
+	},
+	{Name: ActionTelepresence, Binary: "telepresence", Args: MinArgs(1)},
+}
+
+func init() {
+	for _, a := range builtinActions {
+		if err := RegisterAction(a); err != nil {
+			panic(err)
+		}
+	}
+}
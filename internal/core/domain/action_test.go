@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAction(t *testing.T) {
+	t.Parallel()
+
+	run := func(context.Context, *ClientSideApplyManifest) (*RunnerResult, error) { return nil, nil }
+	tests := []struct {
+		name    string
+		action  ActionDefinition
+		wantErr string
+	}{
+		{
+			name:   "binary",
+			action: ActionDefinition{Name: "test-register-binary", Binary: "vault", VersionCommand: []string{"vault", "version"}, VersionConstraint: ">=1.15"},
+		},
+		{
+			name:   "run func",
+			action: ActionDefinition{Name: "test-register-run", Run: run},
+		},
+		{
+			name:    "no name",
+			action:  ActionDefinition{Binary: "vault"},
+			wantErr: "action must have a name",
+		},
+		{
+			name:    "nothing to run",
+			action:  ActionDefinition{Name: "test-register-nothing"},
+			wantErr: "action test-register-nothing must have a binary or a Run func",
+		},
+		{
+			name:    "binary and run func",
+			action:  ActionDefinition{Name: "test-register-both", Binary: "vault", Run: run},
+			wantErr: "action test-register-both must have either a binary or a Run func, not both",
+		},
+		{
+			name:    "constraint without version command",
+			action:  ActionDefinition{Name: "test-register-constraint", Binary: "vault", VersionConstraint: ">=1.15"},
+			wantErr: "action test-register-constraint must have a version command to check its version constraint",
+		},
+		{
+			name:    "invalid constraint",
+			action:  ActionDefinition{Name: "test-register-invalid", Binary: "vault", VersionCommand: []string{"vault", "version"}, VersionConstraint: ">=latest"},
+			wantErr: `action test-register-invalid: invalid version constraint ">=latest": latest is not a semantic version`,
+		},
+		{
+			name:    "already registered",
+			action:  ActionDefinition{Name: ActionKubectl, Binary: "kubectl"},
+			wantErr: "action kubectl is already registered",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := RegisterAction(tc.action)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				actions.Lock()
+				defer actions.Unlock()
+				delete(actions.m, tc.action.Name)
+			})
+			got, err := LookupAction(tc.action.Name)
+			require.NoError(t, err)
+			assert.Equal(t, tc.action.Binary, got.Binary)
+			assert.Contains(t, ActionNames(), tc.action.Name)
+		})
+	}
+}
+
+func TestValidateAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		action  string
+		args    []string
+		wantErr string
+	}{
+		{name: "any args", action: ActionTask},
+		{name: "min args", action: ActionKubectl, args: []string{"apply", "-f", "-"}},
+		{name: "exact args", action: ActionKubectx, args: []string{"kind-crib"}},
+		{
+			name:    "too few args",
+			action:  ActionCmd,
+			wantErr: "invalid args of action cmd: requires at least 1 arg(s), only received 0",
+		},
+		{
+			name:    "too many args",
+			action:  ActionKubens,
+			args:    []string{"crib", "default"},
+			wantErr: "invalid args of action kubens: accepts 1 arg(s), received 2",
+		},
+		{
+			name:    "unknown",
+			action:  "terraform",
+			args:    []string{"apply"},
+			wantErr: `unknown action "terraform"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateAction(tc.action, tc.args)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+	assert.ErrorIs(t, ValidateAction("terraform", nil), ErrUnknownAction)
+}
+
+func TestVersionConstraintCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint VersionConstraint
+		version    string
+		wantErr    string
+	}{
+		{constraint: "", version: "0.1.0"},
+		{constraint: ">=3.18.4", version: "3.18.4"},
+		{constraint: ">=3.18.4", version: "3.19.0"},
+		{constraint: ">=0.20.0", version: "0.9.0", wantErr: "version 0.9.0 does not satisfy >=0.20.0"},
+		{constraint: ">1.24", version: "1.24.0", wantErr: "version 1.24.0 does not satisfy >1.24"},
+		{constraint: "<2", version: "v1.9.9"},
+		{constraint: "<=2.0.0", version: "2.0.1", wantErr: "version 2.0.1 does not satisfy <=2.0.0"},
+		{constraint: "==22.17.0", version: "22.17.0"},
+		{constraint: "22.17.0", version: "22.17.1", wantErr: "version 22.17.1 does not satisfy 22.17.0"},
+		{constraint: ">=1.0.0", version: "unknown", wantErr: "unknown is not a semantic version"},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.constraint)+" "+tc.version, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.constraint.Check(tc.version)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
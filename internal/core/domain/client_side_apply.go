@@ -3,9 +3,13 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 )
 
-// Action represents the type of action to be performed by the client-side apply manifest.
+// Action represents the type of action to be performed by the client-side apply manifest. These are
+// the built-in actions, more can be registered with RegisterAction.
 const (
 	ActionAws          = "aws"
 	ActionCmd          = "cmd"
@@ -14,6 +18,8 @@ const (
 	ActionHelm         = "helm"
 	ActionKind         = "kind"
 	ActionKubectl      = "kubectl"
+	ActionKubectx      = "kubectx"
+	ActionKubens       = "kubens"
 	ActionTask         = "task"
 	ActionTelepresence = "telepresence"
 )
@@ -24,6 +30,15 @@ const (
 	FailureAbort    = "abort"
 )
 
+const (
+	// DefaultBackoff is the delay before the first retry of a step that does not declare its own backoff.
+	DefaultBackoff = time.Second
+	// MaxBackoff caps the doubled delay between retries.
+	MaxBackoff = 5 * time.Minute
+	// MaxRetries is the number of retries that a step may declare at most.
+	MaxRetries = 20
+)
+
 var (
 	// ErrEmptyAction is an error that indicates that the action field in the ClientSideApplySpec is empty.
 	ErrEmptyAction = errors.New("action cannot be empty")
@@ -42,13 +57,59 @@ type (
 
 	ClientSideApplySpec struct {
 		OnFailure string   `yaml:"onFailure" validate:"required,oneof=continue abort"`
-		Action    string   `yaml:"action"    validate:"required,oneof=aws cmd cribctl docker helm kind kubectl task telepresence"`
+		Action    string   `yaml:"action"    validate:"required,csa_action"`
 		Args      []string `yaml:"args"      validate:"required,dive"`
+		// Undo is an optional step that reverses the effects of this step when a plan is destroyed.
+		Undo *ClientSideApplyUndo `yaml:"undo,omitempty" validate:"omitempty"`
+		// Retries is the number of times the step is run again after it failed, at most MaxRetries.
+		Retries int `yaml:"retries,omitempty" validate:"gte=0,lte=20"`
+		// Backoff is the delay before the first retry, which doubles with every further retry up to
+		// MaxBackoff. It defaults to DefaultBackoff.
+		Backoff time.Duration `yaml:"backoff,omitempty" validate:"gte=0"`
+		// Timeout limits the duration of each attempt. Attempts are not limited when zero.
+		Timeout time.Duration `yaml:"timeout,omitempty" validate:"gte=0"`
+		// Env holds environment variables of the command, which take precedence over the
+		// environment of cribctl.
+		Env map[string]string `yaml:"env,omitempty"`
+		// WorkingDir is the directory the command runs in, instead of the working directory of cribctl.
+		WorkingDir string `yaml:"workingDir,omitempty"`
+		// Stdin is written to the standard input of the command.
+		Stdin string `yaml:"stdin,omitempty"`
+		// Outputs are extracted once the step succeeded, and can be referenced by later steps
+		// and manifests, see OutputPlaceholder.
+		Outputs []ClientSideApplyOutput `yaml:"outputs,omitempty" validate:"omitempty,dive"`
+	}
+
+	// ClientSideApplyOutput describes how a named output is extracted from a ClientSideApply step.
+	// Exactly one of Regex, JSONPath and File is set.
+	ClientSideApplyOutput struct {
+		Name string `yaml:"name" validate:"required,alphanum"`
+		// Regex matches the standard output of the step. The output is the first capture group, or the
+		// whole match if the expression has none.
+		Regex string `yaml:"regex,omitempty" validate:"required_without_all=JSONPath File,excluded_with=JSONPath File"`
+		// JSONPath is evaluated against the standard output of the step parsed as JSON, e.g. {.address}.
+		JSONPath string `yaml:"jsonPath,omitempty" validate:"required_without_all=Regex File,excluded_with=Regex File"`
+		// File is read once the step succeeded, relative to the working directory of the step.
+		File string `yaml:"file,omitempty" validate:"required_without_all=Regex JSONPath,excluded_with=Regex JSONPath"`
+		// Sensitive outputs are registered as secrets, see RegisterSecret, and are neither reported nor
+		// stored with the record of the plan.
+		Sensitive bool `yaml:"sensitive,omitempty"`
+	}
+
+	// ClientSideApplyUndo describes the action that reverses a ClientSideApply step, for example
+	// deleting a kind cluster that the step created.
+	ClientSideApplyUndo struct {
+		Action string   `yaml:"action" validate:"required,csa_action"`
+		Args   []string `yaml:"args"   validate:"required,dive"`
 	}
 
 	// RunnerResult represents the result of a client-side apply operation.
 	RunnerResult struct {
 		Output []byte
+		// ExitCode is the exit code of the executed command, or -1 if it is not known.
+		ExitCode int
+		// Outputs are the values of the outputs declared by the step, keyed by name.
+		Outputs map[string]string
 	}
 
 	// AbortError is an error that indicates that the previous step failed and that the handler should
@@ -109,3 +170,40 @@ func (m *ClientSideApplyManifest) NewError(err error) error {
 	}
 	return fmt.Errorf("unknown onFailure action: %q", m.Spec.OnFailure)
 }
+
+// UndoManifest returns a new ClientSideApplyManifest that performs the undo step of the manifest.
+// It returns nil if the manifest does not declare an undo step. The undo step inherits the
+// OnFailure behavior, retry policy, timeout, environment and working directory of the original
+// step, but not its standard input.
+func (m *ClientSideApplyManifest) UndoManifest() *ClientSideApplyManifest {
+	if m == nil || m.Spec.Undo == nil {
+		return nil
+	}
+	return &ClientSideApplyManifest{
+		Manifest: m.Manifest,
+		Spec: ClientSideApplySpec{
+			OnFailure:  m.Spec.OnFailure,
+			Action:     m.Spec.Undo.Action,
+			Args:       slices.Clone(m.Spec.Undo.Args),
+			Retries:    m.Spec.Retries,
+			Backoff:    m.Spec.Backoff,
+			Timeout:    m.Spec.Timeout,
+			Env:        maps.Clone(m.Spec.Env),
+			WorkingDir: m.Spec.WorkingDir,
+		},
+	}
+}
+
+// RetryDelay returns the delay before the given retry of the step, starting at 1 for the first retry.
+// The delay doubles with every retry up to MaxBackoff, or the backoff of the step if it is longer.
+func (s *ClientSideApplySpec) RetryDelay(retry int) time.Duration {
+	backoff := s.Backoff
+	if backoff == 0 {
+		backoff = DefaultBackoff
+	}
+	delay := backoff
+	for i := 1; i < retry && delay < MaxBackoff; i++ {
+		delay *= 2
+	}
+	return max(min(delay, MaxBackoff), backoff)
+}
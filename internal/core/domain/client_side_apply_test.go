@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,3 +31,96 @@ func TestClientSideApplyErrors(t *testing.T) {
 		is.ErrorAs(err, &continueErr, "expected error to be of type ContinueError")
 	})
 }
+
+func TestUndoManifest(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	m := &ClientSideApplyManifest{
+		Spec: ClientSideApplySpec{
+			OnFailure: FailureContinue,
+			Action:    ActionKind,
+			Args:      []string{"create", "cluster"},
+		},
+	}
+	is.Nil(m.UndoManifest(), "expected no undo manifest when undo is not declared")
+
+	m.Spec.Undo = &ClientSideApplyUndo{
+		Action: ActionKind,
+		Args:   []string{"delete", "cluster"},
+	}
+	undo := m.UndoManifest()
+	is.NotNil(undo)
+	is.Equal(ClientSideApplySpec{
+		OnFailure: FailureContinue,
+		Action:    ActionKind,
+		Args:      []string{"delete", "cluster"},
+	}, undo.Spec)
+
+	m.Spec.Retries, m.Spec.Timeout = 2, time.Minute
+	m.Spec.Env, m.Spec.WorkingDir, m.Spec.Stdin = map[string]string{"KIND_EXPERIMENTAL_PROVIDER": "podman"}, "/tmp", "config"
+	undo = m.UndoManifest()
+	is.Equal(ClientSideApplySpec{
+		OnFailure:  FailureContinue,
+		Action:     ActionKind,
+		Args:       []string{"delete", "cluster"},
+		Retries:    2,
+		Timeout:    time.Minute,
+		Env:        map[string]string{"KIND_EXPERIMENTAL_PROVIDER": "podman"},
+		WorkingDir: "/tmp",
+	}, undo.Spec, "the undo step runs in the environment of the step, without its stdin")
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	spec := &ClientSideApplySpec{}
+	is.Equal(DefaultBackoff, spec.RetryDelay(1))
+	is.Equal(4*DefaultBackoff, spec.RetryDelay(3))
+
+	spec.Backoff = 500 * time.Millisecond
+	is.Equal(500*time.Millisecond, spec.RetryDelay(1))
+	is.Equal(time.Second, spec.RetryDelay(2))
+
+	// The delay is capped, and does not overflow.
+	spec.Backoff = 5 * time.Second
+	is.Equal(MaxBackoff, spec.RetryDelay(10))
+	for _, retry := range []int{34, 64, 100} {
+		is.Equal(MaxBackoff, spec.RetryDelay(retry), retry)
+	}
+	spec.Backoff = 10 * time.Minute
+	is.Equal(10*time.Minute, spec.RetryDelay(3), "a longer backoff is not shortened")
+}
+
+func TestUnmarshalClientSideApplyManifest(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	m, err := UnmarshalManifest[ClientSideApplyManifest]([]byte(`apiVersion: crib.smartcontract.com/v1alpha1
+kind: ClientSideApply
+spec:
+  onFailure: abort
+  action: aws
+  args: [sso, login]
+  retries: 3
+  backoff: 5s
+  timeout: 2m
+  env:
+    AWS_PROFILE: staging
+  workingDir: /tmp
+  stdin: "y"
+`))
+	is.NoError(err)
+	is.Equal(ClientSideApplySpec{
+		OnFailure:  FailureAbort,
+		Action:     ActionAws,
+		Args:       []string{"sso", "login"},
+		Retries:    3,
+		Backoff:    5 * time.Second,
+		Timeout:    2 * time.Minute,
+		Env:        map[string]string{"AWS_PROFILE": "staging"},
+		WorkingDir: "/tmp",
+		Stdin:      "y",
+	}, m.Spec)
+}
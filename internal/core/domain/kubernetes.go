@@ -0,0 +1,35 @@
+package domain
+
+import "fmt"
+
+// ActionServerSideApply is the action reported for remote bundles that are applied or deleted through
+// the Kubernetes API by a KubernetesApplier, rather than by running kubectl.
+const ActionServerSideApply = "server-side-apply"
+
+// ObjectAction describes what happened to a single Kubernetes object.
+const (
+	ObjectCreated    = "created"
+	ObjectConfigured = "configured"
+	ObjectUnchanged  = "unchanged"
+	ObjectDeleted    = "deleted"
+	ObjectNotFound   = "not found"
+	ObjectFailed     = "failed"
+)
+
+// ObjectResult is the outcome of applying or deleting a single Kubernetes object.
+type ObjectResult struct {
+	// Object is the applied or deleted object.
+	Object ObjectRef
+	// Action is one of the ObjectAction constants.
+	Action string
+	// Err is the error that caused the object to fail, if any.
+	Err error
+}
+
+// String returns the result as a line of output, e.g. "default/ConfigMap/config created".
+func (r ObjectResult) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%s %s: %v", r.Object, r.Action, r.Err)
+	}
+	return r.Object.String() + " " + r.Action
+}
@@ -19,7 +19,7 @@ const (
 
 type (
 	unmarshalableManifest interface {
-		Manifest | ClientSideApplyManifest
+		Manifest | ClientSideApplyManifest | WaitManifest
 	}
 
 	// Manifest represents a basic Kubernetes manifest.
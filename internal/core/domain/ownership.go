@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Labels that mark the objects rendered by a plan with the plan and component they belong to.
+const (
+	// LabelPlan holds the name of the applied plan that rendered the object.
+	LabelPlan = "crib.sdk/plan"
+	// LabelComponent holds the ID of the component that rendered the object, e.g. "sdk.HelmChart".
+	LabelComponent = "crib.sdk/component"
+	// LabelInstance identifies the instance of the applied plan that rendered the object, i.e. the plan
+	// and its namespace, see InstanceLabelValue. The same plan may be applied to several namespaces.
+	LabelInstance = "crib.sdk/instance"
+)
+
+// maxLabelValue is the maximum length of a Kubernetes label value.
+const maxLabelValue = 63
+
+// ObjectRef identifies a Kubernetes object.
+type ObjectRef struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// NewObjectRef returns the reference to the object described by the manifest.
+func NewObjectRef(doc GenericManifest) ObjectRef {
+	// Nested mappings are decoded as GenericManifest from YAML, and as map[string]any from JSON.
+	metadata, ok := doc["metadata"].(map[string]any)
+	if !ok {
+		metadata, _ = doc["metadata"].(GenericManifest)
+	}
+	ref := ObjectRef{}
+	ref.APIVersion, _ = doc["apiVersion"].(string)
+	ref.Kind, _ = doc["kind"].(string)
+	ref.Namespace, _ = metadata["namespace"].(string)
+	ref.Name, _ = metadata["name"].(string)
+	return ref
+}
+
+// IsControlled reports whether the object is created by a controller rather than applied: it has owner
+// references, e.g. the Pods of a ReplicaSet, or it is one of the Endpoints and EndpointSlices that the
+// controllers of a Service create with the labels of the Service. Such objects are never rendered, so
+// they are not pruned.
+func IsControlled(doc GenericManifest) bool {
+	metadata, ok := doc["metadata"].(map[string]any)
+	if !ok {
+		metadata, _ = doc["metadata"].(GenericManifest)
+	}
+	if owners, _ := metadata["ownerReferences"].([]any); len(owners) > 0 {
+		return true
+	}
+	apiVersion, _ := doc["apiVersion"].(string)
+	kind, _ := doc["kind"].(string)
+	return apiVersion == "v1" && kind == "Endpoints" || strings.HasPrefix(apiVersion, "discovery.k8s.io/") && kind == "EndpointSlice"
+}
+
+// Group returns the API group of the object, empty for the core group.
+func (r ObjectRef) Group() string {
+	group, _, ok := strings.Cut(r.APIVersion, "/")
+	if !ok {
+		return ""
+	}
+	return group
+}
+
+// Resource returns the object as kubectl accepts it, e.g. "Deployment.apps/api" or "ConfigMap/config".
+func (r ObjectRef) Resource() string {
+	if group := r.Group(); group != "" {
+		return r.Kind + "." + group + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Name
+}
+
+// String returns the object as "Kind/name", prefixed with its namespace if it has one.
+func (r ObjectRef) String() string {
+	if r.Namespace != "" {
+		return r.Namespace + "/" + r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Name
+}
+
+// LabelValue converts s into a valid Kubernetes label value: at most 63 characters, made of
+// alphanumerics, '-', '_' and '.', beginning and ending with an alphanumeric. Other characters
+// are replaced with '-'.
+func LabelValue(s string) string {
+	value := []byte(s)
+	for i, c := range value {
+		if !isAlphanumeric(c) && c != '-' && c != '_' && c != '.' {
+			value[i] = '-'
+		}
+	}
+	if len(value) > maxLabelValue {
+		value = value[:maxLabelValue]
+	}
+	return strings.Trim(string(value), "-_.")
+}
+
+// InstanceLabelValue returns the value of LabelInstance for the plan applied to the namespace, e.g.
+// "my-plan.crib". Values that are not valid label values as is, e.g. because they are too long, are
+// shortened and suffixed with a hash of the plan and namespace, so that instances keep distinct values.
+func InstanceLabelValue(plan, namespace string) string {
+	instance := plan
+	if namespace != "" {
+		instance += "." + namespace
+	}
+	value := LabelValue(instance)
+	if value == instance {
+		return value
+	}
+	sum := sha256.Sum256([]byte(instance))
+	hash := hex.EncodeToString(sum[:4])
+	value = strings.TrimRight(value[:min(len(value), maxLabelValue-len(hash)-1)], "-_.")
+	if value == "" {
+		return hash
+	}
+	return value + "-" + hash
+}
+
+func isAlphanumeric(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in, want string
+	}{
+		{in: "sdk.HelmChart", want: "sdk.HelmChart"},
+		{in: "plan with spaces", want: "plan-with-spaces"},
+		{in: "_internal.", want: "internal"},
+		{in: "ünïcode", want: "n--code"},
+		{in: strings.Repeat("a", 70), want: strings.Repeat("a", 63)},
+		{in: strings.Repeat("a", 62) + "-b", want: strings.Repeat("a", 62)},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, LabelValue(tc.in), tc.in)
+	}
+}
+
+func TestInstanceLabelValue(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "my-plan.crib", InstanceLabelValue("my-plan", "crib"))
+	assert.Equal(t, "my-plan", InstanceLabelValue("my-plan", ""))
+	assert.NotEqual(t, InstanceLabelValue("my-plan", "crib"), InstanceLabelValue("my-plan", "other"))
+
+	// Values that must be shortened or sanitized keep distinct and valid.
+	long := strings.Repeat("a", 60)
+	a, b := InstanceLabelValue(long, "crib-a"), InstanceLabelValue(long, "crib-b")
+	assert.NotEqual(t, a, b)
+	assert.LessOrEqual(t, len(a), 63)
+	assert.Equal(t, a, LabelValue(a))
+	assert.NotEqual(t, InstanceLabelValue("my plan", "crib"), InstanceLabelValue("my-plan", "crib"))
+}
+
+func TestIsControlled(t *testing.T) {
+	t.Parallel()
+
+	object := func(apiVersion, kind string, metadata map[string]any) GenericManifest {
+		return GenericManifest{"apiVersion": apiVersion, "kind": kind, "metadata": metadata}
+	}
+	owners := []any{map[string]any{"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "api"}}
+	assert.True(t, IsControlled(object("v1", "Pod", map[string]any{"name": "api", "ownerReferences": owners})))
+	assert.True(t, IsControlled(object("v1", "Endpoints", map[string]any{"name": "api"})))
+	assert.True(t, IsControlled(object("discovery.k8s.io/v1", "EndpointSlice", map[string]any{"name": "api-x7k2p"})))
+	assert.False(t, IsControlled(object("v1", "Service", map[string]any{"name": "api", "ownerReferences": []any{}})))
+	assert.False(t, IsControlled(object("v1", "ConfigMap", nil)))
+}
+
+func TestObjectRef(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	ref := NewObjectRef(GenericManifest{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "api", "namespace": "crib"},
+	})
+	is.Equal(ObjectRef{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "crib", Name: "api"}, ref)
+	is.Equal("apps", ref.Group())
+	is.Equal("Deployment.apps/api", ref.Resource())
+	is.Equal("crib/Deployment/api", ref.String())
+
+	ref = NewObjectRef(GenericManifest{"apiVersion": "v1", "kind": "Namespace", "metadata": GenericManifest{"name": "crib"}})
+	is.Empty(ref.Group())
+	is.Equal("Namespace/crib", ref.Resource())
+	is.Equal("Namespace/crib", ref.String())
+}
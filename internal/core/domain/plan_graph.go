@@ -0,0 +1,101 @@
+package domain
+
+import "strings"
+
+// PlanNodeKind represents the kind of a node in a PlanGraph that is not a Kubernetes resource.
+// Nodes that render a Kubernetes resource have the kind of the resource, e.g. ConfigMap.
+const (
+	PlanNodeKindPlan      = "Plan"
+	PlanNodeKindChart     = "Chart"
+	PlanNodeKindHelm      = "Helm"
+	PlanNodeKindInclude   = "Include"
+	PlanNodeKindConstruct = "Construct"
+)
+
+// PlanNodePrefix is the prefix of the ID of the nodes that represent a plan, e.g. "plan:chainlink-jd".
+const PlanNodePrefix = "plan:"
+
+type (
+	// PlanGraph is the full construct tree of a rendered plan, together with the dependencies declared
+	// between its constructs. Every plan, including child plans, is a top-level node. The components
+	// of a plan are children of the plan node, and the constructs of a component are its descendants.
+	PlanGraph struct {
+		// Plan is the name of the root plan.
+		Plan string `json:"plan"`
+		// Namespace is the primary namespace of the root plan.
+		Namespace string `json:"namespace"`
+		// Nodes are the nodes of the graph. A parent always comes before its children.
+		Nodes []PlanNode `json:"nodes"`
+		// Edges are the dependencies between nodes.
+		Edges []PlanEdge `json:"edges,omitempty"`
+	}
+
+	// PlanNode is a plan or a construct of a PlanGraph.
+	PlanNode struct {
+		// ID identifies the node: the plan name prefixed with PlanNodePrefix for plans, and the
+		// path of the construct otherwise.
+		ID string `json:"id"`
+		// Name is the name of the plan, or the ID of the construct within its parent.
+		Name string `json:"name"`
+		// Parent is the ID of the parent node, empty for plans.
+		Parent string `json:"parent,omitempty"`
+		// Kind is the kind of the Kubernetes resource rendered by the node, or one of the PlanNodeKind
+		// constants for nodes that do not render a resource themselves.
+		Kind string `json:"kind"`
+		// Namespace is the namespace of the node, inherited from the closest chart if the node
+		// does not set its own.
+		Namespace string `json:"namespace,omitempty"`
+		// Resource is the resource rendered by the node, written as Kind/name, or the Helm release.
+		Resource string `json:"resource,omitempty"`
+	}
+
+	// PlanEdge is a dependency between two nodes of a PlanGraph: From is processed after To.
+	PlanEdge struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+)
+
+// Children returns the nodes whose parent is the node with the given ID, in order.
+func (g *PlanGraph) Children(id string) []PlanNode {
+	var children []PlanNode
+	for _, n := range g.Nodes {
+		if n.Parent == id {
+			children = append(children, n)
+		}
+	}
+	return children
+}
+
+// Node returns the node with the given ID, and whether it exists.
+func (g *PlanGraph) Node(id string) (PlanNode, bool) {
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n, true
+		}
+	}
+	return PlanNode{}, false
+}
+
+// IsPlan reports whether the node represents a plan.
+func (n PlanNode) IsPlan() bool {
+	return strings.HasPrefix(n.ID, PlanNodePrefix)
+}
+
+// Label returns a one-line description of the node: its name, kind, namespace and resource.
+func (n PlanNode) Label() string {
+	var b strings.Builder
+	b.WriteString(n.Name)
+	b.WriteString(" (")
+	b.WriteString(n.Kind)
+	if n.Namespace != "" {
+		b.WriteString(", namespace: ")
+		b.WriteString(n.Namespace)
+	}
+	if n.Resource != "" {
+		b.WriteString(", renders: ")
+		b.WriteString(n.Resource)
+	}
+	b.WriteString(")")
+	return b.String()
+}
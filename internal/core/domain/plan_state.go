@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// StepStatus represents the outcome of applying a single manifest bundle.
+const (
+	StepStatusSucceeded = "succeeded"
+	// StepStatusContinued indicates that the step failed and processing continued with the next step.
+	StepStatusContinued = "continued"
+	// StepStatusAborted indicates that the step failed and processing stopped.
+	StepStatusAborted = "aborted"
+	// StepStatusSkipped indicates that the step was not run, e.g. because it already succeeded
+	// in an earlier apply that is being resumed.
+	StepStatusSkipped = "skipped"
+)
+
+// ErrPlanRecordNotFound is an error that indicates that no record exists for a plan.
+var ErrPlanRecordNotFound = errors.New("plan record not found")
+
+type (
+	// PlanRecord is the persisted state of an applied plan. It captures enough information
+	// about the rendered manifests to detect when a fresh render of the plan has drifted
+	// from what was applied.
+	PlanRecord struct {
+		// Name is the name of the applied plan.
+		Name string `yaml:"name"`
+		// Namespace is the primary namespace of the applied plan.
+		Namespace string `yaml:"namespace,omitempty"`
+		// AppliedAt is the time at which the plan was applied.
+		AppliedAt time.Time `yaml:"appliedAt"`
+		// Bundles are the manifest bundles of the plan, in the order in which they are applied.
+		Bundles []BundleRecord `yaml:"bundles"`
+		// Charts are the Helm chart versions resolved while rendering the plan, keyed by the
+		// name of the HelmChart component.
+		Charts HelmChartVersions `yaml:"charts,omitempty"`
+	}
+
+	// BundleRecord is the persisted state of a single manifest bundle.
+	BundleRecord struct {
+		// Key identifies the bundle across renders of the same plan.
+		Key string `yaml:"key"`
+		// Hash is the content hash of the manifests in the bundle.
+		Hash string `yaml:"hash"`
+		// IsLocal is true for ClientSideApply bundles.
+		IsLocal bool `yaml:"isLocal,omitempty"`
+		// Step is the result of applying the bundle. It is nil if the bundle was not applied.
+		Step *StepRecord `yaml:"step,omitempty"`
+	}
+
+	// StepRecord is the result of applying a manifest bundle.
+	StepRecord struct {
+		Action   string        `yaml:"action"`
+		Status   string        `yaml:"status"`
+		Duration time.Duration `yaml:"duration"`
+		ExitCode int           `yaml:"exitCode,omitempty"`
+		Output   string        `yaml:"output,omitempty"`
+		Error    string        `yaml:"error,omitempty"`
+		// Outputs are the outputs produced by the step, which are restored when the step is skipped.
+		Outputs map[string]string `yaml:"outputs,omitempty"`
+	}
+
+	// BundleReport is the outcome of applying a single manifest bundle.
+	BundleReport struct {
+		// Bundle identifies the bundle across renders, see BundleRecord.Key.
+		Bundle string
+		// Path is the comma separated list of manifest files in the bundle.
+		Path string
+		// Action is the runner action that applied the bundle, e.g. kubectl.
+		Action string
+		// Duration is the time it took to apply the bundle.
+		Duration time.Duration
+		// ExitCode is the exit code of the runner, or -1 if it is not known.
+		ExitCode int
+		// Output is the captured output of the runner. Runners that write their output to a log file
+		// only capture its last part.
+		Output []byte
+		// LogFile is the path of the file holding the full output of the bundle, if any.
+		LogFile string
+		// Objects are the outcomes of the individual objects of bundles applied through the
+		// Kubernetes API, see ActionServerSideApply. It is empty for bundles applied by a runner.
+		Objects []ObjectResult
+		// Outputs are the outputs produced by a ClientSideApply step, keyed by name. Sensitive outputs
+		// are left out.
+		Outputs map[string]string
+		// Status is one of StepStatusSucceeded, StepStatusContinued, StepStatusAborted or StepStatusSkipped.
+		Status string
+		// Err is the error that caused the bundle to fail, if any.
+		Err error
+	}
+
+	// ApplyReport is the outcome of applying each bundle of a plan, in the order they were processed.
+	// Bundles after an aborted bundle are not processed and have no entry.
+	ApplyReport []BundleReport
+
+	// PlanDrift describes the differences between a plan record and a fresh render of the plan,
+	// as well as between the rendered manifests and the live objects in the cluster.
+	PlanDrift struct {
+		// Added are the keys of bundles that are rendered but were not applied.
+		Added []string
+		// Removed are the keys of bundles that were applied but are no longer rendered.
+		Removed []string
+		// Changed are the keys of bundles whose rendered content differs from what was applied.
+		Changed []string
+		// Charts are the Helm charts whose resolved version differs from what was applied.
+		Charts []ChartDrift
+		// Live are the keys of bundles whose objects differ from the live objects in the cluster.
+		Live []string
+	}
+
+	// ChartDrift describes a Helm chart whose resolved version has changed.
+	ChartDrift struct {
+		Name     string
+		Applied  string
+		Rendered string
+	}
+)
+
+// Bundle returns the record of the bundle with the given key, or nil if it does not exist.
+func (r *PlanRecord) Bundle(key string) *BundleRecord {
+	if r == nil {
+		return nil
+	}
+	i := slices.IndexFunc(r.Bundles, func(b BundleRecord) bool {
+		return b.Key == key
+	})
+	if i < 0 {
+		return nil
+	}
+	return &r.Bundles[i]
+}
+
+// Completed returns true if the bundle with the given key was applied successfully with the same content hash.
+func (r *PlanRecord) Completed(key, hash string) bool {
+	b := r.Bundle(key)
+	return b != nil && b.Hash == hash && b.Step != nil && b.Step.Status == StepStatusSucceeded
+}
+
+// Drift compares the applied record against a rendered record of the same plan. Only the
+// rendered state is compared, see PlanDrift.Live for differences with the cluster.
+func (r *PlanRecord) Drift(rendered *PlanRecord) *PlanDrift {
+	drift := new(PlanDrift)
+	for _, b := range rendered.Bundles {
+		switch applied := r.Bundle(b.Key); {
+		case applied == nil:
+			drift.Added = append(drift.Added, b.Key)
+		case applied.Hash != b.Hash:
+			drift.Changed = append(drift.Changed, b.Key)
+		}
+	}
+	for _, b := range r.Bundles {
+		if rendered.Bundle(b.Key) == nil {
+			drift.Removed = append(drift.Removed, b.Key)
+		}
+	}
+
+	applied := make(map[string]string, len(r.Charts))
+	for _, c := range r.Charts {
+		applied[c.Name] = c.Version
+	}
+	for _, c := range rendered.Charts {
+		if v, ok := applied[c.Name]; ok && v != c.Version {
+			drift.Charts = append(drift.Charts, ChartDrift{Name: c.Name, Applied: v, Rendered: c.Version})
+		}
+	}
+	return drift
+}
+
+// Fail marks the bundle as failed with the given error. The bundle is aborted if the error
+// is an AbortError, otherwise processing continues.
+func (r *BundleReport) Fail(err error) {
+	r.Err = err
+	r.Status = StepStatusContinued
+	if errors.Is(err, ErrAbort) {
+		r.Status = StepStatusAborted
+	}
+	if r.ExitCode == 0 {
+		r.ExitCode = -1
+	}
+}
+
+// Failed returns true if the bundle failed, regardless of whether processing continued.
+func (r *BundleReport) Failed() bool {
+	return r.Status != StepStatusSucceeded && r.Status != StepStatusSkipped
+}
+
+// Redact masks the registered secrets in the output and the error of the report, see RegisterSecret.
+func (r *BundleReport) Redact() {
+	r.Output = RedactBytes(r.Output)
+	r.Err = RedactError(r.Err)
+}
+
+// Err returns an aggregated error of all failed bundles, or nil if every bundle succeeded.
+func (r ApplyReport) Err() error {
+	var errs []error
+	for _, b := range r {
+		if b.Failed() {
+			errs = append(errs, b.Err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d bundles failed: %w", len(errs), len(r), errors.Join(errs...))
+}
+
+// HasDrift returns true if any difference was detected.
+func (d *PlanDrift) HasDrift() bool {
+	return len(d.Added)+len(d.Removed)+len(d.Changed)+len(d.Charts)+len(d.Live) > 0
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanRecordDrift(t *testing.T) {
+	t.Parallel()
+
+	applied := &PlanRecord{
+		Name: "test",
+		Bundles: []BundleRecord{
+			{Key: "ns/Namespace.a.k8s.yaml", Hash: "a"},
+			{Key: "csa/ClientSideApply.b.k8s.yaml", Hash: "b", IsLocal: true},
+			{Key: "removed/Service.c.k8s.yaml", Hash: "c"},
+		},
+		Charts: HelmChartVersions{
+			{Name: "anvil", Version: "0.1.0"},
+			{Name: "postgres", Version: "16.7.10"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		rendered *PlanRecord
+		want     *PlanDrift
+	}{
+		{
+			name:     "no drift",
+			rendered: applied,
+			want:     &PlanDrift{},
+		},
+		{
+			name: "drift",
+			rendered: &PlanRecord{
+				Name: "test",
+				Bundles: []BundleRecord{
+					{Key: "ns/Namespace.a.k8s.yaml", Hash: "a"},
+					{Key: "csa/ClientSideApply.b.k8s.yaml", Hash: "changed", IsLocal: true},
+					{Key: "added/Deployment.d.k8s.yaml", Hash: "d"},
+				},
+				Charts: HelmChartVersions{
+					{Name: "anvil", Version: "0.2.0"},
+					{Name: "postgres", Version: "16.7.10"},
+					{Name: "new", Version: "1.0.0"},
+				},
+			},
+			want: &PlanDrift{
+				Added:   []string{"added/Deployment.d.k8s.yaml"},
+				Removed: []string{"removed/Service.c.k8s.yaml"},
+				Changed: []string{"csa/ClientSideApply.b.k8s.yaml"},
+				Charts:  []ChartDrift{{Name: "anvil", Applied: "0.1.0", Rendered: "0.2.0"}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			is := assert.New(t)
+
+			got := applied.Drift(tc.rendered)
+			is.Equal(tc.want, got)
+			is.Equal(tc.name != "no drift", got.HasDrift())
+		})
+	}
+}
+
+func TestPlanDriftHasDrift(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, new(PlanDrift).HasDrift())
+	assert.True(t, (&PlanDrift{Live: []string{"a"}}).HasDrift())
+}
+
+func TestPlanRecordCompleted(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	record := &PlanRecord{
+		Bundles: []BundleRecord{
+			{Key: "a", Hash: "1", Step: &StepRecord{Status: StepStatusSucceeded}},
+			{Key: "b", Hash: "2", Step: &StepRecord{Status: StepStatusAborted}},
+			{Key: "c", Hash: "3"},
+		},
+	}
+	is.True(record.Completed("a", "1"))
+	is.False(record.Completed("a", "changed"), "the content changed since it was applied")
+	is.False(record.Completed("b", "2"), "the bundle failed")
+	is.False(record.Completed("c", "3"), "the bundle was not applied")
+	is.False(record.Completed("d", "4"), "the bundle is unknown")
+	is.False((*PlanRecord)(nil).Completed("a", "1"))
+}
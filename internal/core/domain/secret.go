@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"bytes"
+	"cmp"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// Redacted replaces the values of registered secrets in output.
+	Redacted = "******"
+	// SensitiveTag is the struct tag that marks fields of props holding secrets, e.g. `sensitive:"true"`.
+	SensitiveTag = "sensitive"
+
+	// minSecretLength is the length below which values are not registered as secrets, masking them
+	// would hide too much unrelated output.
+	minSecretLength = 4
+)
+
+// secrets holds the registered secrets, and the replacer masking them. The replacer is rebuilt
+// lazily after secrets were registered.
+var secrets = struct {
+	sync.RWMutex
+	values   map[string]struct{}
+	replacer *strings.Replacer
+}{values: make(map[string]struct{})}
+
+// RegisterSecret registers values as secrets, so that they are masked by Redact. Blank values and
+// values shorter than 4 characters are ignored.
+func RegisterSecret(values ...string) {
+	secrets.Lock()
+	defer secrets.Unlock()
+	for _, v := range values {
+		if len(strings.TrimSpace(v)) < minSecretLength {
+			continue
+		}
+		if _, ok := secrets.values[v]; !ok {
+			secrets.values[v] = struct{}{}
+			secrets.replacer = nil
+		}
+	}
+}
+
+// RegisterSensitive registers the values of the fields of v that are tagged as sensitive as secrets,
+// see SensitiveTag. Nested structs, and the structs in pointers, slices and maps, are searched too.
+// Tagged fields may hold a string, or a pointer, slice or map of strings. String fields still holding
+// the value of their default tag are not registered.
+func RegisterSensitive(v any) {
+	registerSensitive(reflect.ValueOf(v), false, make(map[uintptr]struct{}))
+}
+
+func registerSensitive(v reflect.Value, sensitive bool, seen map[uintptr]struct{}) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		// Props may reference each other, so every pointer is only visited once.
+		if _, ok := seen[v.Pointer()]; ok {
+			return
+		}
+		seen[v.Pointer()] = struct{}{}
+		registerSensitive(v.Elem(), sensitive, seen)
+	case reflect.Interface:
+		if !v.IsNil() {
+			registerSensitive(v.Elem(), sensitive, seen)
+		}
+	case reflect.String:
+		if sensitive {
+			RegisterSecret(v.String())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			registerSensitive(v.Index(i), sensitive, seen)
+		}
+	case reflect.Map:
+		for it := v.MapRange(); it.Next(); {
+			registerSensitive(it.Value(), sensitive, seen)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tagged := f.Tag.Get(SensitiveTag) == "true"
+			// A default is public, and often a common word that would be masked in unrelated output.
+			if def, ok := f.Tag.Lookup("default"); ok && tagged && v.Field(i).Kind() == reflect.String && v.Field(i).String() == def {
+				continue
+			}
+			registerSensitive(v.Field(i), sensitive || tagged, seen)
+		}
+	}
+}
+
+// Redact returns s with the values of all registered secrets replaced by Redacted.
+func Redact(s string) string {
+	r := redactor()
+	if r == nil {
+		return s
+	}
+	return r.Replace(s)
+}
+
+// RedactBytes returns b with the values of all registered secrets replaced by Redacted. The returned
+// slice is b itself if it holds no secrets.
+func RedactBytes(b []byte) []byte {
+	r := redactor()
+	if r == nil || len(b) == 0 {
+		return b
+	}
+	s := r.Replace(string(b))
+	if s == string(b) {
+		return b
+	}
+	return []byte(s)
+}
+
+// RedactError returns an error whose message has the values of all registered secrets replaced by
+// Redacted. The original error is still matched by errors.Is and errors.As.
+func RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	// Only errors that are redacted as a whole are returned as is, wrapped ones are redacted again.
+	if _, ok := err.(*redactedError); ok {
+		return err
+	}
+	return &redactedError{err: err}
+}
+
+// redactor returns the replacer masking the registered secrets, or nil if none are registered. Longer
+// secrets are replaced first, so that a secret containing another one, e.g. a database URL containing
+// its password, is masked as a whole.
+func redactor() *strings.Replacer {
+	secrets.RLock()
+	r, n := secrets.replacer, len(secrets.values)
+	secrets.RUnlock()
+	if r != nil || n == 0 {
+		return r
+	}
+
+	secrets.Lock()
+	defer secrets.Unlock()
+	if secrets.replacer == nil {
+		values := make([]string, 0, len(secrets.values))
+		for v := range secrets.values {
+			values = append(values, v)
+		}
+		slices.SortFunc(values, func(a, b string) int {
+			return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+		})
+		oldnew := make([]string, 0, 2*len(values))
+		for _, v := range values {
+			oldnew = append(oldnew, v, Redacted)
+		}
+		secrets.replacer = strings.NewReplacer(oldnew...)
+	}
+	return secrets.replacer
+}
+
+// redactedError masks the registered secrets in the message of the error it wraps.
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return Redact(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// RedactWriter is an io.Writer that masks the registered secrets in the lines written to the underlying
+// writer. Incomplete lines are buffered until they are terminated or flushed, so that secrets split
+// across writes are masked too.
+type RedactWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewRedactWriter returns a RedactWriter writing to w.
+func NewRedactWriter(w io.Writer) *RedactWriter {
+	return &RedactWriter{w: w}
+}
+
+// Write implements io.Writer. It always consumes all of p.
+func (w *RedactWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	_, err := w.w.Write(RedactBytes(w.buf[:i+1]))
+	w.buf = append(w.buf[:0], w.buf[i+1:]...)
+	return len(p), err
+}
+
+// Flush writes any buffered incomplete line, and flushes the underlying writer if it buffers its output.
+func (w *RedactWriter) Flush() error {
+	if len(w.buf) > 0 {
+		_, err := w.w.Write(RedactBytes(w.buf))
+		w.buf = w.buf[:0]
+		if err != nil {
+			return err
+		}
+	}
+	if f, ok := w.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	RegisterSecret("redact-test-password", "postgresql://user:redact-test-password@db:5432/redact", "abc", "   ")
+	assert.Equal(t, "url ****** and ******", Redact("url postgresql://user:redact-test-password@db:5432/redact and redact-test-password"))
+	assert.Equal(t, "abc is too short to be masked", Redact("abc is too short to be masked"))
+
+	b := []byte("nothing to mask")
+	assert.Same(t, &b[0], &RedactBytes(b)[0], "output without secrets is not copied")
+	assert.Equal(t, []byte("masked: ******"), RedactBytes([]byte("masked: redact-test-password")))
+}
+
+func TestRedactError(t *testing.T) {
+	t.Parallel()
+
+	RegisterSecret("redact-test-error")
+	err := RedactError(fmt.Errorf("running %q: %w", "login redact-test-error", NewAbortError(errors.New("exit status 1"))))
+	assert.EqualError(t, err, `running "login ******": exit status 1`)
+	assert.ErrorIs(t, err, ErrAbort)
+	assert.Same(t, err, RedactError(err), "redacted errors are not wrapped again")
+	assert.NoError(t, RedactError(nil))
+}
+
+func TestRegisterSensitive(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		Key  string `sensitive:"true"`
+		Name string
+	}
+	type props struct {
+		Password *string            `sensitive:"true"`
+		Files    map[string]*string `sensitive:"true"`
+		Tokens   []string           `sensitive:"true"`
+		Nested   nested
+		Nodes    []*nested
+		Image    string
+		User     string `default:"sensitive-test-user" sensitive:"true"`
+		Database string `default:"sensitive-test-database" sensitive:"true"`
+	}
+	password, file := "sensitive-test-password", "sensitive-test-file"
+	RegisterSensitive(&props{
+		Password: &password,
+		Files:    map[string]*string{"secrets.toml": &file},
+		Tokens:   []string{"sensitive-test-token"},
+		Nested:   nested{Key: "sensitive-test-key", Name: "sensitive-test-name"},
+		Nodes:    []*nested{{Key: "sensitive-test-node"}, nil},
+		Image:    "sensitive-test-image",
+		User:     "sensitive-test-user",
+		Database: "sensitive-test-db",
+	})
+
+	for _, secret := range []string{password, file, "sensitive-test-token", "sensitive-test-key", "sensitive-test-node", "sensitive-test-db"} {
+		assert.Equal(t, Redacted, Redact(secret), secret)
+	}
+	for _, value := range []string{"sensitive-test-name", "sensitive-test-image", "sensitive-test-user"} {
+		assert.Equal(t, value, Redact(value), "%s is not a secret", value)
+	}
+}
+
+func TestRedactWriter(t *testing.T) {
+	t.Parallel()
+
+	RegisterSecret("redact-test-writer")
+	var buf bytes.Buffer
+	w := NewRedactWriter(&buf)
+	for _, chunk := range []string{"first redact-", "test-wri", "ter\nsecond ", "redact-test-writer"} {
+		_, err := w.Write([]byte(chunk))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "first ******\n", buf.String(), "incomplete lines are buffered")
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "first ******\nsecond ******", buf.String())
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// outputPlaceholder matches the references to the outputs of ClientSideApply steps, see OutputPlaceholder.
+var outputPlaceholder = regexp.MustCompile(`\$\{\{\s*outputs\.([[:alnum:]]+)\s*\}\}`)
+
+// OutputPlaceholder returns the placeholder that is replaced with the named output of a ClientSideApply
+// step when the plan is applied, e.g. ${{ outputs.contractAddress }}. Placeholders can be used in the
+// args, env, workingDir and stdin of later steps, and anywhere in Kubernetes manifests.
+func OutputPlaceholder(name string) string {
+	return "${{ outputs." + name + " }}"
+}
+
+// HasOutputPlaceholder reports whether b references an output.
+func HasOutputPlaceholder(b []byte) bool {
+	return outputPlaceholder.Match(b)
+}
+
+// OutputReferences returns the names of the outputs referenced by b, in the order of their first reference.
+func OutputReferences(b []byte) []string {
+	var names []string
+	for _, match := range outputPlaceholder.FindAllSubmatch(b, -1) {
+		if name := string(match[1]); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ResolveOutputs replaces the output placeholders in s with the values of the outputs. It errors if an
+// output is referenced that no step produced (yet).
+func ResolveOutputs(s string, outputs map[string]string) (string, error) {
+	var missing []string
+	resolved := outputPlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := outputPlaceholder.FindStringSubmatch(placeholder)[1]
+		value, ok := outputs[name]
+		if !ok && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined outputs %s: the steps producing them must be applied first", strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+// ResolveOutputs replaces the output placeholders in the args, env, working directory and stdin of the
+// step with the values of the outputs.
+func (s *ClientSideApplySpec) ResolveOutputs(outputs map[string]string) error {
+	var err error
+	resolve := func(v *string) {
+		if err == nil {
+			*v, err = ResolveOutputs(*v, outputs)
+		}
+	}
+	for i := range s.Args {
+		resolve(&s.Args[i])
+	}
+	if len(s.Env) > 0 {
+		s.Env = maps.Clone(s.Env)
+		for name, value := range s.Env {
+			resolve(&value)
+			s.Env[name] = value
+		}
+	}
+	resolve(&s.WorkingDir)
+	resolve(&s.Stdin)
+	return err
+}
+
+// OutputReferences returns the names of the outputs referenced by the args, env, working directory and
+// stdin of the step, in the order of their first reference.
+func (s *ClientSideApplySpec) OutputReferences() []string {
+	refs := slices.Concat(s.Args, slices.Sorted(maps.Values(s.Env)), []string{s.WorkingDir, s.Stdin})
+	return OutputReferences([]byte(strings.Join(refs, "\n")))
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveOutputs(t *testing.T) {
+	t.Parallel()
+
+	outputs := map[string]string{"address": "0xabc", "csaKey": "abcd"}
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr string
+	}{
+		{name: "no placeholders", in: "echo ${HOME}", want: "echo ${HOME}"},
+		{name: "placeholder", in: "--address=" + OutputPlaceholder("address"), want: "--address=0xabc"},
+		{name: "without spaces", in: "${{outputs.csaKey}}/${{ outputs.address}}", want: "abcd/0xabc"},
+		{name: "undefined", in: "${{ outputs.missing }} ${{ outputs.other }} ${{ outputs.missing }}", wantErr: "undefined outputs missing, other"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ResolveOutputs(tc.in, outputs)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.in != tc.want, HasOutputPlaceholder([]byte(tc.in)))
+		})
+	}
+}
+
+func TestOutputReferences(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"missing", "address"}, OutputReferences([]byte("${{ outputs.missing }} ${{outputs.address}} ${{ outputs.missing }}")))
+	assert.Empty(t, OutputReferences([]byte("echo ${HOME}")))
+}
+
+func TestClientSideApplySpecResolveOutputs(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	env := map[string]string{"ADDRESS": OutputPlaceholder("address")}
+	spec := ClientSideApplySpec{
+		Action:     ActionCribctl,
+		Args:       []string{"register", "--key", OutputPlaceholder("csaKey")},
+		Env:        env,
+		WorkingDir: "/tmp/" + OutputPlaceholder("address"),
+		Stdin:      OutputPlaceholder("csaKey"),
+	}
+	is.Equal([]string{"csaKey", "address"}, spec.OutputReferences())
+	is.NoError(spec.ResolveOutputs(map[string]string{"address": "0xabc", "csaKey": "abcd"}))
+	is.Equal([]string{"register", "--key", "abcd"}, spec.Args)
+	is.Equal(map[string]string{"ADDRESS": "0xabc"}, spec.Env)
+	is.Equal(OutputPlaceholder("address"), env["ADDRESS"], "the env of the manifest is not modified")
+	is.Equal("/tmp/0xabc", spec.WorkingDir)
+	is.Equal("abcd", spec.Stdin)
+	is.Empty(spec.OutputReferences())
+
+	spec.Stdin = OutputPlaceholder("missing")
+	is.ErrorContains(spec.ResolveOutputs(nil), "undefined outputs missing")
+}
@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Wait is the kind of the manifests that make cribctl wait for a condition before applying the
+// manifests that depend on them.
+const Wait = "Wait"
+
+// ActionWait is the action reported for Wait bundles.
+const ActionWait = "wait"
+
+// WaitCondition represents the condition that a Wait manifest waits for.
+const (
+	// WaitRollout waits for the rollout of Deployments, StatefulSets or DaemonSets to complete.
+	WaitRollout = "rollout"
+	// WaitPodReady waits for every selected Pod to be ready. At least one Pod must exist.
+	WaitPodReady = "pod-ready"
+	// WaitJobComplete waits for Jobs to complete, and fails as soon as one of them failed.
+	WaitJobComplete = "job-complete"
+	// WaitEndpoints waits for the Endpoints of a Service to have ready addresses.
+	WaitEndpoints = "endpoints"
+	// WaitHTTP waits for a URL to respond with HTTP 200.
+	WaitHTTP = "http"
+	// WaitCRDEstablished waits for a CustomResourceDefinition to be established.
+	WaitCRDEstablished = "crd-established"
+)
+
+// Defaults of the timeout and poll interval of wait conditions that do not set their own.
+const (
+	DefaultWaitTimeout  = 10 * time.Minute
+	DefaultWaitInterval = 2 * time.Second
+)
+
+type (
+	// WaitManifest represents a manifest that waits for a condition on the client side.
+	WaitManifest struct {
+		Manifest `yaml:",inline"`
+		Spec     WaitSpec `yaml:"spec"`
+	}
+
+	// WaitSpec describes the condition to wait for and the objects it applies to. Objects are selected
+	// by name, or by label selector.
+	WaitSpec struct {
+		Condition string `yaml:"condition" validate:"required,oneof=rollout pod-ready job-complete endpoints http crd-established"`
+		Namespace string `yaml:"namespace,omitempty"`
+		// Kind is the kind of the workloads of a rollout: Deployment, StatefulSet or DaemonSet.
+		Kind     string `yaml:"kind,omitempty"     validate:"required_if=Condition rollout,omitempty,oneof=Deployment StatefulSet DaemonSet"`
+		Name     string `yaml:"name,omitempty"`
+		Selector string `yaml:"selector,omitempty"`
+		// URL is the URL requested by http conditions.
+		URL string `yaml:"url,omitempty" validate:"required_if=Condition http,omitempty,http_url"`
+		// Timeout and Interval default to the WaitDefaults of the applied plan when zero.
+		Timeout  time.Duration `yaml:"timeout,omitempty"`
+		Interval time.Duration `yaml:"interval,omitempty"`
+	}
+
+	// WaitDefaults are the timeout and poll interval of the wait conditions that do not set their own.
+	WaitDefaults struct {
+		Timeout  time.Duration
+		Interval time.Duration
+	}
+)
+
+// Or returns the defaults, taking each value that is not set from d2.
+func (d WaitDefaults) Or(d2 WaitDefaults) WaitDefaults {
+	return WaitDefaults{
+		Timeout:  cmp.Or(d.Timeout, d2.Timeout),
+		Interval: cmp.Or(d.Interval, d2.Interval),
+	}
+}
+
+// WithDefaults returns the spec with the timeout and interval taken from the defaults if it does not set them.
+func (s WaitSpec) WithDefaults(d WaitDefaults) WaitSpec {
+	s.Timeout = cmp.Or(s.Timeout, d.Timeout)
+	s.Interval = cmp.Or(s.Interval, d.Interval)
+	return s
+}
+
+// Object returns the API version and kind of the objects that the condition is evaluated against.
+// It returns empty strings for http conditions.
+func (s *WaitSpec) Object() (apiVersion, kind string) {
+	switch s.Condition {
+	case WaitRollout:
+		return "apps/v1", s.Kind
+	case WaitPodReady:
+		return "v1", "Pod"
+	case WaitJobComplete:
+		return "batch/v1", "Job"
+	case WaitEndpoints:
+		return "v1", "Endpoints"
+	case WaitCRDEstablished:
+		return "apiextensions.k8s.io/v1", "CustomResourceDefinition"
+	}
+	return "", ""
+}
+
+// String describes the condition, e.g. "pod-ready of Pods app=api in default".
+func (s *WaitSpec) String() string {
+	return s.Condition + " of " + s.target()
+}
+
+// target describes the objects that the condition applies to.
+func (s *WaitSpec) target() string {
+	if s.Condition == WaitHTTP {
+		return s.URL
+	}
+	_, kind := s.Object()
+	target := kind + "/" + s.Name
+	if s.Name == "" {
+		target = kind + "s " + s.Selector
+	}
+	if s.Namespace != "" {
+		target += " in " + s.Namespace
+	}
+	return target
+}
+
+// Evaluate reports whether the live objects meet the condition. Otherwise, status describes what is
+// still being waited for. An error is returned when the condition can no longer be met, e.g. because
+// a Job failed.
+func (s *WaitSpec) Evaluate(objects []GenericManifest) (ready bool, status string, err error) {
+	if len(objects) == 0 {
+		return false, "no " + s.target() + " found", nil
+	}
+	for _, obj := range objects {
+		ready, status, err := s.evaluate(obj)
+		if err != nil {
+			return false, "", fmt.Errorf("%s/%s: %w", kindOf(obj), NewObjectRef(obj).Name, err)
+		}
+		if !ready {
+			return false, fmt.Sprintf("%s/%s: %s", kindOf(obj), NewObjectRef(obj).Name, status), nil
+		}
+	}
+	return true, "", nil
+}
+
+// evaluate reports whether a single live object meets the condition.
+func (s *WaitSpec) evaluate(obj GenericManifest) (bool, string, error) {
+	switch s.Condition {
+	case WaitRollout:
+		return rolloutComplete(obj)
+	case WaitPodReady:
+		if condition(obj, "Ready") {
+			return true, "", nil
+		}
+		return false, "not ready", nil
+	case WaitJobComplete:
+		if condition(obj, "Failed") {
+			return false, "", errors.New("job failed")
+		}
+		if condition(obj, "Complete") {
+			return true, "", nil
+		}
+		return false, "not complete", nil
+	case WaitEndpoints:
+		for _, subset := range slice(obj, "subsets") {
+			if len(slice(subset, "addresses")) > 0 {
+				return true, "", nil
+			}
+		}
+		return false, "no ready addresses", nil
+	case WaitCRDEstablished:
+		if condition(obj, "Established") {
+			return true, "", nil
+		}
+		return false, "not established", nil
+	}
+	return false, "", fmt.Errorf("unsupported wait condition %q", s.Condition)
+}
+
+// rolloutComplete reports whether the rollout of a Deployment, StatefulSet or DaemonSet is complete, with
+// the same rules as kubectl rollout status.
+func rolloutComplete(obj GenericManifest) (bool, string, error) {
+	if number(obj, "status", "observedGeneration") < number(obj, "metadata", "generation") {
+		return false, "waiting for the rollout to be observed", nil
+	}
+	var desired, updated, available int64
+	switch kindOf(obj) {
+	case "DaemonSet":
+		desired = number(obj, "status", "desiredNumberScheduled")
+		updated = number(obj, "status", "updatedNumberScheduled")
+		available = number(obj, "status", "numberAvailable")
+	case "StatefulSet":
+		desired = replicas(obj)
+		updated = number(obj, "status", "updatedReplicas")
+		available = number(obj, "status", "readyReplicas")
+	default:
+		desired = replicas(obj)
+		updated = number(obj, "status", "updatedReplicas")
+		available = number(obj, "status", "availableReplicas")
+		if old := number(obj, "status", "replicas") - updated; old > 0 {
+			return false, fmt.Sprintf("%d old replicas are pending termination", old), nil
+		}
+	}
+	if updated < desired {
+		return false, fmt.Sprintf("%d of %d updated replicas", updated, desired), nil
+	}
+	if available < desired {
+		return false, fmt.Sprintf("%d of %d updated replicas are available", available, desired), nil
+	}
+	return true, "", nil
+}
+
+// replicas returns the desired number of replicas of a workload, which defaults to 1.
+func replicas(obj GenericManifest) int64 {
+	if field(obj, "spec", "replicas") == nil {
+		return 1
+	}
+	return number(obj, "spec", "replicas")
+}
+
+// condition reports whether the object has a status condition of the given type with status True.
+func condition(obj GenericManifest, conditionType string) bool {
+	for _, c := range slice(obj, "status", "conditions") {
+		if field(c, "type") == conditionType && field(c, "status") == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+func kindOf(obj GenericManifest) string {
+	kind, _ := obj["kind"].(string)
+	return kind
+}
+
+// field returns the value at the path within the object, or nil. Nested mappings may be decoded as
+// GenericManifest or map[string]any.
+func field(v any, path ...string) any {
+	for _, key := range path {
+		switch m := v.(type) {
+		case GenericManifest:
+			v = m[key]
+		case map[string]any:
+			v = m[key]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// number returns the number at the path within the object, or 0.
+func number(v any, path ...string) int64 {
+	switch n := field(v, path...).(type) {
+	case int:
+		return int64(n)
+	case int64:
+		return n
+	case float64:
+		return int64(n)
+	}
+	return 0
+}
+
+// slice returns the sequence at the path within the object, or nil.
+func slice(v any, path ...string) []any {
+	s, _ := field(v, path...).([]any)
+	return s
+}
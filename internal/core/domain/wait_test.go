@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitSpecEvaluate(t *testing.T) {
+	t.Parallel()
+
+	obj := func(kind, name string, fields map[string]any) GenericManifest {
+		doc := GenericManifest{"kind": kind, "metadata": map[string]any{"name": name, "generation": 2}}
+		for k, v := range fields {
+			doc[k] = v
+		}
+		return doc
+	}
+	conditions := func(conditionType, status string) map[string]any {
+		return map[string]any{"conditions": []any{map[string]any{"type": conditionType, "status": status}}}
+	}
+
+	tests := []struct {
+		desc    string
+		spec    WaitSpec
+		objects []GenericManifest
+		ready   bool
+		status  string
+		err     string
+	}{
+		{
+			desc:   "no objects",
+			spec:   WaitSpec{Condition: WaitPodReady, Namespace: "crib", Selector: "app=api"},
+			status: "no Pods app=api in crib found",
+		},
+		{
+			desc:    "pods ready",
+			spec:    WaitSpec{Condition: WaitPodReady, Selector: "app=api"},
+			objects: []GenericManifest{obj("Pod", "a", map[string]any{"status": conditions("Ready", "True")})},
+			ready:   true,
+		},
+		{
+			desc: "pod not ready",
+			spec: WaitSpec{Condition: WaitPodReady, Selector: "app=api"},
+			objects: []GenericManifest{
+				obj("Pod", "a", map[string]any{"status": conditions("Ready", "True")}),
+				obj("Pod", "b", map[string]any{"status": conditions("Ready", "False")}),
+			},
+			status: "Pod/b: not ready",
+		},
+		{
+			desc: "deployment rolled out",
+			spec: WaitSpec{Condition: WaitRollout, Kind: "Deployment", Name: "api"},
+			objects: []GenericManifest{obj("Deployment", "api", map[string]any{
+				"spec":   map[string]any{"replicas": 2},
+				"status": map[string]any{"observedGeneration": 2, "replicas": 2, "updatedReplicas": 2, "availableReplicas": 2},
+			})},
+			ready: true,
+		},
+		{
+			desc: "deployment rollout not observed",
+			spec: WaitSpec{Condition: WaitRollout, Kind: "Deployment", Name: "api"},
+			objects: []GenericManifest{obj("Deployment", "api", map[string]any{
+				"status": map[string]any{"observedGeneration": 1},
+			})},
+			status: "Deployment/api: waiting for the rollout to be observed",
+		},
+		{
+			desc: "deployment with old replicas",
+			spec: WaitSpec{Condition: WaitRollout, Kind: "Deployment", Name: "api"},
+			objects: []GenericManifest{obj("Deployment", "api", map[string]any{
+				"status": map[string]any{"observedGeneration": 2, "replicas": 2, "updatedReplicas": 1, "availableReplicas": 2},
+			})},
+			status: "Deployment/api: 1 old replicas are pending termination",
+		},
+		{
+			desc: "statefulset not ready",
+			spec: WaitSpec{Condition: WaitRollout, Kind: "StatefulSet", Selector: "app=db"},
+			objects: []GenericManifest{obj("StatefulSet", "db", map[string]any{
+				"spec":   map[string]any{"replicas": 3.0},
+				"status": map[string]any{"observedGeneration": 2.0, "updatedReplicas": 3.0, "readyReplicas": 1.0},
+			})},
+			status: "StatefulSet/db: 1 of 3 updated replicas are available",
+		},
+		{
+			desc:    "job failed",
+			spec:    WaitSpec{Condition: WaitJobComplete, Name: "migrate"},
+			objects: []GenericManifest{obj("Job", "migrate", map[string]any{"status": conditions("Failed", "True")})},
+			err:     "Job/migrate: job failed",
+		},
+		{
+			desc:    "job complete",
+			spec:    WaitSpec{Condition: WaitJobComplete, Name: "migrate"},
+			objects: []GenericManifest{obj("Job", "migrate", map[string]any{"status": conditions("Complete", "True")})},
+			ready:   true,
+		},
+		{
+			desc: "endpoints without addresses",
+			spec: WaitSpec{Condition: WaitEndpoints, Name: "api"},
+			objects: []GenericManifest{obj("Endpoints", "api", map[string]any{
+				"subsets": []any{map[string]any{"notReadyAddresses": []any{map[string]any{"ip": "10.0.0.1"}}}},
+			})},
+			status: "Endpoints/api: no ready addresses",
+		},
+		{
+			desc: "endpoints with addresses",
+			spec: WaitSpec{Condition: WaitEndpoints, Name: "api"},
+			objects: []GenericManifest{obj("Endpoints", "api", map[string]any{
+				"subsets": []any{map[string]any{"addresses": []any{map[string]any{"ip": "10.0.0.1"}}}},
+			})},
+			ready: true,
+		},
+		{
+			desc:    "crd established",
+			spec:    WaitSpec{Condition: WaitCRDEstablished, Name: "widgets.example.com"},
+			objects: []GenericManifest{obj("CustomResourceDefinition", "widgets.example.com", map[string]any{"status": conditions("Established", "True")})},
+			ready:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ready, status, err := tc.spec.Evaluate(tc.objects)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ready, ready)
+			assert.Equal(t, tc.status, status)
+		})
+	}
+}
+
+func TestWaitSpecWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	defaults := WaitDefaults{Timeout: time.Minute}.Or(WaitDefaults{Timeout: time.Hour, Interval: time.Second})
+	assert.Equal(t, WaitDefaults{Timeout: time.Minute, Interval: time.Second}, defaults)
+
+	spec := WaitSpec{Condition: WaitHTTP, URL: "http://localhost", Interval: 5 * time.Second}.WithDefaults(defaults)
+	assert.Equal(t, time.Minute, spec.Timeout)
+	assert.Equal(t, 5*time.Second, spec.Interval)
+	assert.Equal(t, "http of http://localhost", spec.String())
+}
@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// KubernetesApplier applies and deletes Kubernetes objects through the API server, as an alternative
+// to running kubectl. Every object is reported, the returned error joins the errors of the failed objects.
+type KubernetesApplier interface {
+	// Apply applies the objects in order with server-side apply. Objects without a namespace are
+	// applied to the default namespace of the cluster.
+	Apply(ctx context.Context, objects []domain.GenericManifest) ([]domain.ObjectResult, error)
+	// Delete deletes the objects in reverse order and waits until they are gone. Objects that
+	// do not exist are reported as not found, which is not an error.
+	Delete(ctx context.Context, objects []domain.GenericManifest) ([]domain.ObjectResult, error)
+	// List lists the objects matching the label selector, across every namespace and every resource
+	// type that can be listed and deleted. Objects created by controllers, see domain.IsControlled,
+	// are left out.
+	List(ctx context.Context, selector string) ([]domain.ObjectRef, error)
+}
+
+// KubernetesReader reads the live objects that a wait condition is evaluated against.
+type KubernetesReader interface {
+	// Read returns the objects of the kind of the condition, see domain.WaitSpec.Object, that match its
+	// name or label selector. Objects that do not exist are not returned, which is not an error.
+	Read(ctx context.Context, spec *domain.WaitSpec) ([]domain.GenericManifest, error)
+}
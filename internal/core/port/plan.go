@@ -37,4 +37,20 @@ type (
 
 	// ComponentFunc is a function that takes a context and props and returns a Component.
 	ComponentFunc func(ctx context.Context) (Component, error)
+
+	// Parameter represents a typed parameter declared on a [crib.Plan], whose value can be set
+	// when the plan is applied.
+	Parameter interface {
+		// Name returns the name of the parameter, unique within the plan and its child plans.
+		Name() string
+		// Type returns the name of the Go type of the parameter value.
+		Type() string
+		// Default returns the value of the parameter when it is not set.
+		Default() any
+		// Usage returns a short description of the parameter.
+		Usage() string
+		// Resolve converts the raw value to the type of the parameter and validates it. A nil raw
+		// value resolves to the default value.
+		Resolve(ctx context.Context, raw any) (any, error)
+	}
 )
@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// PlanStateStore defines methods for persisting the records of applied plans. Records are kept per
+// plan and namespace, since the same plan may be applied to several namespaces.
+type PlanStateStore interface {
+	// Save stores the record, replacing any existing record for the same plan and namespace.
+	Save(ctx context.Context, record *domain.PlanRecord) error
+	// Load returns the record of the named plan applied to the namespace. It returns an error wrapping
+	// [domain.ErrPlanRecordNotFound] if the plan has no record.
+	Load(ctx context.Context, name, namespace string) (*domain.PlanRecord, error)
+	// Delete removes the record of the named plan applied to the namespace. Deleting a missing record
+	// is not an error.
+	Delete(ctx context.Context, name, namespace string) error
+}
+
+// ApplyProgress is notified as the bundles of a plan are applied, e.g. to show the progress of the
+// apply on a terminal. Its methods are never called concurrently.
+type ApplyProgress interface {
+	// BundleStarted is called before a bundle is applied.
+	BundleStarted(r domain.BundleReport)
+	// BundleFinished is called once a bundle was applied, failed or was skipped.
+	BundleFinished(r domain.BundleReport)
+}
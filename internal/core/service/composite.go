@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"maps"
 	"path"
 	"reflect"
 	"runtime"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"weak"
 
 	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/pool"
 	"go.uber.org/fx"
 
 	"github.com/smartcontractkit/crib-sdk/internal"
@@ -22,6 +27,15 @@ import (
 // _voidValue is the [reflect.Value] for an empty struct.
 var _voidValue = reflect.ValueOf(struct{}{})
 
+// _inType is the [reflect.Type] of In.
+var _inType = reflect.TypeFor[In]()
+
+// _outType is the [reflect.Type] of Out.
+var _outType = reflect.TypeFor[Out]()
+
+// _errorType is the [reflect.Type] of error.
+var _errorType = reflect.TypeFor[error]()
+
 type (
 	AutoComponent struct {
 		component   any
@@ -29,17 +43,91 @@ type (
 		applyMethod reflect.Method // The Apply method of the component.
 		produces    reflect.Type
 		consumes    []reflect.Type
-		isSliceType bool // for collecting multiple instances like []GroupResult
+		isSliceType bool          // for collecting multiple instances like []GroupResult
+		qualifier   string        // The qualifier that the component was registered with, see Named.
+		ctor        reflect.Value // The constructor of a component that is created when it is applied, see Constructor.
+	}
+
+	// NamedConstructor is a Component constructor registered with a qualifier, see Named.
+	NamedConstructor struct {
+		name string
+		ctor any
+	}
+
+	// In is embedded in a struct parameter of an Apply method to have each of its exported fields
+	// provided separately, like fx.In. A field tagged with a qualifier, e.g. `name:"registry-5001"`,
+	// is provided by the component registered with that qualifier, see Named.
+	//
+	//	type RegistryParams struct {
+	//		service.In
+	//		Registry *DockerResults `name:"registry-5001"`
+	//		Ctx      context.Context
+	//	}
+	In struct{}
+
+	// Out is embedded in the struct returned by an Apply method to have each of its exported fields produced
+	// separately, like fx.Out, so that a component can produce several values. A field tagged with a qualifier,
+	// e.g. `name:"registry-url"`, is consumed by asking for that qualifier, see In.
+	//
+	//	type RegistryOutputs struct {
+	//		service.Out
+	//		URL         string `name:"registry-url"`
+	//		Credentials *Credentials
+	//		Chart       port.Component
+	//	}
+	Out struct{}
+
+	// Optional is a parameter, or a field of an In struct, that is provided with a value of type T if a component
+	// produces one. Unlike other dependencies it is left empty, rather than failing with a missing dependency,
+	// if no component does.
+	//
+	//	func (m *MyComponent) Apply(registry service.Optional[*DockerResults]) {
+	//		if r, ok := registry.Get(); ok {
+	//			// Use r.
+	//		}
+	//	}
+	Optional[T any] struct {
+		Value T    // The provided value, or the zero value of T if none was provided.
+		Ok    bool // Whether a value was provided.
+	}
+
+	// optional is implemented by every Optional, to find the type of the value it holds.
+	optional interface {
+		optionalType() reflect.Type
+	}
+
+	// product is a value produced by a component: its type, the qualifier that it is registered with, if
+	// any, and the index of the field of the Out struct holding it, or -1 if it is the value returned.
+	product struct {
+		typ   reflect.Type
+		name  string
+		field int
+	}
+
+	// dependency is a value consumed by a component: its type, and the qualifier of the component
+	// providing it, if the component asks for a specific one.
+	dependency struct {
+		typ   reflect.Type
+		name  string
+		field int // The index of the field of an In struct that receives the value.
 	}
 
 	// Constructor is a resolved Component constructor that can be used to create a new instance of a component.
 	// For example, the constructor is the result of `func() *MyComponent` or `func() (*MyComponent, error)`.
+	// Constructors with parameters, e.g. `func(ctx context.Context, res *DockerResults) (*MyComponent, error)`,
+	// are only called when the component is applied, once the values they consume are available.
 	Constructor struct {
 		component any                      // The actual component instance created by the constructor.
 		namer     func(name string) string // The name of the component, derived from the constructor or component type.
 		cType     reflect.Type             // The type of the component, used for reflection.
+		ctor      reflect.Value            // The constructor function.
+		err       error                    // The error returned by the constructor, if any.
 	}
 
+	// ParamValues holds the values of the plan parameters that a Composite is applied with, by parameter
+	// name. Constructors and Apply methods receive it by declaring a ParamValues parameter.
+	ParamValues map[string]any
+
 	// ComponentExecutor defines the interface for executing individual components.
 	ComponentExecutor interface {
 		// ExecuteComponent executes a single AutoComponent and handles its dependencies.
@@ -53,26 +141,40 @@ type (
 		executor     ComponentExecutor
 		results      map[reflect.Type]any
 		sliceResults map[reflect.Type][]any
+		namedResults map[string]any         // The results of the components registered with a qualifier, by qualifier.
+		sliceOrder   map[reflect.Type][]int // The indexes of the components that produced each of the sliceResults.
 		components   []*AutoComponent
 		mu           sync.RWMutex
+		sequential   bool // Apply the components one at a time, see WithSequentialExecution.
 	}
 
 	// CompositeSet contains fx Options and is used by a Plan to execute a Composite.
 	CompositeSet struct {
-		fxOpts []fx.Option
+		fxOpts     []fx.Option
+		sequential bool
 	}
 
+	// CompositeSetOpt configures a CompositeSet.
+	CompositeSetOpt func(*CompositeSet)
+
 	// chartContext is a composite builtin that injects a method to fetch a context.Context that is used to create a cdk8s.Chart instance.
 	// The provided context contains the full root chart context and can be used to create other constructs within the chart.
 	chartContext struct {
 		instanceCtx func() context.Context
 	}
 
-	// CompositeResult satisfies the port.Component interface and contains the result of applying a Composite.
-	// TODO(polds): This is inaccurate. It only contains the root cdk8s.Chart instance.
-	// 	Still need a way to fetch into a composite and get their results.
+	// builtin is a composite builtin that provides a value of the context that the Composite is applied with,
+	// e.g. its validator, to the components consuming it.
+	builtin[T any] struct {
+		name  string
+		value T
+	}
+
+	// CompositeResult satisfies the port.Component interface and contains the result of applying a Composite:
+	// the root cdk8s.Chart instance, and the values produced by the components of the Composite, see Outputs.
 	CompositeResult struct {
 		port.Component
+		composite *Composite
 	}
 
 	// constructorRefs exists as a way to allow the composite with their constructors to implement the propsValidator
@@ -101,45 +203,77 @@ func (c *chartContext) Name() string {
 	return "sdk.composite.builtin.chartContext"
 }
 
+func newBuiltin[T any](name string, value T) func() *builtin[T] {
+	return func() *builtin[T] {
+		return &builtin[T]{name: name, value: value}
+	}
+}
+
+func (b *builtin[T]) Apply() T {
+	return b.value
+}
+
+func (b *builtin[T]) Name() string {
+	return b.name
+}
+
 // NewCompositeSet initializes a CompositeSet with the base Fx options.
 // It sets up a lifecycle hook to apply the composite when the application starts.
 // The returned CompositeSet can be used to apply components defined in the Composite.
-func NewCompositeSet() *CompositeSet {
-	cs := &CompositeSet{
-		fxOpts: []fx.Option{
-			fx.Invoke(
-				func(lc fx.Lifecycle, composite *Composite) {
-					lc.Append(fx.Hook{
-						OnStart: func(ctx context.Context) error {
-							return composite.Apply(ctx)
-						},
-					})
-				},
-			),
-		},
+func NewCompositeSet(opts ...CompositeSetOpt) *CompositeSet {
+	cs := &CompositeSet{}
+	for _, opt := range opts {
+		opt(cs)
+	}
+	cs.fxOpts = []fx.Option{
+		fx.Invoke(
+			func(lc fx.Lifecycle, composite *Composite) {
+				composite.sequential = cs.sequential
+				lc.Append(fx.Hook{
+					OnStart: func(ctx context.Context) error {
+						return composite.Apply(ctx)
+					},
+				})
+			},
+		),
 	}
 	return cs
 }
 
+// WithSequentialExecution applies the components of the Composite one at a time, in dependency order,
+// instead of applying independent components concurrently.
+func WithSequentialExecution() CompositeSetOpt {
+	return func(cs *CompositeSet) {
+		cs.sequential = true
+	}
+}
+
 func (c *CompositeSet) Apply(ctx context.Context, ctors ...any) (port.Component, error) {
+	// The components share a lock serializing their JSII calls, see Composite.Apply.
+	ctx, unlock := internal.ContextWithJSIILock(ctx)
+	defer unlock()
+
 	chart := NewChartFactory(ctx)().NewChart(newConstructorRefs(ctors...))
 	ctx = internal.ContextWithConstruct(ctx, chart)
 	ctors = append(
 		[]any{
 			newChartContext(ctx),
 			NewChartFactory(ctx),
+			newBuiltin("sdk.composite.builtin.validator", internal.ValidatorFromContext(ctx)),
+			newBuiltin("sdk.composite.builtin.params", ParamValues(internal.ParamsFromContext(ctx))),
 		},
 		ctors...,
 	)
 
+	var composite *Composite
 	opts := append(
-		[]fx.Option{registerComponents(ctors...)},
+		[]fx.Option{registerComponents(ctors...), fx.Populate(&composite)},
 		c.fxOpts...,
 	)
 
 	app := fx.New(opts...)
 	return dry.Wrap2(
-		CompositeResult{Component: chart},
+		CompositeResult{Component: chart, composite: composite},
 		dry.FirstError(
 			dry.Wrapf(app.Start(ctx), "starting composite application"),
 			dry.Wrapf(app.Stop(ctx), "cleaning up composite application"),
@@ -149,18 +283,29 @@ func (c *CompositeSet) Apply(ctx context.Context, ctors ...any) (port.Component,
 
 // Apply is the main entry point for the Composite pattern. This is executed by the Fx application lifecycle when
 // CompositeSet.Apply is called.
-func (c *Composite) Apply(context.Context) error {
+//
+// Components are applied level by level, independent components of a level concurrently, unless the Composite
+// is sequential. JSII calls are not safe to make concurrently, so a component only runs while it holds the JSII
+// lock of the context. Components release it while they run binaries, e.g. `helm template`, see
+// internal.WithoutJSIILock, which is where applying components concurrently saves time.
+func (c *Composite) Apply(ctx context.Context) error {
 	graph, err := c.dependencyGraph()
 	if err != nil {
 		return fmt.Errorf("building dependency graph: %w", err)
 	}
-	return c.executeGraph(graph)
+	if c.sequential {
+		return c.executeGraph(graph)
+	}
+	ctx, unlock := internal.ContextWithJSIILock(ctx)
+	defer unlock()
+	return c.executeLevels(ctx, graph)
 }
 
 func registerComponents(components ...any) fx.Option {
 	c := &Composite{
 		results:      make(map[reflect.Type]any),
 		sliceResults: make(map[reflect.Type][]any),
+		namedResults: make(map[string]any),
 	}
 	// Set the executor to the composite itself by default
 	c.executor = c
@@ -200,6 +345,10 @@ func Components(ctors ...any) iter.Seq2[*AutoComponent, error] {
 	}
 	return func(yield func(*AutoComponent, error) bool) {
 		for idx, ctor := range ctors {
+			var qualifier string
+			if named, ok := ctor.(NamedConstructor); ok {
+				qualifier, ctor = named.name, named.ctor
+			}
 			autoComp, err := dry.FirstError2(
 				func() (*AutoComponent, error) {
 					return nil, isCallable(ctor)
@@ -208,6 +357,9 @@ func Components(ctors ...any) iter.Seq2[*AutoComponent, error] {
 					return constructor(ctor, nameFn(idx)).Analyze()
 				},
 			)
+			if autoComp != nil {
+				autoComp.qualifier = qualifier
+			}
 			if !yield(autoComp, err) {
 				return
 			}
@@ -215,11 +367,58 @@ func Components(ctors ...any) iter.Seq2[*AutoComponent, error] {
 	}
 }
 
-// isCallable checks if the provided argument is a callable function with no required parameters.
+// Named registers the constructor of a component with a qualifier. Consumers receive its result, rather
+// than the result of another component of the same type, by asking for the qualifier with a field of
+// an In struct, e.g. `name:"registry-5001"`. Qualifiers must be unique within a Composite.
+func Named(name string, ctor any) NamedConstructor {
+	return NamedConstructor{name: name, ctor: ctor}
+}
+
+// Outputs returns the values of type T produced by the components of the Composite that c is the result of, in
+// the order in which the components were registered. If T is an interface, the values of every type implementing
+// it are returned, grouped by type. It returns nil if c is not a CompositeResult.
+func Outputs[T any](c port.Component) []T {
+	r, ok := c.(CompositeResult)
+	if !ok || r.composite == nil {
+		return nil
+	}
+	r.composite.mu.RLock()
+	defer r.composite.mu.RUnlock()
+
+	// The results of other types are ordered by type, so that interfaces are returned in the same order every time.
+	t := reflect.TypeFor[T]()
+	types := slices.SortedFunc(maps.Keys(r.composite.sliceResults), func(a, b reflect.Type) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	var outputs []T
+	for _, typ := range types {
+		if typ != t && !implements(typ, t) {
+			continue
+		}
+		for _, v := range r.composite.sliceResults[typ] {
+			if out, ok := v.(T); ok {
+				outputs = append(outputs, out)
+			}
+		}
+	}
+	return outputs
+}
+
+// Get returns the provided value, and whether a value was provided.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Ok
+}
+
+func (Optional[T]) optionalType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// isCallable checks if the provided argument is a callable function returning a component, and optionally an error.
 //
 // Examples of valid constructors:
 //   - func() *MyComponent
 //   - func() (*MyComponent, error)
+//   - func(ctx context.Context, res *DockerResults) (*MyComponent, error) // parameters are injected, see Constructor
 //   - func() func(v any) func() *MyComponent // closure with no required parameters
 //   - func() func(v any) func() (*MyComponent, error) // closure with no required parameters
 func isCallable(ctor any) error {
@@ -233,29 +432,51 @@ func isCallable(ctor any) error {
 	if ctorType.Kind() != reflect.Func {
 		return fmt.Errorf("cannot register component of type %T, component must be a callable function", ctor)
 	}
-	if ctorType.NumIn() > 0 {
-		return fmt.Errorf("cannot register component of type %T with non-zero required arguments (is the component a closure?)", ctor)
+	if n := ctorType.NumOut(); n == 0 || n > 2 || n == 2 && ctorType.Out(1) != _errorType {
+		return fmt.Errorf("cannot register component of type %T, constructor must return the component and optionally an error", ctor)
 	}
 	return nil
 }
 
-// constructor returns a new Constructor by resolving the constructor function.
+// constructor returns a new Constructor by resolving the constructor function. Constructors without parameters
+// are called right away, constructors with parameters are called when the component is applied, see construct.
 func constructor(v any, namerFn func(v string) string) *Constructor {
 	if v == nil {
 		return nil
 	}
-	// Call the constructor to get the component instance.
 	vValue := reflect.ValueOf(v)
-	results := vValue.Call(nil)
-	component := results[0].Interface()
-	// TODO(polds): Handle error return value if it exists.
-	cType := reflect.TypeOf(component)
+	c := &Constructor{
+		namer: namerFn,
+		cType: vValue.Type().Out(0),
+		ctor:  vValue,
+	}
+	if vValue.Type().NumIn() > 0 {
+		return c
+	}
 
-	return &Constructor{
-		component: component,
-		namer:     namerFn,
-		cType:     cType,
+	// Call the constructor to get the component instance.
+	c.component, c.err = callConstructor(vValue, nil)
+	if c.err == nil {
+		c.cType = reflect.TypeOf(c.component)
 	}
+	return c
+}
+
+// callConstructor calls the constructor with the given arguments, returning the component it creates, or the
+// error it returns.
+func callConstructor(ctor reflect.Value, args []reflect.Value) (any, error) {
+	var results []reflect.Value
+	if ctor.Type().IsVariadic() {
+		results = ctor.CallSlice(args)
+	} else {
+		results = ctor.Call(args)
+	}
+	if len(results) > 1 {
+		if err, ok := results[1].Interface().(error); ok && err != nil {
+			return nil, err
+		}
+	}
+	return results[0].Interface(), nil
 }
 
 // Name returns a human-readable name for the component.
@@ -272,6 +493,11 @@ func (c *Constructor) Name() string {
 			return name
 		}
 	}
+	// The component is not created yet, so the name is derived from the constructor, e.g.
+	// "kitchensink.NewRegistryMapper@*kitchensink.RegistryMapper".
+	if c.component == nil && c.ctor.IsValid() {
+		return c.namer(fmt.Sprintf("%s@%s", path.Base(runtime.FuncForPC(c.ctor.Pointer()).Name()), c.cType))
+	}
 
 	// If the component implements fmt.Stringer, use its String() method to get a name.
 	if v, ok := c.component.(fmt.Stringer); ok {
@@ -305,6 +531,14 @@ func (c *Constructor) Analyze() (*AutoComponent, error) {
 		return nil, errors.New("cannot analyze nil component")
 	}
 	name := c.Name()
+	if c.err != nil {
+		return nil, fmt.Errorf("constructing component %q: %w", name, c.err)
+	}
+	deferred := c.ctor.IsValid() && c.ctor.Type().NumIn() > 0
+	if deferred && c.cType.Kind() == reflect.Interface {
+		// The method of an interface type has no receiver, so it cannot be called on the component created later.
+		return nil, fmt.Errorf("cannot register component %q, a constructor with parameters must return a concrete type, not %s", name, c.cType)
+	}
 	applyMethod, exists := c.cType.MethodByName("Apply")
 	if !exists {
 		return nil, fmt.Errorf("cannot register component %q, does not implement Composite, missing Apply method", name)
@@ -315,6 +549,9 @@ func (c *Constructor) Analyze() (*AutoComponent, error) {
 		name:        name,
 		applyMethod: applyMethod,
 	}
+	if deferred {
+		auto.ctor = c.ctor
+	}
 
 	// Analyze what the Apply method produces (return type).
 	methodType := applyMethod.Type
@@ -348,16 +585,53 @@ func (c *Constructor) Analyze() (*AutoComponent, error) {
 // which would be confusing as only the last produced item would be used.
 func (c *Composite) dependencyGraph() (map[string][]string, error) {
 	edges := make(map[string][]string)
+	type namedProduct struct {
+		component *AutoComponent
+		typ       reflect.Type
+	}
+	named := make(map[string]namedProduct)
 
 	// Initialize edges
 	for i := range c.components {
 		edges[c.components[i].name] = []string{}
+		if c.components[i].qualifier != "" && isOutStruct(c.components[i].produces) {
+			return nil, fmt.Errorf("component %q returns %s, which embeds Out, so it cannot be registered with qualifier %q; "+
+				"qualify its fields with name tags instead", c.components[i].name, c.components[i].produces, c.components[i].qualifier)
+		}
+		for _, p := range c.components[i].products() {
+			if p.name == "" {
+				continue
+			}
+			if other, exists := named[p.name]; exists {
+				return nil, fmt.Errorf("components %q and %q are both registered with qualifier %q", other.component.name, c.components[i].name, p.name)
+			}
+			named[p.name] = namedProduct{component: c.components[i], typ: p.typ}
+		}
 	}
 
 	// Build dependency edges and validate single vs multiple producer scenarios
 	for i := range c.components {
 		consumer := c.components[i] // Capture range variable
-		for _, needsType := range consumer.consumes {
+		for _, dep := range consumer.dependencies() {
+			needsType, optional := optionalElem(dep.typ)
+			// Handle qualified types - need the producer registered with the qualifier
+			if dep.name != "" {
+				producer, exists := named[dep.name]
+				if !exists {
+					if optional {
+						continue
+					}
+					return nil, fmt.Errorf("component %q consumes %s named %q but no component is registered with that qualifier",
+						consumer.name, needsType, dep.name)
+				}
+				if !producer.typ.AssignableTo(needsType) {
+					return nil, fmt.Errorf("component %q consumes %s named %q but %q produces %v",
+						consumer.name, needsType, dep.name, producer.component.name, producer.typ)
+				}
+				edges[consumer.name] = append(edges[consumer.name], producer.component.name)
+				continue
+			}
+
 			// Handle slice types - need all producers of element type
 			if needsType.Kind() == reflect.Slice {
 				elemType := needsType.Elem()
@@ -366,7 +640,7 @@ func (c *Composite) dependencyGraph() (map[string][]string, error) {
 					if producer.name == consumer.name {
 						continue
 					}
-					if producer.produces == elemType {
+					if producer.provides(elemType) {
 						edges[consumer.name] = append(edges[consumer.name], producer.name)
 						// TODO(COP-1232): Use a logger.
 						// fmt.Printf("Dependency: %s needs []%s (collects from %s)\n",
@@ -383,9 +657,13 @@ func (c *Composite) dependencyGraph() (map[string][]string, error) {
 				if producer.name == consumer.name {
 					continue
 				}
-				if producer.produces == needsType {
+				switch {
+				case producer.producesType(needsType):
 					matchingProducers = append(matchingProducers, producer.name)
 					edges[consumer.name] = append(edges[consumer.name], producer.name)
+				case producer.provides(needsType):
+					// Interfaces are provided by any of their implementations, see valueForType.
+					edges[consumer.name] = append(edges[consumer.name], producer.name)
 				}
 			}
 
@@ -394,7 +672,8 @@ func (c *Composite) dependencyGraph() (map[string][]string, error) {
 				return nil, fmt.Errorf(
 					"component %q consumes single %s but multiple producers exist: %v. "+
 						"This is confusing because only the last produced item will be used. "+
-						"Consider changing %q to consume []%s to collect all results",
+						"Consider changing %q to consume []%s to collect all results, "+
+						"or registering the producers with crib.Named to consume one of them by its qualifier",
 					consumer.name, needsType, matchingProducers, consumer.name, needsType)
 			}
 
@@ -410,6 +689,11 @@ func (c *Composite) dependencyGraph() (map[string][]string, error) {
 	return edges, nil
 }
 
+// implements reports whether a component producing the given type provides the interface t to its consumers.
+func implements(produces, t reflect.Type) bool {
+	return produces != nil && t.Kind() == reflect.Interface && produces != t && produces.Implements(t)
+}
+
 // executeGraph runs all components in the Composite in the correct order based on their dependencies.
 func (c *Composite) executeGraph(edges map[string][]string) error {
 	executed := make(map[string]bool)
@@ -467,9 +751,106 @@ func (c *Composite) executeGraph(edges map[string][]string) error {
 	return nil
 }
 
+// graphLevels groups the components into levels, so that every component is in a later level than the
+// components it depends on. The components of a level are independent of each other, and keep the order
+// in which they were registered.
+func (c *Composite) graphLevels(edges map[string][]string) ([][]*AutoComponent, error) {
+	componentMap := make(map[string]*AutoComponent)
+	for _, comp := range c.components {
+		componentMap[comp.name] = comp
+	}
+
+	levelOf := make(map[string]int)
+	visiting := make(map[string]bool)
+	var visit func(string) (int, error)
+	visit = func(name string) (int, error) {
+		if level, ok := levelOf[name]; ok {
+			return level, nil
+		}
+		if visiting[name] {
+			return 0, fmt.Errorf("circular dependency detected involving %s", name)
+		}
+		visiting[name] = true
+
+		var level int
+		for _, depName := range edges[name] {
+			if _, exists := componentMap[depName]; !exists {
+				continue
+			}
+			depLevel, err := visit(depName)
+			if err != nil {
+				return 0, err
+			}
+			level = max(level, depLevel+1)
+		}
+
+		visiting[name] = false
+		levelOf[name] = level
+		return level, nil
+	}
+
+	var levels [][]*AutoComponent
+	for _, comp := range c.components {
+		level, err := visit(comp.name)
+		if err != nil {
+			return nil, err
+		}
+		for len(levels) <= level {
+			levels = append(levels, nil)
+		}
+		levels[level] = append(levels[level], comp)
+	}
+	return levels, nil
+}
+
+// executeLevels runs all components in the Composite level by level, see graphLevels. The components of a
+// level run concurrently, each holding the JSII lock of the context while it runs. The caller must hold the lock.
+func (c *Composite) executeLevels(ctx context.Context, edges map[string][]string) error {
+	levels, err := c.graphLevels(edges)
+	if err != nil {
+		return err
+	}
+
+	jsii := internal.JSIILockFromContext(ctx)
+	for _, level := range levels {
+		if len(level) == 1 {
+			if err := c.executor.ExecuteComponent(level[0]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		internal.WithoutJSIILock(ctx, func() {
+			p := pool.New().WithErrors()
+			for _, comp := range level {
+				// The lock is acquired for each component in turn, so that the components start in the order in
+				// which they were registered and their first constructs are always created in the same order.
+				jsii.Lock()
+				p.Go(func() error {
+					defer jsii.Unlock()
+					return c.executor.ExecuteComponent(comp)
+				})
+			}
+			err = p.Wait()
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ExecuteComponent implements the ComponentExecutor interface and is the default implementation to
 // execute components.
 func (c *Composite) ExecuteComponent(comp *AutoComponent) error {
+	if comp.component == nil && comp.ctor.IsValid() {
+		component, err := c.construct(comp)
+		if err != nil {
+			return fmt.Errorf("constructing component %s: %w", comp.name, err)
+		}
+		comp.component = component
+	}
+
 	// Prepare arguments for Run method
 	methodType := comp.applyMethod.Type
 	args := []reflect.Value{reflect.ValueOf(comp.component)} // receiver
@@ -499,19 +880,21 @@ func (c *Composite) ExecuteComponent(comp *AutoComponent) error {
 	// Store result if component produces something
 	if len(results) > 0 && comp.produces != nil {
 		// Note: Possible bug / confusing user experience.
-		// If the component produces multiple values, we only store the first one.
+		// If the component produces multiple values, we only store the first one, unless it is an Out struct.
 		// If, for example, a component author returns 'err, bool' instead of 'bool, error'
 		// then a nil error will be stored and the user will not know that the component produced an error.
-		result := results[0].Interface()
-
 		c.mu.Lock()
-		c.results[comp.produces] = result
-
-		// Also add to slice collection for slice consumers
-		if c.sliceResults[comp.produces] == nil {
-			c.sliceResults[comp.produces] = []any{}
+		idx := slices.Index(c.components, comp)
+		if idx < 0 {
+			idx = len(c.components)
+		}
+		for _, p := range comp.products() {
+			result := results[0]
+			if p.field >= 0 {
+				result = result.Field(p.field)
+			}
+			c.store(idx, p, result.Interface())
 		}
-		c.sliceResults[comp.produces] = append(c.sliceResults[comp.produces], result)
 		c.mu.Unlock()
 
 		// TODO(COP-1232): Use a logger.
@@ -521,11 +904,62 @@ func (c *Composite) ExecuteComponent(comp *AutoComponent) error {
 	return nil
 }
 
+// store stores a value produced by the component registered at index idx. The caller must hold the lock.
+func (c *Composite) store(idx int, p product, result any) {
+	c.results[p.typ] = result
+
+	// Also add to slice collection for slice consumers. Results are kept in the order in which the components
+	// were registered rather than the order in which they finished, as components may run concurrently.
+	if c.sliceOrder == nil {
+		c.sliceOrder = make(map[reflect.Type][]int)
+	}
+	order := c.sliceOrder[p.typ]
+	later := len(order) - sort.SearchInts(order, idx+1)
+	c.sliceOrder[p.typ] = slices.Insert(order, len(order)-later, idx)
+	c.sliceResults[p.typ] = slices.Insert(c.sliceResults[p.typ], len(c.sliceResults[p.typ])-later, result)
+	if p.name != "" {
+		if c.namedResults == nil {
+			c.namedResults = make(map[string]any)
+		}
+		c.namedResults[p.name] = result
+	}
+}
+
+// construct creates the component of a constructor with parameters, providing each of its parameters like the
+// parameters of an Apply method.
+func (c *Composite) construct(comp *AutoComponent) (any, error) {
+	ctorType := comp.ctor.Type()
+	args := make([]reflect.Value, 0, ctorType.NumIn())
+	for i := range ctorType.NumIn() {
+		paramValue, err := c.valueForType(ctorType.In(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to provide params: %w", err)
+		}
+		args = append(args, paramValue)
+	}
+	return callConstructor(comp.ctor, args)
+}
+
 func (c *Composite) valueForType(paramType reflect.Type) (reflect.Value, error) {
+	if isInStruct(paramType) {
+		return c.valueForIn(paramType)
+	}
+	if elemType, ok := optionalElem(paramType); ok {
+		// A missing optional dependency is left empty.
+		value, err := c.valueForType(elemType)
+		return newOptional(paramType, value, err == nil), nil
+	}
 	switch paramType.Kind() {
 	case reflect.Interface:
 		implementations := c.findImplementations(paramType)
 		if len(implementations) == 0 {
+			// An interface produced as is, e.g. by a field of an Out struct, may have been left nil.
+			c.mu.RLock()
+			value, exists := c.results[paramType]
+			c.mu.RUnlock()
+			if exists {
+				return resultValue(paramType, value), nil
+			}
 			return _voidValue, fmt.Errorf("missing dependency %s (no concrete type found that implements this interface)", paramType)
 		}
 		return implementations[0], nil
@@ -544,8 +978,159 @@ func (c *Composite) valueForType(paramType reflect.Type) (reflect.Value, error)
 		if !exists {
 			return _voidValue, fmt.Errorf("no registered component provides missing dependency %s", paramType)
 		}
-		return reflect.ValueOf(value), nil
+		return resultValue(paramType, value), nil
+	}
+}
+
+// resultValue returns the stored result as a value of type t. Results that are nil interfaces, e.g. an
+// interface field of an Out struct that was left nil, are the zero value of t.
+func resultValue(t reflect.Type, result any) reflect.Value {
+	if result == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(result)
+}
+
+// valueForIn creates an In struct of the given type, providing each of its dependencies.
+func (c *Composite) valueForIn(inType reflect.Type) (reflect.Value, error) {
+	in := reflect.New(inType).Elem()
+	for _, dep := range inDependencies(inType) {
+		if dep.name == "" {
+			value, err := c.valueForType(dep.typ)
+			if err != nil {
+				return _voidValue, err
+			}
+			in.Field(dep.field).Set(value)
+			continue
+		}
+
+		c.mu.RLock()
+		value, exists := c.namedResults[dep.name]
+		c.mu.RUnlock()
+		if _, ok := optionalElem(dep.typ); ok {
+			elem, _ := optionalElem(dep.typ)
+			in.Field(dep.field).Set(newOptional(dep.typ, resultValue(elem, value), exists))
+			continue
+		}
+		if !exists {
+			return _voidValue, fmt.Errorf("no registered component named %q provides missing dependency %s", dep.name, dep.typ)
+		}
+		in.Field(dep.field).Set(resultValue(dep.typ, value))
+	}
+	return in, nil
+}
+
+// dependencies returns the values that the component consumes. Parameters of its constructor and its Apply
+// method are consumed as a whole, except for In structs whose fields are consumed one by one.
+func (a *AutoComponent) dependencies() []dependency {
+	var deps []dependency
+	params := slices.Clone(a.consumes)
+	if a.ctor.IsValid() {
+		for i := range a.ctor.Type().NumIn() {
+			params = append(params, a.ctor.Type().In(i))
+		}
+	}
+	for _, paramType := range params {
+		if isInStruct(paramType) {
+			deps = append(deps, inDependencies(paramType)...)
+			continue
+		}
+		deps = append(deps, dependency{typ: paramType})
+	}
+	return deps
+}
+
+// products returns the values that the component produces. The value returned by its Apply method is
+// produced as a whole, with the qualifier of the component, except for Out structs whose fields are
+// produced one by one, with the qualifiers of their name tags.
+func (a *AutoComponent) products() []product {
+	if a.produces == nil {
+		return nil
+	}
+	if !isOutStruct(a.produces) {
+		return []product{{typ: a.produces, name: a.qualifier, field: -1}}
+	}
+	var products []product
+	for i := range a.produces.NumField() {
+		f := a.produces.Field(i)
+		if !f.IsExported() || f.Type == _outType {
+			continue
+		}
+		products = append(products, product{typ: f.Type, name: f.Tag.Get("name"), field: i})
+	}
+	return products
+}
+
+// producesType reports whether the component produces a value of exactly type t.
+func (a *AutoComponent) producesType(t reflect.Type) bool {
+	return slices.ContainsFunc(a.products(), func(p product) bool {
+		return p.typ == t
+	})
+}
+
+// provides reports whether the component produces a value of type t, or a value implementing the interface t.
+func (a *AutoComponent) provides(t reflect.Type) bool {
+	return slices.ContainsFunc(a.products(), func(p product) bool {
+		return p.typ == t || implements(p.typ, t)
+	})
+}
+
+// isInStruct reports whether t is a struct that embeds In.
+func isInStruct(t reflect.Type) bool {
+	return embeds(t, _inType)
+}
+
+// isOutStruct reports whether t is a struct that embeds Out.
+func isOutStruct(t reflect.Type) bool {
+	return embeds(t, _outType)
+}
+
+// embeds reports whether t is a struct that embeds the marker type.
+func embeds(t, marker reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := range t.NumField() {
+		if f := t.Field(i); f.Anonymous && f.Type == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalElem returns the type of the value held by t if t is an Optional, or t itself otherwise.
+func optionalElem(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct {
+		return t, false
+	}
+	opt, ok := reflect.Zero(t).Interface().(optional)
+	if !ok {
+		return t, false
+	}
+	return opt.optionalType(), true
+}
+
+// newOptional returns an Optional of type t holding value if ok, or an empty one otherwise.
+func newOptional(t reflect.Type, value reflect.Value, ok bool) reflect.Value {
+	opt := reflect.New(t).Elem()
+	if ok && value.IsValid() {
+		opt.FieldByName("Value").Set(value)
+		opt.FieldByName("Ok").SetBool(true)
+	}
+	return opt
+}
+
+// inDependencies returns the dependencies of the exported fields of the In struct t, in field order.
+func inDependencies(t reflect.Type) []dependency {
+	var deps []dependency
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() || f.Type == _inType {
+			continue
+		}
+		deps = append(deps, dependency{typ: f.Type, name: f.Tag.Get("name"), field: i})
 	}
+	return deps
 }
 
 // findImplementations finds all instances that satisfy the given type.
@@ -581,10 +1166,11 @@ func (c *Composite) findImplementations(targetType reflect.Type) []reflect.Value
 // findInterfaceImplementations searches both results and sliceResults for interface implementations.
 func (c *Composite) findInterfaceImplementations(targetType reflect.Type, implementations *[]reflect.Value, seen map[string]bool) {
 	// Search main results map
-	for _, resultValue := range c.results {
-		if reflect.TypeOf(resultValue).Implements(targetType) {
-			val := reflect.ValueOf(resultValue)
-			key := c.createUniqueKey(resultValue)
+	for _, result := range c.results {
+		// Nil interfaces implement nothing.
+		if result != nil && reflect.TypeOf(result).Implements(targetType) {
+			val := reflect.ValueOf(result)
+			key := c.createUniqueKey(result)
 			if !seen[key] {
 				*implementations = append(*implementations, val)
 				seen[key] = true
@@ -595,7 +1181,7 @@ func (c *Composite) findInterfaceImplementations(targetType reflect.Type, implem
 	// Search slice results map
 	for _, sliceInstances := range c.sliceResults {
 		for _, instance := range sliceInstances {
-			if reflect.TypeOf(instance).Implements(targetType) {
+			if instance != nil && reflect.TypeOf(instance).Implements(targetType) {
 				val := reflect.ValueOf(instance)
 				key := c.createUniqueKey(instance)
 				if !seen[key] {
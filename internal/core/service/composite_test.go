@@ -7,13 +7,17 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
 
+	"github.com/smartcontractkit/crib-sdk/internal"
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/infra"
 	"github.com/smartcontractkit/crib-sdk/internal/core/port"
 )
@@ -340,10 +344,6 @@ func Test_analyzeConstructor_PanicCases(t *testing.T) {
 			desc:        "not a valid constructor",
 			constructor: "foo bar",
 		},
-		{
-			desc:        "function with parameters",
-			constructor: func(string) *SimpleComponent { return &SimpleComponent{} },
-		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -546,10 +546,26 @@ func Test_isCallable(t *testing.T) {
 			errContains: "of type *service.compositeNoImpl",
 		},
 		{
-			desc:        "function has arguments",
-			input:       func(int, string) *compositeImpl { return newCompositeImpl() },
+			desc:    "function has arguments",
+			input:   func(int, string) *compositeImpl { return newCompositeImpl() },
+			wantErr: assert.NoError,
+		},
+		{
+			desc:    "function returns an error",
+			input:   func() (*compositeImpl, error) { return newCompositeImpl(), nil },
+			wantErr: assert.NoError,
+		},
+		{
+			desc:        "function returns nothing",
+			input:       func(int) {},
 			wantErr:     assert.Error,
-			errContains: "non-zero required arguments",
+			errContains: "constructor must return the component and optionally an error",
+		},
+		{
+			desc:        "function returns a second value that is not an error",
+			input:       func() (*compositeImpl, bool) { return newCompositeImpl(), true },
+			wantErr:     assert.Error,
+			errContains: "constructor must return the component and optionally an error",
 		},
 	}
 
@@ -2955,3 +2971,567 @@ func Test_newConstructorRefs_Coverage(t *testing.T) {
 		assert.NoError(t, refs.Validate(t.Context()))
 	})
 }
+
+// Test types for qualifier tests
+
+// Consumer that asks for the SharedData of the producer registered as "b"
+type QualifiedConsumer struct {
+	got *SharedData
+}
+
+type QualifiedParams struct {
+	In
+	Data *SharedData `name:"b"`
+	All  []*SharedData
+}
+
+func (q *QualifiedConsumer) Apply(params QualifiedParams) int {
+	q.got = params.Data
+	return len(params.All)
+}
+
+func (q *QualifiedConsumer) String() string {
+	return "QualifiedConsumer"
+}
+
+func mustAnalyzeNamed(name string, ctor any) *AutoComponent {
+	component := mustAnalyzeConstructor(ctor)
+	component.qualifier = name
+	return component
+}
+
+func Test_Composite_dependencyGraph_Qualifiers(t *testing.T) {
+	t.Parallel()
+
+	consumer := &QualifiedConsumer{}
+	newConsumer := func() *QualifiedConsumer { return consumer }
+
+	t.Run("wires edges by type and qualifier", func(t *testing.T) {
+		t.Parallel()
+
+		composite := &Composite{
+			components: []*AutoComponent{
+				mustAnalyzeNamed("a", NewProducerA),
+				mustAnalyzeNamed("b", NewProducerB),
+				mustAnalyzeConstructor(newConsumer),
+			},
+		}
+		edges, err := composite.dependencyGraph()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ProducerB", "ProducerA", "ProducerB"}, edges["QualifiedConsumer"])
+	})
+
+	tests := []struct {
+		name        string
+		components  []*AutoComponent
+		errContains string
+	}{
+		{
+			name: "duplicate qualifier",
+			components: []*AutoComponent{
+				mustAnalyzeNamed("b", NewProducerA),
+				mustAnalyzeNamed("b", NewProducerB),
+			},
+			errContains: `components "ProducerA" and "ProducerB" are both registered with qualifier "b"`,
+		},
+		{
+			name: "unknown qualifier",
+			components: []*AutoComponent{
+				mustAnalyzeNamed("a", NewProducerA),
+				mustAnalyzeConstructor(newConsumer),
+			},
+			errContains: `component "QualifiedConsumer" consumes *service.SharedData named "b" but no component is registered with that qualifier`,
+		},
+		{
+			name: "qualified producer of another type",
+			components: []*AutoComponent{
+				mustAnalyzeNamed("b", NewNoDepsConsumer),
+				mustAnalyzeConstructor(newConsumer),
+			},
+			errContains: `component "QualifiedConsumer" consumes *service.SharedData named "b" but "NoDepsConsumer" produces error`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			composite := &Composite{components: tc.components}
+			_, err := composite.dependencyGraph()
+			assert.ErrorContains(t, err, tc.errContains)
+		})
+	}
+}
+
+func Test_Composite_Qualifiers_Integration(t *testing.T) {
+	t.Parallel()
+
+	consumer := &QualifiedConsumer{}
+	composite := &Composite{
+		results:      make(map[reflect.Type]any),
+		sliceResults: make(map[reflect.Type][]any),
+		namedResults: make(map[string]any),
+	}
+	composite.executor = composite
+	for component, err := range Components(Named("a", NewProducerA), Named("b", NewProducerB), func() *QualifiedConsumer { return consumer }) {
+		require.NoError(t, err)
+		composite.components = append(composite.components, component)
+	}
+
+	require.NoError(t, composite.Apply(t.Context()))
+	require.NotNil(t, consumer.got)
+	assert.Equal(t, "from B", consumer.got.Value)
+	assert.Equal(t, 2, composite.results[reflect.TypeFor[int]()], "unqualified consumers still collect every producer")
+	assert.Contains(t, composite.namedResults, "a")
+}
+
+func Test_Composite_graphLevels(t *testing.T) {
+	t.Parallel()
+
+	composite := &Composite{
+		components: []*AutoComponent{
+			mustAnalyzeConstructor(NewNoDepsConsumer),
+			mustAnalyzeConstructor(NewSliceConsumer),
+			mustAnalyzeConstructor(NewProducerB),
+			mustAnalyzeConstructor(NewSingleConsumer),
+			mustAnalyzeConstructor(NewProducerA),
+		},
+	}
+	levels, err := composite.graphLevels(map[string][]string{
+		"NoDepsConsumer": {"SingleConsumer"},
+		"SingleConsumer": {"ProducerA"},
+		"SliceConsumer":  {"ProducerA", "ProducerB", "Unknown"},
+	})
+	require.NoError(t, err)
+	names := lo.Map(levels, func(level []*AutoComponent, _ int) []string {
+		return lo.Map(level, func(comp *AutoComponent, _ int) string { return comp.name })
+	})
+	assert.Equal(t, [][]string{
+		{"ProducerB", "ProducerA"},
+		{"SliceConsumer", "SingleConsumer"},
+		{"NoDepsConsumer"},
+	}, names, "components keep the order in which they were registered within their level")
+
+	_, err = composite.graphLevels(map[string][]string{
+		"ProducerA": {"ProducerB"},
+		"ProducerB": {"ProducerA"},
+	})
+	assert.ErrorContains(t, err, "circular dependency detected")
+}
+
+// barrierExecutor executes components that wait for each other while they hold no JSII lock, like components
+// running `helm template`. It only completes if the components run concurrently.
+type barrierExecutor struct {
+	ctx     context.Context
+	barrier sync.WaitGroup
+	locked  atomic.Int32
+}
+
+func (b *barrierExecutor) ExecuteComponent(*AutoComponent) error {
+	// holdsLock fails if another component holds the JSII lock at the same time.
+	holdsLock := func() error {
+		defer b.locked.Add(-1)
+		if b.locked.Add(1) > 1 {
+			return errors.New("components hold the JSII lock at the same time")
+		}
+		return nil
+	}
+	if err := holdsLock(); err != nil {
+		return err
+	}
+
+	var err error
+	internal.WithoutJSIILock(b.ctx, func() {
+		b.barrier.Done()
+		done := make(chan struct{})
+		go func() {
+			b.barrier.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			err = errors.New("components did not run concurrently")
+		}
+	})
+	return errors.Join(err, holdsLock())
+}
+
+func Test_Composite_executeLevels(t *testing.T) {
+	t.Parallel()
+
+	ctx, unlock := internal.ContextWithJSIILock(t.Context())
+	defer unlock()
+
+	executor := &barrierExecutor{ctx: ctx}
+	executor.barrier.Add(2)
+	composite := &Composite{
+		components: []*AutoComponent{
+			mustAnalyzeConstructor(NewProducerA),
+			mustAnalyzeConstructor(NewProducerB),
+		},
+		executor: executor,
+	}
+	require.NoError(t, composite.executeLevels(ctx, map[string][]string{}))
+	assert.False(t, internal.JSIILockFromContext(ctx).TryLock(), "the lock is held by the caller again")
+}
+
+func Test_Composite_Apply_Sequential(t *testing.T) {
+	t.Parallel()
+
+	mockExecutor := NewMockComponentExecutor()
+	composite := &Composite{
+		components: []*AutoComponent{
+			mustAnalyzeConstructor(NewSliceConsumer),
+			mustAnalyzeConstructor(NewProducerA),
+			mustAnalyzeConstructor(NewProducerB),
+		},
+		executor:   mockExecutor,
+		sequential: true,
+	}
+	require.NoError(t, composite.Apply(t.Context()))
+	assert.Equal(t, []string{"ProducerA", "ProducerB", "SliceConsumer"}, mockExecutor.GetExecutionOrder())
+}
+
+// Test types for constructor injection tests
+
+// Consumer whose constructor consumes the SharedData of the producer registered as "a"
+type InjectedConsumer struct {
+	data   *SharedData
+	params ParamValues
+}
+
+type InjectedParams struct {
+	In
+	Data   *SharedData `name:"a"`
+	Params ParamValues
+}
+
+func NewInjectedConsumer(params InjectedParams) (*InjectedConsumer, error) {
+	if params.Data == nil {
+		return nil, errors.New("no data")
+	}
+	return &InjectedConsumer{data: params.Data, params: params.Params}, nil
+}
+
+func (i *InjectedConsumer) Apply() string {
+	return i.data.Value
+}
+
+func Test_constructor_Injection(t *testing.T) {
+	t.Parallel()
+
+	t.Run("constructor with parameters is analyzed without being called", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := constructor(NewInjectedConsumer, nil).Analyze()
+		require.NoError(t, err)
+		assert.Nil(t, got.component)
+		assert.Equal(t, "service.NewInjectedConsumer@*service.InjectedConsumer", got.name)
+		assert.Equal(t, reflect.TypeFor[string](), got.produces)
+		assert.Equal(t, []dependency{
+			{typ: reflect.TypeFor[*SharedData](), name: "a", field: 1},
+			{typ: reflect.TypeFor[ParamValues](), field: 2},
+		}, got.dependencies())
+	})
+
+	t.Run("error of a constructor without parameters", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := constructor(func() (*SimpleComponent, error) { return nil, errors.New("boom") }, nil).Analyze()
+		assert.ErrorContains(t, err, "constructing component")
+		assert.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("constructor with parameters returning an interface", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := constructor(func(context.Context) fmt.Stringer { return &SimpleComponent{} }, nil).Analyze()
+		assert.ErrorContains(t, err, "a constructor with parameters must return a concrete type, not fmt.Stringer")
+	})
+}
+
+func Test_Composite_ConstructorInjection_Integration(t *testing.T) {
+	t.Parallel()
+
+	newComposite := func(t *testing.T, ctors ...any) *Composite {
+		t.Helper()
+		composite := &Composite{
+			results:      make(map[reflect.Type]any),
+			sliceResults: make(map[reflect.Type][]any),
+			namedResults: make(map[string]any),
+		}
+		composite.executor = composite
+		for component, err := range Components(ctors...) {
+			require.NoError(t, err)
+			composite.components = append(composite.components, component)
+		}
+		return composite
+	}
+
+	t.Run("constructor receives upstream results", func(t *testing.T) {
+		t.Parallel()
+
+		params := ParamValues{"replicas": 3}
+		composite := newComposite(t,
+			NewInjectedConsumer,
+			Named("a", NewProducerA),
+			newBuiltin("params", params),
+		)
+		require.NoError(t, composite.Apply(t.Context()))
+		assert.Equal(t, "from A", composite.results[reflect.TypeFor[string]()])
+
+		consumer, ok := composite.components[0].component.(*InjectedConsumer)
+		require.True(t, ok)
+		assert.Equal(t, params, consumer.params)
+	})
+
+	t.Run("constructor error is returned", func(t *testing.T) {
+		t.Parallel()
+
+		composite := newComposite(t,
+			Named("a", func() *ProducerNil { return &ProducerNil{} }),
+			newBuiltin("params", ParamValues{}),
+			NewInjectedConsumer,
+		)
+		err := composite.Apply(t.Context())
+		assert.ErrorContains(t, err, "constructing component 2::service.NewInjectedConsumer@*service.InjectedConsumer: no data")
+	})
+
+	t.Run("missing constructor dependency", func(t *testing.T) {
+		t.Parallel()
+
+		composite := newComposite(t, Named("a", NewProducerA), NewInjectedConsumer)
+		err := composite.Apply(t.Context())
+		assert.ErrorContains(t, err, "no registered component provides missing dependency service.ParamValues")
+	})
+}
+
+// ProducerNil produces a nil SharedData.
+type ProducerNil struct{}
+
+func (p *ProducerNil) Apply() *SharedData {
+	return nil
+}
+
+func (p *ProducerNil) String() string {
+	return "ProducerNil"
+}
+
+func Test_Composite_ExecuteComponent_SliceResultsOrder(t *testing.T) {
+	t.Parallel()
+
+	composite := &Composite{
+		results:      make(map[reflect.Type]any),
+		sliceResults: make(map[reflect.Type][]any),
+		components: []*AutoComponent{
+			mustAnalyzeConstructor(NewProducerA),
+			mustAnalyzeConstructor(NewProducerB),
+			mustAnalyzeConstructor(NewProducerA),
+		},
+	}
+	// The components finish in another order than they were registered in, as they may when run concurrently.
+	for _, i := range []int{1, 2, 0} {
+		require.NoError(t, composite.ExecuteComponent(composite.components[i]))
+	}
+
+	values := lo.Map(composite.sliceResults[reflect.TypeFor[*SharedData]()], func(v any, _ int) string {
+		return v.(*SharedData).Value
+	})
+	assert.Equal(t, []string{"from A", "from B", "from A"}, values)
+}
+
+func Test_Outputs(t *testing.T) {
+	t.Parallel()
+
+	composite := &Composite{
+		results:      make(map[reflect.Type]any),
+		sliceResults: make(map[reflect.Type][]any),
+	}
+	composite.executor = composite
+	for component, err := range Components(NewProducerB, NewProducerA, NewSimpleComponent) {
+		require.NoError(t, err)
+		composite.components = append(composite.components, component)
+	}
+	require.NoError(t, composite.Apply(t.Context()))
+	result := CompositeResult{composite: composite}
+
+	values := lo.Map(Outputs[*SharedData](result), func(v *SharedData, _ int) string {
+		return v.Value
+	})
+	assert.Equal(t, []string{"from B", "from A"}, values)
+	assert.Len(t, Outputs[any](result), 2)
+	assert.Empty(t, Outputs[string](result))
+	assert.Nil(t, Outputs[*SharedData](CompositeResult{}), "a Composite that failed to start has no outputs")
+	assert.Nil(t, Outputs[*SharedData](nil))
+}
+
+// Test types for Optional and Out tests
+
+// OutProducer produces each field of OutResults separately.
+type OutProducer struct{}
+
+type OutResults struct {
+	Out
+	URL   string `name:"url"`
+	Data  *SharedData
+	Count int
+}
+
+func (o *OutProducer) Apply() (OutResults, error) {
+	return OutResults{URL: "http://registry:5000", Data: &SharedData{Value: "from out"}, Count: 2}, nil
+}
+
+func (o *OutProducer) String() string {
+	return "OutProducer"
+}
+
+// OptionalConsumer consumes values of OutProducer, and values that no component produces.
+type OptionalConsumer struct {
+	params  OptionalParams
+	missing Optional[*SimpleComponent]
+}
+
+type OptionalParams struct {
+	In
+	URL          Optional[string] `name:"url"`
+	MissingNamed Optional[string] `name:"missing"`
+	Data         *SharedData
+	Count        Optional[int]
+}
+
+func (o *OptionalConsumer) Apply(params OptionalParams, missing Optional[*SimpleComponent]) {
+	o.params, o.missing = params, missing
+}
+
+func (o *OptionalConsumer) String() string {
+	return "OptionalConsumer"
+}
+
+// NilOutProducer leaves the interface fields of its Out struct nil.
+type NilOutProducer struct{}
+
+type NilOutResults struct {
+	Out
+	Chart port.Component
+	Named port.Component `name:"chart"`
+}
+
+func (n *NilOutProducer) Apply() NilOutResults {
+	return NilOutResults{}
+}
+
+func (n *NilOutProducer) String() string {
+	return "NilOutProducer"
+}
+
+// NilOutConsumer consumes the nil interfaces of NilOutProducer.
+type NilOutConsumer struct {
+	params  NilOutParams
+	chart   port.Component
+	applied bool
+}
+
+type NilOutParams struct {
+	In
+	Chart    port.Component
+	Named    port.Component           `name:"chart"`
+	Optional Optional[port.Component] `name:"chart"`
+}
+
+func (n *NilOutConsumer) Apply(params NilOutParams, chart port.Component) {
+	n.params, n.chart, n.applied = params, chart, true
+}
+
+func (n *NilOutConsumer) String() string {
+	return "NilOutConsumer"
+}
+
+func Test_Composite_OptionalAndOut(t *testing.T) {
+	t.Parallel()
+
+	t.Run("products of an Out struct", func(t *testing.T) {
+		t.Parallel()
+
+		got := mustAnalyzeConstructor(func() *OutProducer { return &OutProducer{} })
+		assert.Equal(t, []product{
+			{typ: reflect.TypeFor[string](), name: "url", field: 1},
+			{typ: reflect.TypeFor[*SharedData](), field: 2},
+			{typ: reflect.TypeFor[int](), field: 3},
+		}, got.products())
+	})
+
+	t.Run("wires edges to the fields of Out structs and ignores missing optionals", func(t *testing.T) {
+		t.Parallel()
+
+		composite := &Composite{
+			components: []*AutoComponent{
+				mustAnalyzeConstructor(func() *OptionalConsumer { return &OptionalConsumer{} }),
+				mustAnalyzeConstructor(func() *OutProducer { return &OutProducer{} }),
+			},
+		}
+		edges, err := composite.dependencyGraph()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"OutProducer", "OutProducer", "OutProducer"}, edges["OptionalConsumer"])
+	})
+
+	t.Run("Out struct registered with a qualifier", func(t *testing.T) {
+		t.Parallel()
+
+		composite := &Composite{
+			components: []*AutoComponent{
+				mustAnalyzeNamed("registry", func() *OutProducer { return &OutProducer{} }),
+			},
+		}
+		_, err := composite.dependencyGraph()
+		assert.ErrorContains(t, err, `component "OutProducer" returns service.OutResults, which embeds Out, so it cannot be registered with qualifier "registry"`)
+	})
+
+	t.Run("integration", func(t *testing.T) {
+		t.Parallel()
+
+		consumer := &OptionalConsumer{}
+		composite := &Composite{
+			results:      make(map[reflect.Type]any),
+			sliceResults: make(map[reflect.Type][]any),
+			namedResults: make(map[string]any),
+		}
+		composite.executor = composite
+		for component, err := range Components(func() *OptionalConsumer { return consumer }, func() *OutProducer { return &OutProducer{} }) {
+			require.NoError(t, err)
+			composite.components = append(composite.components, component)
+		}
+		require.NoError(t, composite.Apply(t.Context()))
+
+		assert.Equal(t, Optional[string]{Value: "http://registry:5000", Ok: true}, consumer.params.URL)
+		assert.Equal(t, Optional[int]{Value: 2, Ok: true}, consumer.params.Count)
+		assert.Equal(t, "from out", consumer.params.Data.Value)
+		assert.Equal(t, Optional[string]{}, consumer.params.MissingNamed)
+		_, ok := consumer.missing.Get()
+		assert.False(t, ok)
+		assert.Empty(t, Outputs[OutResults](CompositeResult{composite: composite}), "Out structs are produced field by field")
+	})
+
+	t.Run("nil interface fields of an Out struct", func(t *testing.T) {
+		t.Parallel()
+
+		consumer := &NilOutConsumer{}
+		composite := &Composite{
+			results:      make(map[reflect.Type]any),
+			sliceResults: make(map[reflect.Type][]any),
+			namedResults: make(map[string]any),
+		}
+		composite.executor = composite
+		for component, err := range Components(func() *NilOutConsumer { return consumer }, func() *NilOutProducer { return &NilOutProducer{} }) {
+			require.NoError(t, err)
+			composite.components = append(composite.components, component)
+		}
+		require.NoError(t, composite.Apply(t.Context()))
+
+		assert.True(t, consumer.applied)
+		assert.Nil(t, consumer.params.Chart)
+		assert.Nil(t, consumer.params.Named)
+		assert.Equal(t, Optional[port.Component]{Ok: true}, consumer.params.Optional)
+		assert.Nil(t, consumer.chart)
+		assert.Empty(t, Outputs[port.Component](CompositeResult{composite: composite}))
+	})
+}
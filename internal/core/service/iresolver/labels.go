@@ -0,0 +1,46 @@
+package iresolver
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/samber/lo"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+)
+
+// LabelResolver is a resolver function that adds the labels returned by the given function to the
+// metadata of every Kubernetes resource. Labels set by the resource itself take precedence.
+func LabelResolver(labels func(obj cdk8s.ApiObject) map[string]string) ResolverFn {
+	return func(ctx cdk8s.ResolutionContext) {
+		keys := lo.Map(*ctx.Key(), func(k *string, _ int) string {
+			return dry.FromPtr(k)
+		})
+		if dry.FromPtr(ctx.Replaced()) || !slices.Equal(keys, []string{"metadata"}) {
+			return
+		}
+		add := labels(ctx.Obj())
+		if len(add) == 0 {
+			return
+		}
+		metadata, ok := ctx.Value().(map[string]any)
+		if !ok {
+			return
+		}
+
+		existing, _ := metadata["labels"].(map[string]any)
+		merged := make(map[string]any, len(add)+len(existing))
+		for k, v := range add {
+			merged[k] = v
+		}
+		maps.Copy(merged, existing)
+		// cdk8s resolves replaced values again, so stop once every label is present.
+		if len(merged) == len(existing) {
+			return
+		}
+		replaced := maps.Clone(metadata)
+		replaced["labels"] = merged
+		ctx.ReplaceValue(replaced)
+	}
+}
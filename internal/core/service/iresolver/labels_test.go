@@ -0,0 +1,47 @@
+package iresolver
+
+import (
+	"testing"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+func TestLabelResolver(t *testing.T) {
+	resolver := LabelResolver(func(obj cdk8s.ApiObject) map[string]string {
+		return map[string]string{"crib.sdk/plan": "root", "crib.sdk/component": *obj.Node().Id()}
+	})
+	app := cdk8s.Testing_App(&cdk8s.AppProps{
+		YamlOutputType: cdk8s.YamlOutputType_FILE_PER_APP,
+		Resolvers:      dry.ToPtr([]cdk8s.IResolver{NewResolver(resolver, ResolutionPriorityDefault)}),
+	})
+	chart := cdk8s.NewChart(app, dry.ToPtr("TestChart"), nil)
+	cdk8s.NewApiObject(chart, dry.ToPtr("plain"), &cdk8s.ApiObjectProps{
+		ApiVersion: dry.ToPtr("v1"),
+		Kind:       dry.ToPtr("ConfigMap"),
+		Metadata:   &cdk8s.ApiObjectMetadata{Name: dry.ToPtr("plain")},
+	})
+	cdk8s.NewApiObject(chart, dry.ToPtr("labelled"), &cdk8s.ApiObjectProps{
+		ApiVersion: dry.ToPtr("v1"),
+		Kind:       dry.ToPtr("ConfigMap"),
+		Metadata: &cdk8s.ApiObjectMetadata{
+			Name:   dry.ToPtr("labelled"),
+			Labels: dry.PtrMapping(map[string]string{"app": "test", "crib.sdk/component": "custom"}),
+		},
+	})
+
+	var labels []domain.GenericManifest
+	for doc, err := range domain.UnmarshalDocument([]byte(*app.SynthYaml())) {
+		require.NoError(t, err)
+		labels = append(labels, doc["metadata"].(domain.GenericManifest)["labels"].(domain.GenericManifest))
+	}
+	assert.Equal(t, []domain.GenericManifest{
+		{"crib.sdk/plan": "root", "crib.sdk/component": "plain"},
+		// Labels of the resource take precedence.
+		{"crib.sdk/plan": "root", "crib.sdk/component": "custom", "app": "test"},
+	}, labels)
+}
@@ -1,14 +1,21 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"maps"
+	"path"
 	"path/filepath"
+	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
 	"github.com/samber/lo"
@@ -25,6 +32,16 @@ import (
 	"github.com/smartcontractkit/crib-sdk/internal/core/service/iresolver"
 )
 
+// Labels that the HelmChart component and Helm add to rendered resources.
+const (
+	helmCribNameLabel  = "helm.crib.sdk/name"
+	helmCribChartLabel = "helm.crib.sdk/chart"
+	helmChartLabel     = "helm.sh/chart"
+)
+
+// maxStepOutput is the maximum number of trailing output bytes of a step kept in the plan record.
+const maxStepOutput = 4 << 10
+
 // mu is a package-level mutex to ensure that only one plan is being created at a time.
 // This is necessary because cdk8s has some level of globally shared state, causing concurrent
 // App and Chart creations to fail.
@@ -35,6 +52,16 @@ type (
 		Build() port.Planner
 	}
 
+	// paramsDeclarer is implemented by plans that declare parameters.
+	paramsDeclarer interface {
+		Params() []port.Parameter
+	}
+
+	// waitDefaulter is implemented by plans that declare the defaults of their wait conditions.
+	waitDefaulter interface {
+		WaitDefaults() domain.WaitDefaults
+	}
+
 	// PlanService is a service that handles discovery of manifests in a directory, and applying them.
 	// It includes the logic for applying special ClientSideApply manifests.
 	//
@@ -49,8 +76,50 @@ type (
 	//	appPlan.Synthesize()
 	//	// Finally, apply the plan, which will discover the manifests in the tempdir and apply them.
 	//	err = svc.ApplyPlan(ctx)
+	//	// Or tear everything down again, walking the manifests in reverse order.
+	//	_, err = appPlan.Destroy(ctx)
+	//
+	// When created with WithPlanStateStore, a record of each applied plan is kept so that
+	// appPlan.Drift(ctx) can detect changes since the last apply.
+	//
+	// Bundles are applied one at a time by default. With WithConcurrency, bundles that do not depend
+	// on each other are applied at the same time, see AppPlan.Apply.
 	PlanService struct {
-		fh port.FileHandler
+		fh          port.FileHandler
+		store       port.PlanStateStore
+		concurrency int
+		// params are the raw values of plan parameters, keyed by parameter name.
+		params map[string]any
+		// applier applies and deletes remote bundles through the Kubernetes API, see WithKubernetesApplier.
+		// Remote bundles are applied with kubectl if it is nil.
+		applier port.KubernetesApplier
+		// reader reads the objects that Wait bundles wait for, see WithKubernetesReader.
+		// Objects are read with kubectl if it is nil.
+		reader port.KubernetesReader
+		// wait overrides the wait defaults declared by the plan, see WithWaitDefaults.
+		wait domain.WaitDefaults
+		// progress is notified as bundles are applied, see WithProgress.
+		progress port.ApplyProgress
+		// quiet keeps the output of the commands off the console, see WithQuietOutput.
+		quiet bool
+		// listObjects lists the objects in the cluster matching a label selector, see WithPrune.
+		// It defaults to listing them with the applier, or with kubectl.
+		listObjects func(ctx context.Context, selector string) ([]domain.ObjectRef, error)
+	}
+
+	// PlanServiceOpt is a functional option for configuring a PlanService.
+	PlanServiceOpt func(*PlanService)
+
+	// ApplyOpt is a functional option for configuring a single apply of an AppPlan.
+	ApplyOpt func(*applyOptions)
+
+	applyOptions struct {
+		resume   bool
+		from, to string
+		// targets and skip select components by ID or plan, see selectCharts.
+		targets, skip []string
+		dependencies  bool
+		prune         bool
 	}
 
 	// Manifest represents a manifest file with its name and whether it's purpose is
@@ -65,6 +134,9 @@ type (
 		root      string
 		manifests []Manifest
 		isLocal   bool
+		// resolved holds the manifests of a remote bundle with the outputs they reference resolved, see
+		// resolveOutputs. If set, the bundle is applied from it instead of from the rendered files.
+		resolved []byte
 	}
 
 	// AppPlan is a struct that represents the application plan, it includes
@@ -75,18 +147,156 @@ type (
 		App         cdk8s.App
 		Chart       cdk8s.Chart
 		planResults *plancache.Results
+		// charts holds, for each synthesized chart, the positions of the charts it depends on.
+		// A chart's position is the ordinal prefix of its output directory.
+		charts [][]int
+		// params are the resolved values of the plan parameters, keyed by parameter name.
+		params map[string]any
+		// components are the components created for each plan, keyed by plan name.
+		components map[string][]port.Component
 	}
 
+	// PlanState is the result of applying a plan.
 	PlanState struct {
 		*plancache.Results
+
+		// Report is the outcome of each applied bundle.
+		Report domain.ApplyReport
 	}
 )
 
 // NewPlanService creates a new PlanService with the provided FileHandler.
-func NewPlanService(_ context.Context, fh *filehandler.Handler) (*PlanService, error) {
-	return &PlanService{
-		fh: fh,
-	}, nil
+func NewPlanService(_ context.Context, fh *filehandler.Handler, opts ...PlanServiceOpt) (*PlanService, error) {
+	svc := &PlanService{
+		fh:          fh,
+		concurrency: 1,
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc, nil
+}
+
+// WithPlanStateStore configures the store in which a record of each applied plan is kept.
+// Without a store, applied plans are not recorded and drift cannot be detected.
+func WithPlanStateStore(store port.PlanStateStore) PlanServiceOpt {
+	return func(p *PlanService) {
+		p.store = store
+	}
+}
+
+// WithConcurrency configures the maximum number of bundles that are applied at the same time.
+// Values lower than 1 are treated as 1, which applies every bundle in order.
+func WithConcurrency(n int) PlanServiceOpt {
+	return func(p *PlanService) {
+		p.concurrency = max(n, 1)
+	}
+}
+
+// WithParams sets the raw values of plan parameters, keyed by parameter name. Values are converted
+// and validated by the parameter when the plan is created. Setting a parameter that is not declared
+// by the plan or any of its child plans is an error.
+func WithParams(values map[string]any) PlanServiceOpt {
+	return func(p *PlanService) {
+		p.params = values
+	}
+}
+
+// WithKubernetesApplier applies and deletes the objects of remote bundles, and lists the objects to prune,
+// through the Kubernetes API with the given applier instead of running kubectl. ClientSideApply bundles
+// are still run by their runner, and drift is still detected with kubectl diff. A nil applier applies remote
+// bundles with kubectl, which is the default.
+func WithKubernetesApplier(applier port.KubernetesApplier) PlanServiceOpt {
+	return func(p *PlanService) {
+		p.applier = applier
+	}
+}
+
+// WithKubernetesReader reads the objects that Wait bundles wait for through the Kubernetes API with the
+// given reader instead of running kubectl get. A nil reader reads them with kubectl, which is the default.
+func WithKubernetesReader(reader port.KubernetesReader) PlanServiceOpt {
+	return func(p *PlanService) {
+		p.reader = reader
+	}
+}
+
+// WithWaitDefaults overrides the timeout and poll interval of the wait conditions that do not set their
+// own. Values that are not set fall back to the defaults declared by the plan, and then to
+// domain.DefaultWaitTimeout and domain.DefaultWaitInterval.
+func WithWaitDefaults(d domain.WaitDefaults) PlanServiceOpt {
+	return func(p *PlanService) {
+		p.wait = d
+	}
+}
+
+// WithProgress notifies the given progress as bundles are applied.
+func WithProgress(progress port.ApplyProgress) PlanServiceOpt {
+	return func(p *PlanService) {
+		p.progress = progress
+	}
+}
+
+// WithQuietOutput keeps the output of the commands run by the bundles off os.Stdout and os.Stderr. The
+// output is still written to the log file of each bundle, see AppPlan.Apply, and the last part of it is
+// reported.
+func WithQuietOutput() PlanServiceOpt {
+	return func(p *PlanService) {
+		p.quiet = true
+	}
+}
+
+// WithResume skips the bundles that succeeded with the same content in the last recorded apply of the
+// plan, so that a failed apply continues from the first bundle that failed or was not processed.
+// It requires a PlanService with a state store.
+func WithResume() ApplyOpt {
+	return func(o *applyOptions) {
+		o.resume = true
+	}
+}
+
+// WithFrom skips the bundles before the first bundle matching the given key, see matchBundle.
+func WithFrom(bundle string) ApplyOpt {
+	return func(o *applyOptions) {
+		o.from = bundle
+	}
+}
+
+// WithTargets only applies the bundles of the components matching the given selectors, e.g.
+// "sdk.HelmChart#anvil" or "plan:chainlink-jd", see matchComponent.
+func WithTargets(selectors ...string) ApplyOpt {
+	return func(o *applyOptions) {
+		o.targets = append(o.targets, selectors...)
+	}
+}
+
+// WithSkip skips the bundles of the components matching the given selectors, see WithTargets.
+func WithSkip(selectors ...string) ApplyOpt {
+	return func(o *applyOptions) {
+		o.skip = append(o.skip, selectors...)
+	}
+}
+
+// WithDependencies also applies the bundles of the components that the targets depend on, including the
+// components of the child plans of a targeted plan.
+func WithDependencies() ApplyOpt {
+	return func(o *applyOptions) {
+		o.dependencies = true
+	}
+}
+
+// WithPrune deletes the objects in the cluster that are labelled as rendered by the plan, but are no
+// longer part of its render, once every bundle was applied successfully. See AppPlan.prune.
+func WithPrune() ApplyOpt {
+	return func(o *applyOptions) {
+		o.prune = true
+	}
+}
+
+// WithTo skips the bundles after the last bundle matching the given key, see matchBundle.
+func WithTo(bundle string) ApplyOpt {
+	return func(o *applyOptions) {
+		o.to = bundle
+	}
 }
 
 // CreatePlan creates a new container app, and builds the plan with the provided components.
@@ -105,77 +315,528 @@ func (p *PlanService) CreatePlan(ctx context.Context, plan port.Planner) (*AppPl
 	}
 	app.RootPlan = planBuilder.Build()
 
+	// Resolve the plan parameters, so that components can read them from the context.
+	params, err := p.resolveParams(ctx, app.RootPlan)
+	if err != nil {
+		return nil, err
+	}
+	app.params = params
+	ctx = internal.ContextWithParams(ctx, params)
+
 	// cdk8s has some level of globally shared state, so we need to acquire a lock.
 	mu.Lock()
 	defer mu.Unlock()
 
 	var resolutionErrors error
-	// Loop through each child plan and their components, resolve them, and add them to the chart.
-	for _, child := range app.RootPlan.ChildPlans() {
-		for _, fn := range child.Components() {
+	// Resolve the components of every plan in dependency order, adding them to the chart. Child plans
+	// at any depth come before their parents, and the root plan comes last.
+	components := make(map[string][]port.Component)
+	for _, plan := range planOrder(app.RootPlan) {
+		for _, fn := range plan.Components() {
 			component, err := fn(ctx)
 			if err != nil {
 				resolutionErrors = errors.Join(resolutionErrors, err)
 				continue
 			}
 			app.planResults.Add(component)
+			if component != nil {
+				components[plan.Name()] = append(components[plan.Name()], component)
+			}
 		}
-	}
-	// Loop through the parent components, resolve them, and add them to the chart.
-	for _, fn := range app.RootPlan.Components() {
-		component, err := fn(ctx)
-		if err != nil {
-			resolutionErrors = errors.Join(resolutionErrors, err)
-			continue
+		// The components of a plan depend on the components of its child plans, so that child plans
+		// are applied first even when bundles are applied concurrently.
+		for _, component := range components[plan.Name()] {
+			for _, dep := range childComponents(plan, components) {
+				component.Node().AddDependency(dep)
+			}
 		}
-		app.planResults.Add(component)
 	}
 	// If there were any errors, return them.
 	if resolutionErrors != nil {
 		return nil, resolutionErrors
 	}
 
-	// Synthesize the app to create the manifests in the tempdir.
+	// Synthesize the app to create the manifests in the tempdir. The components are known by now, so
+	// that rendered resources are labelled with the component they belong to, see ownerLabels.
+	app.components = components
 	app.App.Synth()
+	app.charts = chartGraph(app.App)
 	return app, nil
 }
 
-// Apply applies the discovered manifests in the directory.
-func (a *AppPlan) Apply(ctx context.Context) (*PlanState, error) {
+// planOrder returns the plan and all of its child plans, at any depth, in topological order: every
+// plan comes after the plans it depends on, and the root comes last. Plans are identified by name,
+// so a plan reached through several parents (a diamond) is only returned once, at its first position.
+// Cycles are detected when the plan is built, so they are not reported here.
+func planOrder(root port.Planner) []port.Planner {
+	var (
+		order   []port.Planner
+		visited = make(map[string]struct{})
+	)
+	var visit func(p port.Planner)
+	visit = func(p port.Planner) {
+		if _, ok := visited[p.Name()]; ok {
+			return
+		}
+		visited[p.Name()] = struct{}{}
+		for _, child := range p.ChildPlans() {
+			visit(child)
+		}
+		order = append(order, p)
+	}
+	visit(root)
+	return order
+}
+
+// PlanParams returns the parameters declared by the plan and all of its child plans, in the order in which
+// the plans are rendered. A parameter declared by several plans is only returned once.
+func PlanParams(plan port.Planner) []port.Parameter {
+	var (
+		params []port.Parameter
+		seen   = make(map[string]struct{})
+	)
+	for _, plan := range planOrder(plan) {
+		declarer, ok := plan.(paramsDeclarer)
+		if !ok {
+			continue
+		}
+		for _, param := range declarer.Params() {
+			if _, ok := seen[param.Name()]; ok {
+				continue
+			}
+			seen[param.Name()] = struct{}{}
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// resolveParams resolves the value of every parameter declared by the plan from the raw values of the
+// PlanService. All errors are collected and returned at once.
+func (p *PlanService) resolveParams(ctx context.Context, plan port.Planner) (map[string]any, error) {
+	var errs error
+	params := PlanParams(plan)
+	resolved := make(map[string]any, len(params))
+	for _, param := range params {
+		v, err := param.Resolve(ctx, p.params[param.Name()])
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		resolved[param.Name()] = v
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.params)) {
+		if !slices.ContainsFunc(params, func(param port.Parameter) bool { return param.Name() == name }) {
+			errs = errors.Join(errs, fmt.Errorf("plan %q has no parameter %q", plan.Name(), name))
+		}
+	}
+	return resolved, errs
+}
+
+// childComponents returns the components of the child plans of the plan. Child plans without components
+// of their own are looked through, so that the components of their child plans are returned instead.
+func childComponents(plan port.Planner, components map[string][]port.Component) []port.Component {
+	var deps []port.Component
+	for _, child := range plan.ChildPlans() {
+		if c := components[child.Name()]; len(c) > 0 {
+			deps = append(deps, c...)
+			continue
+		}
+		deps = append(deps, childComponents(child, components)...)
+	}
+	return deps
+}
+
+// Apply applies the discovered manifests in the directory. The outcome of each bundle is reported in
+// the returned PlanState, which is returned even if a bundle failed. If any bundle failed, an error
+// aggregating every failure is returned. If the PlanService has a state store, a record of the applied
+// bundles is saved once processing stops, including when a bundle aborts.
+//
+// Bundles are applied once the bundles they depend on have been processed, see bundleGraph. Up to
+// the configured concurrency, independent bundles are applied at the same time. When a bundle aborts
+// no further bundles are started, bundles that are already running are allowed to finish.
+//
+// The record is saved as a checkpoint after each applied bundle, so that an interrupted apply can be
+// resumed with WithResume. Bundles skipped through the options are reported as skipped.
+//
+// The output of each bundle is written to its own log file in the logs directory of the render
+// directory, e.g. logs/02-register/00-cmd.log, and streamed to the console unless WithQuietOutput is set.
+// Registered secrets are masked in the output, the logs and the errors, see domain.RegisterSecret.
+func (a *AppPlan) Apply(ctx context.Context, opts ...ApplyOpt) (*PlanState, error) {
+	var o applyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	manifests := a.svc.findManifests()
 	bundles := a.svc.normalizeManifests(manifests)
+	record, err := a.record(bundles)
+	if err != nil {
+		return nil, err
+	}
+	skip, err := a.skipBundles(ctx, bundles, record, o)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkSkippedOutputs(bundles, skip); err != nil {
+		return nil, err
+	}
+
+	// Bundles that fail with onFailure: continue are reported, and processing moves on to the next bundle.
+	checkpoint := func(i int, r *domain.BundleReport) {
+		if r.Status == domain.StepStatusSkipped {
+			return // Keep the step of the previous apply, if any.
+		}
+		record.Bundles[i].Step = newStepRecord(r)
+		// Errors are reported by the final save below.
+		_ = a.saveRecord(ctx, record)
+	}
+	ctx = contextWithWaitDefaults(ctx, a.waitDefaults())
+	ctx = contextWithOutputs(ctx, recordedOutputs(record, skip))
+	reports := a.svc.applyBundles(ctx, bundles, a.bundleGraph(bundles), skip, checkpoint)
+	report := make(domain.ApplyReport, 0, len(bundles))
+	for _, r := range reports {
+		if r == nil {
+			continue // Not started because a bundle aborted.
+		}
+		report = append(report, *r)
+	}
+	if o.prune && report.Err() == nil {
+		for _, r := range a.prune(ctx, bundles) {
+			r.Redact()
+			report = append(report, r)
+		}
+	}
+	state := &PlanState{Results: a.planResults, Report: report}
+	return state, errors.Join(report.Err(), a.saveRecord(ctx, record))
+}
+
+// Drift compares a fresh render of the plan against the record of the last apply, and the
+// rendered Kubernetes resources against the live objects in the cluster. Bundles referencing
+// sensitive outputs are not compared against the cluster, as those outputs are not recorded.
+func (a *AppPlan) Drift(ctx context.Context) (*domain.PlanDrift, error) {
+	if a.svc.store == nil {
+		return nil, errors.New("no plan state store configured")
+	}
+	applied, err := a.svc.store.Load(ctx, a.planName(), a.planNamespace())
+	if err != nil {
+		return nil, err
+	}
+	bundles := a.svc.normalizeManifests(a.svc.findManifests())
+	rendered, err := a.record(bundles)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := applied.Drift(rendered)
+	// Live objects are compared as they were applied, with the outputs of the last apply resolved.
+	// Sensitive outputs are not recorded, so bundles referencing them cannot be compared.
+	ctx = contextWithOutputs(ctx, recordedOutputs(applied, nil))
+	sensitive := a.sensitiveOutputs(bundles)
 	for _, bundle := range bundles {
-		err := bundle.Apply(ctx, a.svc)
-		if errors.Is(err, domain.ErrAbort) {
+		if bundle.isLocal {
+			continue // ClientSideApply steps have no live objects to compare against.
+		}
+		if slices.ContainsFunc(bundle.outputReferences(a.svc), func(name string) bool { return sensitive[name] != "" }) {
+			continue
+		}
+		bundle, err := bundle.resolveOutputs(ctx, a.svc)
+		if err != nil {
+			return nil, err
+		}
+		drifted, err := bundle.Diff(ctx)
+		if err != nil {
 			return nil, err
 		}
-		// TODO: Log errors with a Continue type.
+		if drifted {
+			drift.Live = append(drift.Live, bundle.Key())
+		}
 	}
-	return &PlanState{Results: a.planResults}, nil
+	return drift, nil
+}
+
+// skipBundles returns, for each bundle of the record, whether it is skipped according to the options.
+// Skipped bundles keep the step of the last recorded apply if their content did not change, so that the
+// saved record still describes the latest outcome of every bundle.
+func (a *AppPlan) skipBundles(ctx context.Context, bundles []ManifestBundle, record *domain.PlanRecord, o applyOptions) ([]bool, error) {
+	skip := make([]bool, len(record.Bundles))
+	if !o.resume && o.from == "" && o.to == "" && len(o.targets) == 0 && len(o.skip) == 0 {
+		return skip, nil
+	}
+	charts, err := a.selectCharts(o)
+	if err != nil {
+		return nil, err
+	}
+	// selected reports whether the bundle belongs to a selected chart.
+	selected := func(i int) bool {
+		if charts == nil {
+			return true
+		}
+		chart, _, ok := cutOrdinal(bundles[i].dir())
+		return ok && chart < len(charts) && charts[chart]
+	}
+
+	// Resolve the range of bundles to apply.
+	keys := lo.Map(record.Bundles, func(b domain.BundleRecord, _ int) string { return b.Key })
+	from, to := 0, len(keys)-1
+	if o.from != "" {
+		if from = slices.IndexFunc(keys, func(key string) bool { return matchBundle(key, o.from) }); from < 0 {
+			return nil, fmt.Errorf("no bundle matches %q", o.from)
+		}
+	}
+	if o.to != "" {
+		if _, to, _ = lo.FindLastIndexOf(keys, func(key string) bool { return matchBundle(key, o.to) }); to < 0 {
+			return nil, fmt.Errorf("no bundle matches %q", o.to)
+		}
+	}
+	if from > to {
+		return nil, fmt.Errorf("bundle %q comes after bundle %q", o.from, o.to)
+	}
+
+	if o.resume && a.svc.store == nil {
+		return nil, errors.New("resuming an apply requires a plan state store")
+	}
+	previous, err := a.loadRecord(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, b := range record.Bundles {
+		inRange := i >= from && i <= to
+		completed := o.resume && previous.Completed(b.Key, b.Hash)
+		if inRange && selected(i) && !completed {
+			continue
+		}
+		skip[i] = true
+		if prev := previous.Bundle(b.Key); prev != nil && prev.Hash == b.Hash {
+			record.Bundles[i].Step = prev.Step
+		}
+	}
+	return skip, nil
+}
+
+// loadRecord returns the stored record of the plan, or nil if the plan has no record or the
+// PlanService has no state store.
+func (a *AppPlan) loadRecord(ctx context.Context) (*domain.PlanRecord, error) {
+	if a.svc.store == nil || a.planName() == "" {
+		return nil, nil
+	}
+	record, err := a.svc.store.Load(ctx, a.planName(), a.planNamespace())
+	if errors.Is(err, domain.ErrPlanRecordNotFound) {
+		return nil, nil
+	}
+	return dry.Wrapf2(record, err, "loading record of plan %q", a.planName())
+}
+
+// matchBundle returns true if the bundle key matches the selector. A selector matches a bundle by its
+// key, e.g. "plan-sdk.namespace-1234abcd/Namespace.foo.k8s.yaml", or by the directory of its key,
+// which selects every bundle rendered from the same chart.
+func matchBundle(key, selector string) bool {
+	selector = strings.TrimSuffix(selector, "/")
+	return key == selector || path.Dir(key) == selector
+}
+
+// record creates a record of the given bundles. Steps are left empty until the bundles are applied.
+func (a *AppPlan) record(bundles []ManifestBundle) (*domain.PlanRecord, error) {
+	record := &domain.PlanRecord{
+		Name:      a.planName(),
+		Namespace: a.planNamespace(),
+		Bundles:   make([]domain.BundleRecord, 0, len(bundles)),
+	}
+	for _, bundle := range bundles {
+		hash, err := bundle.Hash(a.svc)
+		if err != nil {
+			return nil, err
+		}
+		record.Bundles = append(record.Bundles, domain.BundleRecord{
+			Key:     bundle.Key(),
+			Hash:    hash,
+			IsLocal: bundle.isLocal,
+		})
+	}
+	record.Charts = a.svc.chartVersions(bundles)
+	return record, nil
+}
+
+// saveRecord stores the record of the plan, if the PlanService has a state store.
+func (a *AppPlan) saveRecord(ctx context.Context, record *domain.PlanRecord) error {
+	if a.svc.store == nil || record.Name == "" {
+		return nil
+	}
+	record.AppliedAt = time.Now().UTC()
+	return dry.Wrapf(a.svc.store.Save(ctx, record), "saving record of plan %q", record.Name)
+}
+
+// planName returns the name of the root plan, or an empty string if the plan was not built.
+func (a *AppPlan) planName() string {
+	if a.RootPlan == nil {
+		return ""
+	}
+	return a.RootPlan.Name()
+}
+
+// planNamespace returns the namespace of the root plan, or an empty string if the plan was not built.
+func (a *AppPlan) planNamespace() string {
+	if a.RootPlan == nil {
+		return ""
+	}
+	return a.RootPlan.Namespace()
+}
+
+// Destroy tears down the discovered manifests in the directory. Bundles are processed in the
+// reverse order in which they would be applied: remote bundles are deleted from the cluster and
+// local bundles run their undo step, if they declare one. Undo steps must not reference sensitive
+// outputs, which are not recorded.
+// Processing stops at the first bundle that aborts, all other errors are collected and returned.
+func (a *AppPlan) Destroy(ctx context.Context) (*PlanState, error) {
+	manifests := a.svc.findManifests()
+	bundles := a.svc.normalizeManifests(manifests)
+
+	// Undo steps and remote bundles may reference the outputs of the last apply.
+	record, err := a.loadRecord(ctx)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := a.destroyOutputs(bundles, record)
+	if err != nil {
+		return nil, err
+	}
+	ctx = contextWithOutputs(ctx, outputs)
+
+	var errs error
+	for _, bundle := range slices.Backward(bundles) {
+		err := domain.RedactError(bundle.Destroy(ctx, a.svc))
+		if errors.Is(err, domain.ErrAbort) {
+			return nil, errors.Join(errs, err)
+		}
+		errs = errors.Join(errs, err)
+	}
+	// Only forget the plan once everything was torn down, so that a partial destroy can still be inspected.
+	if errs == nil && a.svc.store != nil && a.planName() != "" {
+		errs = dry.Wrapf(a.svc.store.Delete(ctx, a.planName(), a.planNamespace()), "deleting record of plan %q", a.planName())
+	}
+	return dry.Wrap2(&PlanState{Results: a.planResults}, errs)
 }
 
 // Apply creates a new runner and applies the manifest.
 func (b ManifestBundle) Apply(ctx context.Context, p *PlanService) error {
-	// Acquire a lock to ensure that only one client-side apply is being executed at a time.
-	mu.Lock()
-	defer mu.Unlock()
+	r := b.apply(ctx, p)
+	return domain.RedactError(r.Err)
+}
 
-	var (
-		runner port.ClientSideApplyRunner
-		err    error
-		m      *domain.ClientSideApplyManifest
-	)
+// apply applies the manifest and reports the outcome. The options are passed on to the runner.
+func (b ManifestBundle) apply(ctx context.Context, p *PlanService, opts ...clientsideapply.RunnerOpt) domain.BundleReport {
+	if !b.isLocal {
+		var err error
+		if b, err = b.resolveOutputs(ctx, p); err != nil {
+			r := b.report()
+			r.Fail(domain.NewAbortError(err))
+			return r
+		}
+	}
+	if !b.isLocal && p.applier != nil {
+		return b.serverSideApply(ctx, p)
+	}
+	if b.isWait(p) {
+		return b.wait(ctx, p)
+	}
+	m, err := b.Client(p)
+	if err != nil {
+		r := b.report()
+		r.Fail(domain.NewAbortError(fmt.Errorf("failed to create ClientSideApplyManifest for bundle %s: %w", b.String(), err)))
+		return r
+	}
+	return b.execute(ctx, m, opts...)
+}
+
+// Destroy creates a new runner and reverses the manifest. Bundles without an undo step are skipped.
+// Remote bundles are deleted with the outputs they reference resolved, as they were applied.
+func (b ManifestBundle) Destroy(ctx context.Context, p *PlanService) error {
+	if !b.isLocal {
+		var err error
+		if b, err = b.resolveOutputs(ctx, p); err != nil {
+			return err
+		}
+	}
+	if !b.isLocal && p.applier != nil {
+		return b.serverSideDelete(ctx, p)
+	}
+	if b.isWait(p) {
+		return nil // Waiting has no effect to reverse.
+	}
+	m, err := b.Undo(p)
+	if err != nil {
+		return domain.NewAbortError(fmt.Errorf("failed to create undo ClientSideApplyManifest for bundle %s: %w", b.String(), err))
+	}
+	if m == nil {
+		return nil // Nothing to undo.
+	}
+	r := b.execute(ctx, m)
+	return r.Err
+}
 
-	m, err = b.Client(p)
+// Diff compares the resources of a remote bundle against the live objects in the cluster using
+// kubectl diff. It returns true if the live objects differ from the rendered manifests.
+func (b ManifestBundle) Diff(ctx context.Context) (bool, error) {
+	m, err := b.kubectlDiff()
 	if err != nil {
-		return domain.NewAbortError(fmt.Errorf("failed to create ClientSideApplyManifest for bundle %s: %w", b.String(), err))
+		return false, err
 	}
-	runner, err = clientsideapply.NewRunner(m)
+	runner, err := clientsideapply.NewRunner(m)
 	if err != nil {
-		return domain.NewAbortError(err)
+		return false, err
+	}
+	res, err := runner.Execute(ctx, m)
+	// kubectl diff exits with status 1 when differences were found, and greater than 1 on errors.
+	if err != nil && res != nil && res.ExitCode == 1 {
+		return true, nil
+	}
+	return false, dry.Wrapf(err, "unable to diff bundle %s", b.String())
+}
+
+// execute runs the given manifest with the runner matching its action and reports the outcome.
+func (b ManifestBundle) execute(ctx context.Context, m *domain.ClientSideApplyManifest, opts ...clientsideapply.RunnerOpt) domain.BundleReport {
+	r := b.report()
+	// Runners may rewrite the action, e.g. to the path of the binary.
+	r.Action = m.Spec.Action
+
+	outputs := outputsFromContext(ctx)
+	if err := m.Spec.ResolveOutputs(outputs.values()); err != nil {
+		r.Fail(dry.Wrapf(m.NewError(err), "unable to resolve the outputs referenced by bundle %s", b.String()))
+		return r
+	}
+	runner, err := clientsideapply.NewRunner(m, opts...)
+	if err != nil {
+		r.Fail(domain.NewAbortError(err))
+		return r
+	}
+	start := time.Now()
+	res, err := runner.Execute(ctx, m)
+	r.Duration = time.Since(start)
+	if res != nil {
+		r.Output, r.ExitCode = res.Output, res.ExitCode
+	}
+	if err != nil {
+		r.Fail(dry.Wrapf(m.NewError(err), "unable to execute client-side apply for bundle %s", b.String()))
+		return r
+	}
+	outputs.add(res.Outputs)
+	// Sensitive outputs are only passed on to later steps, they are neither reported nor recorded.
+	r.Outputs = maps.Clone(res.Outputs)
+	for _, o := range m.Spec.Outputs {
+		if o.Sensitive {
+			delete(r.Outputs, o.Name)
+		}
+	}
+	return r
+}
+
+// report creates a new successful report for the bundle.
+func (b ManifestBundle) report() domain.BundleReport {
+	return domain.BundleReport{
+		Bundle: b.Key(),
+		Path:   b.String(),
+		Status: domain.StepStatusSucceeded,
 	}
-	_, err = runner.Execute(ctx, m)
-	return dry.Wrapf(m.NewError(err), "unable to execute client-side apply for bundle %s", b.String())
 }
 
 func (b ManifestBundle) Client(p *PlanService) (*domain.ClientSideApplyManifest, error) {
@@ -187,6 +848,19 @@ func (b ManifestBundle) Client(p *PlanService) (*domain.ClientSideApplyManifest,
 	return b.kubectlApply()
 }
 
+// Undo returns the ClientSideApplyManifest that reverses the bundle. For local bundles this is the
+// undo step of the manifest, which may be nil if none is declared. Remote bundles are deleted with kubectl.
+func (b ManifestBundle) Undo(p *PlanService) (*domain.ClientSideApplyManifest, error) {
+	if b.isLocal {
+		m, err := b.clientSideApply(p)
+		if err != nil {
+			return nil, err
+		}
+		return m.UndoManifest(), nil
+	}
+	return b.kubectlDelete()
+}
+
 // clientSideApply reads the manifest bundle and returns a ClientSideApplyManifest.
 // It errors if the Bundle contains more than one manifest, or if the manifest is not a local manifest.
 func (b ManifestBundle) clientSideApply(p *PlanService) (*domain.ClientSideApplyManifest, error) {
@@ -216,19 +890,105 @@ func (b ManifestBundle) kubectlApply() (*domain.ClientSideApplyManifest, error)
 	}
 
 	// Create a new ClientSideApplyManifest for the kubectl apply command.
+	file, stdin := b.kubectlFiles()
 	return &domain.ClientSideApplyManifest{
 		Spec: domain.ClientSideApplySpec{
 			OnFailure: domain.FailureAbort,
 			Action:    domain.ActionKubectl,
 			Args: []string{
 				"apply",
-				"-f", b.String(),
+				"-f", file,
 				"--wait",
 			},
+			Stdin: stdin,
 		},
 	}, nil
 }
 
+// kubectlDelete creates a new ClientSideApplyManifest that deletes the resources of the given
+// non-local ManifestBundle. Resources that no longer exist are ignored, and a failed deletion
+// does not prevent the remaining bundles from being destroyed.
+func (b ManifestBundle) kubectlDelete() (*domain.ClientSideApplyManifest, error) {
+	if b.isLocal {
+		return nil, fmt.Errorf("bundle %s is a local manifest bundle, expected remote", b.String())
+	}
+	if len(b.manifests) == 0 {
+		return nil, fmt.Errorf("bundle %s contains no manifests", b.String())
+	}
+
+	file, stdin := b.kubectlFiles()
+	return &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: domain.FailureContinue,
+			Action:    domain.ActionKubectl,
+			Args: []string{
+				"delete",
+				"-f", file,
+				"--ignore-not-found",
+				"--wait",
+			},
+			Stdin: stdin,
+		},
+	}, nil
+}
+
+// kubectlDiff creates a new ClientSideApplyManifest that compares the resources of the given
+// non-local ManifestBundle against the live objects in the cluster.
+func (b ManifestBundle) kubectlDiff() (*domain.ClientSideApplyManifest, error) {
+	if b.isLocal {
+		return nil, fmt.Errorf("bundle %s is a local manifest bundle, expected remote", b.String())
+	}
+	if len(b.manifests) == 0 {
+		return nil, fmt.Errorf("bundle %s contains no manifests", b.String())
+	}
+
+	file, stdin := b.kubectlFiles()
+	return &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: domain.FailureContinue,
+			Action:    domain.ActionKubectl,
+			Args: []string{
+				"diff",
+				"-f", file,
+			},
+			Stdin: stdin,
+		},
+	}, nil
+}
+
+// kubectlFiles returns the value of the -f flag of kubectl for the bundle, and the standard input of
+// kubectl. Bundles with resolved outputs are passed on the standard input.
+func (b ManifestBundle) kubectlFiles() (file, stdin string) {
+	if b.resolved != nil {
+		return "-", string(b.resolved)
+	}
+	return b.String(), ""
+}
+
+// newStepRecord creates the persisted record of applying a bundle from its report.
+func newStepRecord(r *domain.BundleReport) *domain.StepRecord {
+	step := &domain.StepRecord{
+		Action:   r.Action,
+		Status:   r.Status,
+		Duration: r.Duration,
+		ExitCode: r.ExitCode,
+		Output:   tail(r.Output, maxStepOutput),
+		Outputs:  r.Outputs,
+	}
+	if r.Err != nil {
+		step.Error = r.Err.Error()
+	}
+	return step
+}
+
+// tail returns at most the last n bytes of b as a string.
+func tail(b []byte, n int) string {
+	if len(b) > n {
+		b = b[len(b)-n:]
+	}
+	return string(b)
+}
+
 // createApp initializes a new basic application with directives on how to synthesize
 // the resulting manifests.
 func (p *PlanService) createApp(plan port.Planner) *AppPlan {
@@ -236,29 +996,29 @@ func (p *PlanService) createApp(plan port.Planner) *AppPlan {
 	mu.Lock()
 	defer mu.Unlock()
 
+	a := &AppPlan{
+		svc:         p,
+		planResults: plancache.New(),
+	}
 	// Create the resolvers.
 	resolvers := append(
 		plan.Resolvers(), // Plan resolvers.
 		// Default resolvers.
 		[]cdk8s.IResolver{
 			iresolver.NewResolver(iresolver.NameResolver, iresolver.ResolutionPriorityLow),
+			iresolver.NewResolver(iresolver.LabelResolver(a.ownerLabels), iresolver.ResolutionPriorityLow),
 		}...,
 	)
 
-	app := cdk8s.NewApp(&cdk8s.AppProps{
+	a.App = cdk8s.NewApp(&cdk8s.AppProps{
 		Outdir:         dry.ToPtr(p.fh.Name()),
 		YamlOutputType: cdk8s.YamlOutputType_FOLDER_PER_CHART_FILE_PER_RESOURCE,
 		Resolvers:      dry.ToPtr(resolvers),
 	})
-	chart := cdk8s.NewChart(app, infra.ResourceID(plan.Name()+"."+plan.Namespace(), nil), &cdk8s.ChartProps{
+	a.Chart = cdk8s.NewChart(a.App, infra.ResourceID(plan.Name()+"."+plan.Namespace(), nil), &cdk8s.ChartProps{
 		Namespace: dry.ToPtr(plan.Namespace()),
 	})
-	return &AppPlan{
-		svc:         p,
-		App:         app,
-		Chart:       chart,
-		planResults: plancache.New(),
-	}
+	return a
 }
 
 // findManifests uses the provided FileReader to scan a directory for manifest files.
@@ -275,6 +1035,55 @@ func (p *PlanService) findManifests() map[string][]Manifest {
 	return manifests
 }
 
+// chartVersions returns the Helm chart versions resolved while rendering the given bundles. Charts
+// are discovered through the labels that the HelmChart component adds to each rendered resource.
+func (p *PlanService) chartVersions(bundles []ManifestBundle) domain.HelmChartVersions {
+	versions := make(map[string]string)
+	for _, bundle := range bundles {
+		if bundle.isLocal {
+			continue
+		}
+		for _, m := range bundle.manifests {
+			raw, err := p.fh.ReadFile(m.Name)
+			if err != nil {
+				continue
+			}
+			for doc, err := range domain.UnmarshalDocument(raw) {
+				if err != nil {
+					break
+				}
+				if name, version, ok := chartVersion(doc); ok {
+					versions[name] = version
+				}
+			}
+		}
+	}
+
+	charts := make(domain.HelmChartVersions, 0, len(versions))
+	for _, name := range slices.Sorted(maps.Keys(versions)) {
+		charts = append(charts, domain.HelmChartVersion{Name: name, Version: versions[name]})
+	}
+	return charts
+}
+
+// chartVersion extracts the HelmChart component name and the resolved chart version from the labels
+// of a rendered resource. Helm marks resources with "<chart>-<version>", resources of subcharts
+// carry a different chart name and are ignored.
+func chartVersion(doc domain.GenericManifest) (name, version string, ok bool) {
+	metadata, _ := doc["metadata"].(map[string]any)
+	labels, _ := metadata["labels"].(map[string]any)
+	annotations, _ := metadata["annotations"].(map[string]any)
+	name, _ = labels[helmCribNameLabel].(string)
+	chart, _ := labels[helmCribChartLabel].(string)
+	// Some charts set the Helm chart reference as an annotation rather than a label.
+	ref, _ := cmp.Or(labels[helmChartLabel], annotations[helmChartLabel]).(string)
+	if name == "" || chart == "" {
+		return "", "", false
+	}
+	version, ok = strings.CutPrefix(ref, chart+"-")
+	return name, version, ok
+}
+
 // normalizeManifests maps a map[string][]Manifest to a []ManifestBundle. It groups together
 // the sets of manifests that can be applied together. For example, given the set:
 //
@@ -356,10 +1165,7 @@ func (p *PlanService) isLocalManifest(path string) bool {
 	if err != nil {
 		return false
 	}
-	if m.APIVersion == domain.CribAPIVersion && m.Kind == domain.ClientSideApply {
-		return true
-	}
-	return false
+	return m.APIVersion == domain.CribAPIVersion && (m.Kind == domain.ClientSideApply || m.Kind == domain.Wait)
 }
 
 // readManifest reads the manifest file at the given path and unmarshals it into a domain.Manifest.
@@ -389,6 +1195,55 @@ func (*PlanService) discoverYAML(_ port.FileReader, path string) error {
 	return domain.NewSkipFileError(path)
 }
 
+// Key returns an identifier for the bundle that is stable across renders of the same plan. It is
+// made of the first manifest in the bundle, with the ordinal prefix of its directory removed.
+func (b ManifestBundle) Key() string {
+	if len(b.manifests) == 0 {
+		return ""
+	}
+	return stableName(b.manifests[0].Name)
+}
+
+// Hash returns the content hash of the manifests in the bundle.
+func (b ManifestBundle) Hash(p *PlanService) (string, error) {
+	h := sha256.New()
+	for _, m := range b.manifests {
+		raw, err := p.fh.ReadFile(m.Name)
+		if err != nil {
+			return "", fmt.Errorf("reading manifest %s: %w", m.Name, err)
+		}
+		// Include the name so that moving a resource between bundles is detected.
+		_, _ = fmt.Fprintf(h, "%s\n", stableName(m.Name))
+		_, _ = h.Write(raw)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// stableName removes the ordinal prefix that cdk8s adds to each chart directory, e.g.
+// "0003-plan-sdk.namespace-1234abcd/Namespace.foo.k8s.yaml" becomes "plan-sdk.namespace-1234abcd/Namespace.foo.k8s.yaml".
+// The ordinal changes whenever a chart is added or removed before it.
+func stableName(name string) string {
+	dir, file := filepath.Split(name)
+	dir = filepath.Clean(dir)
+	if _, rest, ok := cutOrdinal(dir); ok {
+		dir = rest
+	}
+	return filepath.ToSlash(filepath.Join(dir, file))
+}
+
+// cutOrdinal splits the ordinal prefix off a chart directory, e.g. "0003-plan" returns 3 and "plan".
+func cutOrdinal(dir string) (ordinal int, rest string, ok bool) {
+	prefix, rest, ok := strings.Cut(dir, "-")
+	if !ok || prefix == "" || strings.Trim(prefix, "0123456789") != "" {
+		return 0, dir, false
+	}
+	ordinal, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, dir, false
+	}
+	return ordinal, rest, true
+}
+
 // String returns a string representation of the ManifestBundle, which is a slice of Manifest.
 func (b ManifestBundle) String() string {
 	ss := lo.Map(b.manifests, func(m Manifest, _ int) string {
@@ -397,149 +1252,74 @@ func (b ManifestBundle) String() string {
 	return strings.Join(ss, ",")
 }
 
-// Preview renders the DAG as a tree structure and returns it as a string.
-func (a *AppPlan) Preview(ctx context.Context) string {
-	tree := treeprint.New()
-
-	// Create a dummy CDK8s app/chart for context
-	app := cdk8s.NewApp(nil)
-	chartName := "preview"
-	chart := cdk8s.NewChart(app, &chartName, nil)
-	ctx = internal.ContextWithConstruct(ctx, chart)
-
-	// Build tree based on plan structure rather than CDK8s constructs
-	rootBranch := tree.AddBranch(fmt.Sprintf("%s.%s", a.RootPlan.Name(), a.RootPlan.Namespace()))
+// Preview renders the plan as a tree and returns it as a string. The tree holds every plan, the
+// components of each plan and the full construct tree of every component, followed by the dependencies
+// between them, see Graph, and a summary of the plan and its parameters.
+func (a *AppPlan) Preview(_ context.Context) string {
+	graph := a.Graph()
+	tree := treeprint.NewWithRoot(fmt.Sprintf("%s.%s", a.RootPlan.Name(), a.RootPlan.Namespace()))
 
-	// Cache for resolved components to avoid duplicate construction
-	resolvedComponents := make([]port.Component, 0)
-	totalComponents := 0
-
-	// Add child plans first
-	for _, childPlan := range a.RootPlan.ChildPlans() {
-		childBranch := rootBranch.AddBranch(fmt.Sprintf("Plan: %s.%s", childPlan.Name(), childPlan.Namespace()))
-		for _, componentFn := range childPlan.Components() {
-			// Resolve the component once and cache it
-			component, err := componentFn(ctx)
-			if err != nil {
-				childBranch.AddNode(fmt.Sprintf("<error: %v>", err))
-				continue
-			}
-			if component == nil {
-				childBranch.AddNode("<nil component>")
-				continue
-			}
-
-			// Cache the component for later counting
-			resolvedComponents = append(resolvedComponents, component)
-
-			// Get display name
-			name := getComponentDisplayNameFromComponent(component)
-			componentBranch := childBranch.AddBranch(name)
-			addNestedComponents(ctx, componentBranch, component)
+	// addChildren adds the descendants of the node to the branch.
+	var addChildren func(branch treeprint.Tree, id string)
+	addChildren = func(branch treeprint.Tree, id string) {
+		for _, child := range graph.Children(id) {
+			addChildren(branch.AddBranch(child.Label()), child.ID)
 		}
 	}
 
-	// Add root plan components
-	for _, componentFn := range a.RootPlan.Components() {
-		// Resolve the component once and cache it
-		component, err := componentFn(ctx)
-		if err != nil {
-			rootBranch.AddNode(fmt.Sprintf("<error: %v>", err))
-			continue
+	// Add every plan in the order in which it is rendered. Child plans come first, the root plan last.
+	var nested int
+	for _, plan := range graph.Children("") {
+		branch := tree
+		if plan.Name != a.RootPlan.Name() {
+			branch = tree.AddBranch(fmt.Sprintf("Plan: %s.%s", plan.Name, plan.Namespace))
 		}
-		if component == nil {
-			rootBranch.AddNode("<nil component>")
-			continue
+		addChildren(branch, plan.ID)
+		for _, component := range graph.Children(plan.ID) {
+			nested += countDescendants(graph, component.ID)
 		}
-
-		// Cache the component for later counting
-		resolvedComponents = append(resolvedComponents, component)
-
-		// Get display name
-		name := getComponentDisplayNameFromComponent(component)
-		componentBranch := rootBranch.AddBranch(name)
-		addNestedComponents(ctx, componentBranch, component)
-	}
-
-	// Count only the displayed nested components
-	for _, component := range resolvedComponents {
-		totalComponents += countDisplayedComponents(ctx, component)
 	}
 
-	// Add summary information
 	var summary strings.Builder
 	summary.WriteString(tree.String())
+	if len(graph.Edges) > 0 {
+		fmt.Fprintf(&summary, "\nDependencies:\n")
+		for _, edge := range graph.Edges {
+			from, _ := graph.Node(edge.From)
+			to, _ := graph.Node(edge.To)
+			fmt.Fprintf(&summary, "- %s depends on %s\n", from.Name, to.Name)
+		}
+	}
+
 	fmt.Fprintf(&summary, "\nSummary:\n")
 	fmt.Fprintf(&summary, "- Root Plan: %s.%s\n", a.RootPlan.Name(), a.RootPlan.Namespace())
 	fmt.Fprintf(&summary, "- Root Components: %d\n", len(a.RootPlan.Components()))
-	fmt.Fprintf(&summary, "- All Nested Components: %d\n", totalComponents)
-
-	return summary.String()
-}
+	fmt.Fprintf(&summary, "- All Nested Components: %d\n", nested)
 
-// addNestedComponents recursively adds nested components to the tree.
-func addNestedComponents(ctx context.Context, branch treeprint.Tree, component port.Component) {
-	// Get the component's node to access its children
-	if component.Node() == nil {
-		return
-	}
-
-	// Try to get children from the component
-	children := component.Node().Children()
-	if children == nil || len(*children) == 0 {
-		return
-	}
-
-	// Add each child component
-	for _, child := range *children {
-		if child == nil {
-			continue
-		}
-
-		// Get the child's ID for display
-		var childName string
-		if child.Node() != nil && child.Node().Id() != nil {
-			childName = *child.Node().Id()
-		} else {
-			childName = fmt.Sprintf("%T", child)
+	// List the declared parameters with their defaults, and the value they resolved to when it differs.
+	if params := PlanParams(a.RootPlan); len(params) > 0 {
+		fmt.Fprintf(&summary, "\nParameters:\n")
+		for _, param := range params {
+			fmt.Fprintf(&summary, "- %s (%s): %v", param.Name(), param.Type(), param.Default())
+			if v, ok := a.params[param.Name()]; ok && !reflect.DeepEqual(v, param.Default()) {
+				fmt.Fprintf(&summary, " (set to %v)", v)
+			}
+			if param.Usage() != "" {
+				fmt.Fprintf(&summary, " - %s", param.Usage())
+			}
+			summary.WriteString("\n")
 		}
-
-		// Add the child as a node (not a branch since we can't easily recurse into IConstruct)
-		branch.AddNode(childName)
 	}
-}
 
-// getComponentDisplayNameFromComponent gets the display name from an already resolved component.
-func getComponentDisplayNameFromComponent(component port.Component) string {
-	if component == nil {
-		return "<nil component>"
-	}
-	if component.Node() != nil && component.Node().Id() != nil {
-		id := component.Node().Id()
-		return fmt.Sprintf("%s (%T)", *id, component)
-	}
-	return fmt.Sprintf("%T", component)
+	// Parameters and construct names may hold secrets, e.g. a password passed as a parameter.
+	return domain.Redact(summary.String())
 }
 
-// Count only the immediate children that are displayed as nodes in the tree.
-func countDisplayedComponents(ctx context.Context, component port.Component) int {
-	if component == nil || component.Node() == nil {
-		return 0
-	}
-
-	children := component.Node().Children()
-	if children == nil || len(*children) == 0 {
-		return 0 // Root components aren't counted as "nested"
-	}
-
-	count := 0
-	for _, child := range *children {
-		if child == nil {
-			continue
-		}
-		// Only count immediate children that would be displayed as nodes
-		// This matches what addNestedComponents displays
-		count++
+// countDescendants returns the number of descendants of the node with the given ID.
+func countDescendants(graph *domain.PlanGraph, id string) int {
+	var count int
+	for _, child := range graph.Children(id) {
+		count += 1 + countDescendants(graph, child.ID)
 	}
 	return count
 }
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// serverSideApply applies the objects of the remote bundle with the KubernetesApplier of the service.
+// Like kubectl apply, a failed object aborts the plan once the remaining objects of the bundle are applied.
+func (b ManifestBundle) serverSideApply(ctx context.Context, p *PlanService) domain.BundleReport {
+	r := b.report()
+	r.Action = domain.ActionServerSideApply
+	objects, err := b.objects(p)
+	if err != nil {
+		r.Fail(domain.NewAbortError(fmt.Errorf("failed to read bundle %s: %w", b.String(), err)))
+		return r
+	}
+	start := time.Now()
+	results, err := p.applier.Apply(ctx, objects)
+	r.Duration = time.Since(start)
+	r.Objects, r.Output = results, objectOutput(results)
+	if err != nil {
+		r.Fail(domain.NewAbortError(dry.Wrapf(err, "unable to apply bundle %s", b.String())))
+	}
+	return r
+}
+
+// serverSideDelete deletes the objects of the remote bundle with the KubernetesApplier of the service.
+// A failed deletion does not prevent the remaining bundles from being destroyed.
+func (b ManifestBundle) serverSideDelete(ctx context.Context, p *PlanService) error {
+	objects, err := b.objects(p)
+	if err != nil {
+		return domain.NewAbortError(fmt.Errorf("failed to read bundle %s: %w", b.String(), err))
+	}
+	_, err = p.applier.Delete(ctx, objects)
+	if err != nil {
+		return domain.NewContinueError(dry.Wrapf(err, "unable to delete bundle %s", b.String()))
+	}
+	return nil
+}
+
+// objects returns the objects of the manifests of the remote bundle, in order. Bundles with resolved
+// outputs return the resolved objects.
+func (b ManifestBundle) objects(p *PlanService) ([]domain.GenericManifest, error) {
+	if b.resolved != nil {
+		return appendObjects(nil, b.resolved, b.String())
+	}
+	var objects []domain.GenericManifest
+	for _, m := range b.manifests {
+		raw, err := p.fh.ReadFile(m.Name)
+		if err != nil {
+			return nil, err
+		}
+		if objects, err = appendObjects(objects, raw, m.Name); err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// appendObjects appends the non-empty documents of the named manifest to objects.
+func appendObjects(objects []domain.GenericManifest, raw []byte, name string) ([]domain.GenericManifest, error) {
+	for doc, err := range domain.UnmarshalDocument(raw) {
+		if err != nil {
+			return nil, dry.Wrapf(err, "reading manifest %s", name)
+		}
+		if len(doc) > 0 {
+			objects = append(objects, doc)
+		}
+	}
+	return objects, nil
+}
+
+// objectOutput writes the results as the output of a bundle, one object per line.
+func objectOutput(results []domain.ObjectResult) []byte {
+	var b strings.Builder
+	for _, r := range results {
+		b.WriteString(r.String())
+		b.WriteByte('\n')
+	}
+	return []byte(b.String())
+}
+
+// serverSidePrune deletes the object with the KubernetesApplier of the service and reports the outcome.
+func (p *PlanService) serverSidePrune(ctx context.Context, obj domain.ObjectRef) domain.BundleReport {
+	r := domain.BundleReport{
+		Bundle: pruneBundle + obj.String(),
+		Path:   obj.Resource(),
+		Action: domain.ActionServerSideApply,
+		Status: domain.StepStatusSucceeded,
+	}
+	metadata := map[string]any{"name": obj.Name}
+	if obj.Namespace != "" {
+		metadata["namespace"] = obj.Namespace
+	}
+	doc := domain.GenericManifest{"apiVersion": obj.APIVersion, "kind": obj.Kind, "metadata": metadata}
+
+	start := time.Now()
+	results, err := p.applier.Delete(ctx, []domain.GenericManifest{doc})
+	r.Duration = time.Since(start)
+	r.Objects, r.Output = results, objectOutput(results)
+	if err != nil {
+		r.Fail(domain.NewContinueError(dry.Wrapf(err, "unable to prune %s", obj)))
+	}
+	return r
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+// fakeApplier records the objects it is given, failing the objects named in fail.
+type fakeApplier struct {
+	applied, deleted []string
+	objects          []domain.GenericManifest // Applied objects.
+	live             []domain.ObjectRef
+	fail             string
+}
+
+func (f *fakeApplier) Apply(_ context.Context, objects []domain.GenericManifest) ([]domain.ObjectResult, error) {
+	f.objects = append(f.objects, objects...)
+	return f.results(objects, &f.applied, domain.ObjectCreated)
+}
+
+func (f *fakeApplier) Delete(_ context.Context, objects []domain.GenericManifest) ([]domain.ObjectResult, error) {
+	return f.results(objects, &f.deleted, domain.ObjectDeleted)
+}
+
+func (f *fakeApplier) List(context.Context, string) ([]domain.ObjectRef, error) {
+	return f.live, nil
+}
+
+func (f *fakeApplier) results(objects []domain.GenericManifest, names *[]string, action string) ([]domain.ObjectResult, error) {
+	var (
+		results []domain.ObjectResult
+		err     error
+	)
+	for _, doc := range objects {
+		r := domain.ObjectResult{Object: domain.NewObjectRef(doc), Action: action}
+		*names = append(*names, r.Object.Name)
+		if r.Object.Name == f.fail {
+			r.Action, r.Err = domain.ObjectFailed, errors.New("forbidden")
+			err = r.Err
+		}
+		results = append(results, r)
+	}
+	return results, err
+}
+
+func TestApplyPlanKubernetesApplier(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+	is := assert.New(t)
+
+	ctx := t.Context()
+	fh, err := filehandler.New(ctx, t.TempDir())
+	must.NoError(err)
+	root := testPlanner{
+		name:       "root",
+		namespace:  "crib",
+		components: []port.ComponentFunc{configMap("a"), configMap("b")},
+	}
+	applier := &fakeApplier{
+		live: []domain.ObjectRef{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "crib", Name: "removed"}},
+	}
+	svc, err := NewPlanService(ctx, fh, WithKubernetesApplier(applier))
+	must.NoError(err)
+	plan, err := svc.CreatePlan(ctx, root)
+	must.NoError(err)
+
+	state, err := plan.Apply(ctx, WithPrune())
+	must.NoError(err)
+	is.Equal([]string{"a", "b"}, applier.applied)
+	must.Len(state.Report, 3)
+	is.Equal(domain.ActionServerSideApply, state.Report[0].Action)
+	must.Len(state.Report[0].Objects, 1)
+	is.Equal(domain.ObjectCreated, state.Report[0].Objects[0].Action)
+	is.Equal("ConfigMap/a created\n", string(state.Report[0].Output))
+	is.Equal("prune/crib/ConfigMap/removed", state.Report[2].Bundle)
+	is.Equal([]string{"removed"}, applier.deleted)
+
+	// Bundles are deleted in reverse order.
+	applier.deleted = nil
+	_, err = plan.Destroy(ctx)
+	must.NoError(err)
+	is.Equal([]string{"b", "a"}, applier.deleted)
+
+	// A failed object aborts the plan.
+	applier.fail = "a"
+	state, err = plan.Apply(ctx)
+	is.ErrorContains(err, "forbidden")
+	must.Len(state.Report, 1)
+	is.Equal(domain.StepStatusAborted, state.Report[0].Status)
+	is.Equal("ConfigMap/a failed: forbidden\n", string(state.Report[0].Output))
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/aws/constructs-go/constructs/v10"
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// Graph returns the full construct tree of the plan with the dependencies declared between constructs,
+// e.g. with AddDependency. Every plan is a node of the graph, and each plan depends on its child plans.
+//
+// The dependencies that CreatePlan adds from the components of a plan to the components of its child
+// plans are represented by the dependencies between the plans, so they are left out, as are the
+// dependencies implied by a dependency between ancestors.
+func (a *AppPlan) Graph() *domain.PlanGraph {
+	// cdk8s has some level of globally shared state, so we need to acquire a lock.
+	mu.Lock()
+	defer mu.Unlock()
+
+	graph := &domain.PlanGraph{
+		Plan:      a.RootPlan.Name(),
+		Namespace: a.RootPlan.Namespace(),
+	}
+	var (
+		nodes = make(map[string]constructs.IConstruct)
+		// componentPlan is the plan of each component, by the ID of its node.
+		componentPlan = make(map[string]string)
+	)
+	for _, plan := range planOrder(a.RootPlan) {
+		planID := domain.PlanNodePrefix + plan.Name()
+		graph.Nodes = append(graph.Nodes, domain.PlanNode{
+			ID:        planID,
+			Name:      plan.Name(),
+			Kind:      domain.PlanNodeKindPlan,
+			Namespace: plan.Namespace(),
+		})
+		for _, child := range plan.ChildPlans() {
+			graph.Edges = append(graph.Edges, domain.PlanEdge{From: planID, To: domain.PlanNodePrefix + child.Name()})
+		}
+		for _, component := range a.components[plan.Name()] {
+			componentPlan[*component.Node().Path()] = plan.Name()
+			addConstruct(graph, nodes, component, planID, plan.Namespace())
+		}
+	}
+
+	// Collect the dependencies between the constructs of the graph. Those between a construct and its
+	// own ancestors do not order anything, so they are left out.
+	var deps []domain.PlanEdge
+	for _, node := range graph.Nodes {
+		c, ok := nodes[node.ID]
+		if !ok {
+			continue
+		}
+		for _, dep := range *c.Node().Dependencies() {
+			to := *dep.Node().Path()
+			if _, ok := nodes[to]; ok && !isDescendant(to, node.ID) && !isDescendant(node.ID, to) {
+				deps = append(deps, domain.PlanEdge{From: node.ID, To: to})
+			}
+		}
+	}
+	for _, dep := range deps {
+		if from, ok := componentPlan[dep.From]; ok && componentPlan[dep.To] != "" && componentPlan[dep.To] != from {
+			continue
+		}
+		if !impliedEdge(deps, dep) {
+			graph.Edges = append(graph.Edges, dep)
+		}
+	}
+	return graph
+}
+
+// impliedEdge reports whether the dependency follows from another one between the same constructs or
+// their ancestors. cdk8s copies the dependencies between charts to the resources of the charts when the
+// app is synthesized, so these copies are left out of the graph.
+func impliedEdge(deps []domain.PlanEdge, dep domain.PlanEdge) bool {
+	for _, other := range deps {
+		if other != dep &&
+			(other.From == dep.From || isDescendant(dep.From, other.From)) &&
+			(other.To == dep.To || isDescendant(dep.To, other.To)) {
+			return true
+		}
+	}
+	return false
+}
+
+// addConstruct adds the construct and all of its descendants to the graph, below the given parent.
+// Constructs without a namespace of their own inherit the namespace of their parent.
+func addConstruct(graph *domain.PlanGraph, nodes map[string]constructs.IConstruct, c constructs.IConstruct, parent, namespace string) {
+	node := domain.PlanNode{
+		ID:        *c.Node().Path(),
+		Name:      *c.Node().Id(),
+		Parent:    parent,
+		Kind:      domain.PlanNodeKindConstruct,
+		Namespace: namespace,
+	}
+	switch {
+	case *cdk8s.ApiObject_IsApiObject(c):
+		obj := c.(cdk8s.ApiObject)
+		node.Kind = *obj.Kind()
+		if ns := obj.Metadata().Namespace(); ns != nil && *ns != "" {
+			node.Namespace = *ns
+		}
+		node.Resource = node.Kind + "/" + *obj.Name()
+	case *cdk8s.Chart_IsChart(c):
+		node.Kind = domain.PlanNodeKindChart
+		if ns := c.(cdk8s.Chart).Namespace(); ns != nil && *ns != "" {
+			node.Namespace = *ns
+		}
+	default:
+		if helm, ok := c.(cdk8s.Helm); ok {
+			node.Kind = domain.PlanNodeKindHelm
+			node.Resource = "release/" + *helm.ReleaseName()
+		} else if _, ok := c.(cdk8s.Include); ok {
+			node.Kind = domain.PlanNodeKindInclude
+		}
+	}
+	graph.Nodes = append(graph.Nodes, node)
+	nodes[node.ID] = c
+
+	for _, child := range *c.Node().Children() {
+		if child != nil {
+			addConstruct(graph, nodes, child, node.ID, node.Namespace)
+		}
+	}
+}
+
+// isDescendant reports whether the construct path is below the ancestor path.
+func isDescendant(path, ancestor string) bool {
+	return strings.HasPrefix(path, ancestor+"/")
+}
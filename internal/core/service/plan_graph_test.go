@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal"
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+func TestAppPlanGraph(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+
+	ctx := t.Context()
+	fh, err := filehandler.New(ctx, t.TempDir())
+	must.NoError(err)
+
+	// component returns a component that renders a chart with a ConfigMap nested in a plain construct,
+	// and a Secret in its own namespace that depends on the ConfigMap. The chart depends on the charts of
+	// the given sibling components.
+	component := func(name string, deps ...string) port.ComponentFunc {
+		return func(ctx context.Context) (port.Component, error) {
+			parent := internal.ConstructFromContext(ctx)
+			chart := cdk8s.NewChart(parent, dry.ToPtr(name), nil)
+			group := cdk8s.NewChart(chart, dry.ToPtr("group"), &cdk8s.ChartProps{Namespace: dry.ToPtr("group-ns")})
+			cm := cdk8s.NewApiObject(group, dry.ToPtr("cm"), &cdk8s.ApiObjectProps{
+				ApiVersion: dry.ToPtr("v1"),
+				Kind:       dry.ToPtr("ConfigMap"),
+				Metadata:   &cdk8s.ApiObjectMetadata{Name: dry.ToPtr(name)},
+			})
+			secret := cdk8s.NewApiObject(chart, dry.ToPtr("secret"), &cdk8s.ApiObjectProps{
+				ApiVersion: dry.ToPtr("v1"),
+				Kind:       dry.ToPtr("Secret"),
+				Metadata:   &cdk8s.ApiObjectMetadata{Name: dry.ToPtr(name), Namespace: dry.ToPtr("secrets")},
+			})
+			secret.Node().AddDependency(cm)
+			for _, dep := range deps {
+				chart.Node().AddDependency(parent.Node().FindChild(dry.ToPtr(dep)))
+			}
+			return chart, nil
+		}
+	}
+	child := testPlanner{name: "child", namespace: "child-ns", components: []port.ComponentFunc{component("c")}}
+	root := testPlanner{
+		name:       "root",
+		namespace:  "crib",
+		components: []port.ComponentFunc{component("a"), component("b", "a")},
+		children:   []port.Planner{child},
+	}
+
+	svc, err := NewPlanService(ctx, fh)
+	must.NoError(err)
+	plan, err := svc.CreatePlan(ctx, root)
+	must.NoError(err)
+
+	graph := plan.Graph()
+	is := assert.New(t)
+	is.Equal("root", graph.Plan)
+	is.Equal("crib", graph.Namespace)
+
+	// Index the nodes by their path within their plan, e.g. "a/group/cm".
+	nodes := make(map[string]domain.PlanNode)
+	ids := make(map[string]string)
+	for _, n := range graph.Nodes {
+		key := n.Name
+		for parent, ok := graph.Node(n.Parent); ok && !parent.IsPlan(); parent, ok = graph.Node(parent.Parent) {
+			key = parent.Name + "/" + key
+		}
+		nodes[key] = n
+		ids[n.ID] = key
+	}
+
+	is.Equal(domain.PlanNode{ID: "plan:root", Name: "root", Kind: domain.PlanNodeKindPlan, Namespace: "crib"}, nodes["root"])
+	is.Equal(domain.PlanNode{ID: "plan:child", Name: "child", Kind: domain.PlanNodeKindPlan, Namespace: "child-ns"}, nodes["child"])
+	is.Equal("plan:root", nodes["a"].Parent)
+	is.Equal("plan:child", nodes["c"].Parent)
+	is.Equal(domain.PlanNodeKindChart, nodes["a"].Kind)
+	is.Equal("crib", nodes["a"].Namespace)
+	is.Equal("child-ns", nodes["c"].Namespace)
+
+	is.Equal(domain.PlanNodeKindChart, nodes["a/group"].Kind)
+	is.Equal("group-ns", nodes["a/group"].Namespace)
+	is.Equal("ConfigMap", nodes["a/group/cm"].Kind)
+	is.Equal("group-ns", nodes["a/group/cm"].Namespace)
+	is.Equal("ConfigMap/a", nodes["a/group/cm"].Resource)
+	is.Equal("Secret", nodes["a/secret"].Kind)
+	is.Equal("secrets", nodes["a/secret"].Namespace)
+	is.Equal("Secret/a", nodes["a/secret"].Resource)
+
+	// Dependencies are named by node IDs. The dependencies from the components of the root plan to the
+	// components of the child plan are implied by the dependency of the root plan on the child plan.
+	edges := make([][2]string, len(graph.Edges))
+	for i, e := range graph.Edges {
+		edges[i] = [2]string{ids[e.From], ids[e.To]}
+	}
+	is.ElementsMatch([][2]string{
+		{"root", "child"},
+		{"a/secret", "a/group/cm"},
+		{"b", "a"},
+		{"b/secret", "b/group/cm"},
+		{"c/secret", "c/group/cm"},
+	}, edges)
+
+	preview := plan.Preview(ctx)
+	is.Contains(preview, "cm (ConfigMap, namespace: group-ns, renders: ConfigMap/a)")
+	is.Contains(preview, "secret (Secret, namespace: secrets, renders: Secret/a)")
+	is.Contains(preview, "Plan: child.child-ns")
+	is.Contains(preview, "- b depends on a")
+}
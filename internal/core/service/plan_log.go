@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/clientsideapply"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// logDir is the directory of the render directory that holds the log file of each bundle.
+const logDir = "logs"
+
+// logName returns the path of the log file of the bundle, relative to the render directory. It follows
+// the path of the first manifest of the bundle, e.g. logs/02-register/00-cmd.log.
+func (b ManifestBundle) logName() string {
+	if len(b.manifests) == 0 {
+		return ""
+	}
+	name := b.manifests[0].Name
+	return filepath.Join(logDir, strings.TrimSuffix(name, filepath.Ext(name))+".log")
+}
+
+// applyWithLog applies the bundle like apply, writing its output to the log file of the bundle. Bundles
+// whose output is not produced by a runner, e.g. Wait bundles, have their reported output written once
+// they were applied. The bundle is applied without a log file if the file cannot be created.
+// Registered secrets are masked in the log and the report.
+func (b ManifestBundle) applyWithLog(ctx context.Context, p *PlanService, opts ...clientsideapply.RunnerOpt) domain.BundleReport {
+	name := b.logName()
+	f, err := p.createLog(name)
+	if err != nil {
+		r := b.apply(ctx, p, opts...)
+		r.Redact()
+		return r
+	}
+	defer f.Close()
+
+	log := &countingWriter{w: f}
+	r := b.apply(ctx, p, append(opts, clientsideapply.WithLog(log))...)
+	r.Redact()
+	if log.n == 0 {
+		_, _ = log.Write(r.Output)
+	}
+	r.LogFile = p.fh.AbsPathFor(name)
+	return r
+}
+
+// createLog creates or truncates the named log file, along with its directory.
+func (p *PlanService) createLog(name string) (*os.File, error) {
+	if name == "" {
+		return nil, os.ErrInvalid
+	}
+	if err := p.fh.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return nil, err
+	}
+	return p.fh.Create(name)
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += n
+	return n, err
+}
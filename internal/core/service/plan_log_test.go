@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// recordingProgress records the progress of an apply as "started <bundle>" and "<status> <bundle>".
+type recordingProgress struct {
+	events []string
+}
+
+func (p *recordingProgress) BundleStarted(r domain.BundleReport) {
+	p.events = append(p.events, "started "+r.Bundle)
+}
+
+func (p *recordingProgress) BundleFinished(r domain.BundleReport) {
+	p.events = append(p.events, r.Status+" "+r.Bundle)
+}
+
+func TestApplyPlanLogs(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	must := require.New(t)
+
+	progress := &recordingProgress{}
+	fh := setup(t, "testdata/plan/manifests/failures")
+	plan := &AppPlan{
+		svc:      &PlanService{fh: fh, progress: progress, quiet: true},
+		RootPlan: testPlanner{name: "failures"},
+	}
+
+	state, err := plan.Apply(t.Context())
+	must.Error(err)
+	must.Len(state.Report, 3)
+	is.Equal([]string{
+		"started continue/00-cmd.yaml",
+		"continued continue/00-cmd.yaml",
+		"started succeed/00-cmd.yaml",
+		"succeeded succeed/00-cmd.yaml",
+		"started abort/00-cmd.yaml",
+		"aborted abort/00-cmd.yaml",
+	}, progress.events)
+
+	// Each bundle has its own log file, following the path of its manifest.
+	for i, log := range []struct{ name, output string }{
+		{name: "logs/00-continue/00-cmd.log", output: "continue: failing\n"},
+		{name: "logs/01-succeed/00-cmd.log", output: "succeed: ok\n"},
+		{name: "logs/02-abort/00-cmd.log", output: "abort: failing\n"},
+	} {
+		raw, err := fh.ReadFile(log.name)
+		must.NoError(err)
+		is.Equal(log.output, string(raw))
+		is.Equal(fh.AbsPathFor(log.name), state.Report[i].LogFile)
+		is.Equal(log.output, string(state.Report[i].Output))
+	}
+	is.False(fh.FileExists("logs/03-skipped/00-cmd.log"), "bundles that are not applied have no log")
+}
+
+func TestApplyPlanRedactsSecrets(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	must := require.New(t)
+
+	domain.RegisterSecret("hunter2-redact-test")
+	fh := setup(t, "testdata/plan/manifests/secrets")
+	plan := &AppPlan{
+		svc:      &PlanService{fh: fh, quiet: true},
+		RootPlan: testPlanner{name: "secrets"},
+	}
+
+	state, err := plan.Apply(t.Context())
+	must.Error(err)
+	is.NotContains(err.Error(), "hunter2-redact-test")
+	is.ErrorIs(err, domain.ErrAbort)
+	must.Len(state.Report, 1)
+	is.Equal("logging in with ******\n", string(state.Report[0].Output))
+	is.Contains(state.Report[0].Err.Error(), "logging in with ******")
+
+	raw, err := fh.ReadFile("logs/00-login/00-cmd.log")
+	must.NoError(err)
+	is.Equal("logging in with ******\n", string(raw))
+}
@@ -3,10 +3,15 @@ package service
 import (
 	"testing"
 
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/planstate"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
 )
 
 func Test_findManifests(t *testing.T) {
@@ -346,6 +351,186 @@ func TestApplyPlan(t *testing.T) {
 	assert.NotNil(t, res)
 }
 
+func TestApplyPlanReport(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	must := require.New(t)
+
+	ctx := t.Context()
+	plan := &AppPlan{
+		svc: &PlanService{fh: setup(t, "testdata/plan/manifests/failures")},
+	}
+
+	state, err := plan.Apply(ctx)
+	must.Error(err)
+	is.ErrorIs(err, domain.ErrContinue)
+	is.ErrorIs(err, domain.ErrAbort)
+	is.ErrorContains(err, "2 of 3 bundles failed")
+	must.NotNil(state, "the state is returned even when bundles fail")
+
+	// The bundle after the aborted bundle is not processed.
+	must.Len(state.Report, 3)
+	tests := []struct {
+		bundle   string
+		status   string
+		exitCode int
+		output   string
+	}{
+		{bundle: "continue/00-cmd.yaml", status: domain.StepStatusContinued, exitCode: 2, output: "continue: failing"},
+		{bundle: "succeed/00-cmd.yaml", status: domain.StepStatusSucceeded, exitCode: 0, output: "succeed: ok"},
+		{bundle: "abort/00-cmd.yaml", status: domain.StepStatusAborted, exitCode: 3, output: "abort: failing"},
+	}
+	for i, tc := range tests {
+		r := state.Report[i]
+		is.Equal(tc.bundle, r.Bundle)
+		is.Contains(r.Path, tc.bundle)
+		is.Equal(domain.ActionCmd, r.Action, tc.bundle)
+		is.Equal(tc.status, r.Status, tc.bundle)
+		is.Equal(tc.exitCode, r.ExitCode, tc.bundle)
+		is.Contains(string(r.Output), tc.output, tc.bundle)
+		is.Equal(tc.status != domain.StepStatusSucceeded, r.Err != nil, tc.bundle)
+		is.Positive(r.Duration, tc.bundle)
+	}
+}
+
+func TestApplyPlanRecord(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	must := require.New(t)
+
+	ctx := t.Context()
+	store, err := planstate.NewFileStore(ctx, t.TempDir())
+	must.NoError(err)
+	plan := &AppPlan{
+		svc:      &PlanService{fh: setup(t, "testdata/plan/manifests/basic"), store: store},
+		RootPlan: testPlanner{name: "basic", namespace: "crib"},
+	}
+
+	_, err = plan.Apply(ctx)
+	must.NoError(err)
+
+	record, err := store.Load(ctx, "basic", "crib")
+	must.NoError(err)
+	is.Equal("basic", record.Name)
+	is.Equal("crib", record.Namespace)
+	is.False(record.AppliedAt.IsZero())
+	must.Len(record.Bundles, 8)
+	is.Equal("00/00-a.yaml", record.Bundles[0].Key)
+	is.Equal("client_side_apply/00-cmd.yaml", record.Bundles[4].Key)
+	is.True(record.Bundles[4].IsLocal)
+	for _, b := range record.Bundles {
+		is.NotEmpty(b.Hash, b.Key)
+		if is.NotNil(b.Step, b.Key) {
+			is.Equal(domain.StepStatusSucceeded, b.Step.Status, b.Key)
+		}
+	}
+
+	// A fresh render of the same manifests has not drifted.
+	drift, err := plan.Drift(ctx)
+	must.NoError(err)
+	is.False(drift.HasDrift(), "%+v", drift)
+
+	// Changing a manifest is detected as drift of its bundle.
+	must.NoError(plan.svc.fh.WriteFile("01/00-c.yaml", []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: changed\n")))
+	drift, err = plan.Drift(ctx)
+	must.NoError(err)
+	is.Equal([]string{"01/00-c.yaml"}, drift.Changed)
+
+	// The same plan applied to another namespace keeps a record of its own.
+	other := &AppPlan{
+		svc:      &PlanService{fh: setup(t, "testdata/plan/manifests/basic"), store: store},
+		RootPlan: testPlanner{name: "basic", namespace: "other"},
+	}
+	_, err = other.Apply(ctx)
+	must.NoError(err)
+	_, err = other.Destroy(ctx)
+	must.NoError(err)
+	_, err = store.Load(ctx, "basic", "other")
+	is.ErrorIs(err, domain.ErrPlanRecordNotFound)
+	record, err = store.Load(ctx, "basic", "crib")
+	must.NoError(err)
+	is.Equal("crib", record.Namespace)
+
+	// Destroying the plan forgets its record.
+	_, err = plan.Destroy(ctx)
+	must.NoError(err)
+	_, err = store.Load(ctx, "basic", "crib")
+	is.ErrorIs(err, domain.ErrPlanRecordNotFound)
+}
+
+func TestApplyPlanResume(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	must := require.New(t)
+
+	ctx := t.Context()
+	store, err := planstate.NewFileStore(ctx, t.TempDir())
+	must.NoError(err)
+	plan := &AppPlan{
+		svc:      &PlanService{fh: setup(t, "testdata/plan/manifests/failures"), store: store},
+		RootPlan: testPlanner{name: "failures"},
+	}
+	statuses := func(state *PlanState) []string {
+		return lo.Map(state.Report, func(r domain.BundleReport, _ int) string { return r.Status })
+	}
+
+	// The first apply aborts at the third bundle.
+	state, err := plan.Apply(ctx)
+	must.Error(err)
+	is.Equal([]string{domain.StepStatusContinued, domain.StepStatusSucceeded, domain.StepStatusAborted}, statuses(state))
+
+	// Resuming skips the bundle that succeeded, and retries the failed ones.
+	state, err = plan.Apply(ctx, WithResume())
+	must.Error(err)
+	is.Equal([]string{domain.StepStatusContinued, domain.StepStatusSkipped, domain.StepStatusAborted}, statuses(state))
+	record, err := store.Load(ctx, "failures", "")
+	must.NoError(err)
+	is.Equal(domain.StepStatusSucceeded, record.Bundles[1].Step.Status, "skipped bundles keep their previous step")
+
+	// Once the aborting bundle is fixed, resuming continues with it and the bundles after it.
+	must.NoError(plan.svc.fh.WriteFile("02-abort/00-cmd.yaml", []byte(`apiVersion: crib.smartcontract.com/v1alpha1
+kind: ClientSideApply
+spec:
+  onFailure: abort
+  action: cmd
+  args:
+    - 'echo "abort: fixed"'
+`)))
+	state, err = plan.Apply(ctx, WithResume())
+	must.Error(err, "the continued bundle still fails")
+	is.Equal([]string{
+		domain.StepStatusContinued, domain.StepStatusSkipped, domain.StepStatusSucceeded, domain.StepStatusSucceeded,
+	}, statuses(state))
+
+	// A range of bundles is selected by key or by directory.
+	state, err = plan.Apply(ctx, WithFrom("succeed"), WithTo("abort/00-cmd.yaml"))
+	must.NoError(err)
+	is.Equal([]string{
+		domain.StepStatusSkipped, domain.StepStatusSucceeded, domain.StepStatusSucceeded, domain.StepStatusSkipped,
+	}, statuses(state))
+
+	_, err = plan.Apply(ctx, WithFrom("missing"))
+	is.ErrorContains(err, `no bundle matches "missing"`)
+	_, err = plan.Apply(ctx, WithFrom("abort"), WithTo("succeed"))
+	is.ErrorContains(err, "comes after")
+
+	// Resuming requires a state store.
+	_, err = (&AppPlan{svc: &PlanService{fh: plan.svc.fh}}).Apply(ctx, WithResume())
+	is.ErrorContains(err, "requires a plan state store")
+}
+
+func TestMatchBundle(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	const key = "plan-sdk.namespace-1234abcd/Namespace.foo.k8s.yaml"
+	is.True(matchBundle(key, key))
+	is.True(matchBundle(key, "plan-sdk.namespace-1234abcd"))
+	is.True(matchBundle(key, "plan-sdk.namespace-1234abcd/"))
+	is.False(matchBundle(key, "plan-sdk"))
+	is.False(matchBundle(key, "Namespace.foo.k8s.yaml"))
+}
+
 func TestApply(t *testing.T) {
 	t.Parallel()
 
@@ -422,6 +607,98 @@ func TestApply(t *testing.T) {
 	}
 }
 
+func TestDestroyPlan(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	fh := setup(t, "testdata/plan/manifests/basic")
+	ps := &PlanService{
+		fh: fh,
+	}
+	plan := &AppPlan{
+		svc: ps,
+	}
+
+	// Destroy the plan.
+	res, err := plan.Destroy(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func TestUndo(t *testing.T) {
+	t.Parallel()
+
+	fh := setup(t, "testdata/plan/manifests/basic")
+	ps := &PlanService{
+		fh: fh,
+	}
+
+	tests := []struct {
+		name   string
+		bundle ManifestBundle
+		want   *domain.ClientSideApplySpec
+	}{
+		{
+			name: "remote",
+			bundle: ManifestBundle{
+				root: "root",
+				manifests: []Manifest{
+					{Name: "00/00-a.yaml"},
+					{Name: "00/01-b.yaml"},
+				},
+			},
+			want: &domain.ClientSideApplySpec{
+				OnFailure: domain.FailureContinue,
+				Action:    domain.ActionKubectl,
+				Args: []string{
+					"delete",
+					"-f", "root/00/00-a.yaml,root/00/01-b.yaml",
+					"--ignore-not-found",
+					"--wait",
+				},
+			},
+		},
+		{
+			name: "local with undo",
+			bundle: ManifestBundle{
+				isLocal: true,
+				manifests: []Manifest{
+					{Name: "02-client_side_apply/00-cmd.yaml", IsLocal: true},
+				},
+			},
+			want: &domain.ClientSideApplySpec{
+				OnFailure: domain.FailureAbort,
+				Action:    domain.ActionCmd,
+				Args:      []string{`echo "cmd: undo"`},
+			},
+		},
+		{
+			name: "local without undo",
+			bundle: ManifestBundle{
+				isLocal: true,
+				manifests: []Manifest{
+					{Name: "02-client_side_apply/01-task.yaml", IsLocal: true},
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := tc.bundle.Undo(ps)
+			require.NoError(t, err)
+			if tc.want == nil {
+				assert.Nil(t, m)
+				return
+			}
+			require.NotNil(t, m)
+			assert.Equal(t, *tc.want, m.Spec)
+		})
+	}
+}
+
 func TestManifestBundleString(t *testing.T) {
 	t.Parallel()
 
@@ -437,6 +714,96 @@ func TestManifestBundleString(t *testing.T) {
 	assert.Equal(t, expected, mb.String())
 }
 
+func TestStableName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"0003-plan-sdk.namespace-1234abcd/Namespace.foo.k8s.yaml": "plan-sdk.namespace-1234abcd/Namespace.foo.k8s.yaml",
+		"02-client_side_apply/00-cmd.yaml":                        "client_side_apply/00-cmd.yaml",
+		"00/00-a.yaml":                                            "00/00-a.yaml",
+		"plan-sdk/Namespace.yaml":                                 "plan-sdk/Namespace.yaml",
+		"Namespace.yaml":                                          "Namespace.yaml",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, stableName(name), name)
+	}
+}
+
+func TestChartVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		doc         domain.GenericManifest
+		wantName    string
+		wantVersion string
+		wantOK      bool
+	}{
+		{
+			name: "label",
+			doc: domain.GenericManifest{"metadata": map[string]any{"labels": map[string]any{
+				"helm.crib.sdk/name":  "db",
+				"helm.crib.sdk/chart": "postgresql",
+				"helm.sh/chart":       "postgresql-16.7.10",
+			}}},
+			wantName:    "db",
+			wantVersion: "16.7.10",
+			wantOK:      true,
+		},
+		{
+			name: "annotation",
+			doc: domain.GenericManifest{"metadata": map[string]any{
+				"labels": map[string]any{
+					"helm.crib.sdk/name":  "test-chart",
+					"helm.crib.sdk/chart": "component-chart",
+				},
+				"annotations": map[string]any{"helm.sh/chart": "component-chart-0.9.1"},
+			}},
+			wantName:    "test-chart",
+			wantVersion: "0.9.1",
+			wantOK:      true,
+		},
+		{
+			name: "subchart",
+			doc: domain.GenericManifest{"metadata": map[string]any{"labels": map[string]any{
+				"helm.crib.sdk/name":  "app",
+				"helm.crib.sdk/chart": "app",
+				"helm.sh/chart":       "redis-1.0.0",
+			}}},
+		},
+		{
+			name: "not a helm chart",
+			doc:  domain.GenericManifest{"metadata": map[string]any{"name": "foo"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, version, ok := chartVersion(tc.doc)
+			assert.Equal(t, tc.wantOK, ok)
+			if tc.wantOK {
+				assert.Equal(t, tc.wantName, name)
+				assert.Equal(t, tc.wantVersion, version)
+			}
+		})
+	}
+}
+
+// testPlanner is a minimal port.Planner used to name an AppPlan, or to create one with CreatePlan.
+type testPlanner struct {
+	name, namespace string
+	components      []port.ComponentFunc
+	children        []port.Planner
+}
+
+func (p testPlanner) Name() string                     { return p.name }
+func (p testPlanner) Namespace() string                { return p.namespace }
+func (p testPlanner) Components() []port.ComponentFunc { return p.components }
+func (p testPlanner) ChildPlans() []port.Planner       { return p.children }
+func (testPlanner) Resolvers() []cdk8s.IResolver       { return nil }
+func (p testPlanner) Build() port.Planner              { return p }
+
 // setup is a helper that copies the test data to a temporary directory.
 func setup(t *testing.T, basePath string) *filehandler.Handler {
 	t.Helper()
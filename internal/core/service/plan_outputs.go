@@ -0,0 +1,230 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// stepOutputs holds the outputs produced by the ClientSideApply steps of an apply, keyed by name.
+// Bundles may be applied concurrently.
+type stepOutputs struct {
+	mu sync.Mutex
+	m  map[string]string
+}
+
+type outputsKey struct{}
+
+// contextWithOutputs returns a context carrying the outputs of the applied plan.
+func contextWithOutputs(ctx context.Context, o *stepOutputs) context.Context {
+	return context.WithValue(ctx, outputsKey{}, o)
+}
+
+// outputsFromContext returns the outputs carried by the context. Bundles applied on their own get
+// an empty set of outputs.
+func outputsFromContext(ctx context.Context) *stepOutputs {
+	if o, ok := ctx.Value(outputsKey{}).(*stepOutputs); ok {
+		return o
+	}
+	return &stepOutputs{}
+}
+
+// recordedOutputs returns the outputs recorded for the bundles of the plan. If skip is given, only the
+// outputs of the skipped bundles are returned, since the others are produced again.
+func recordedOutputs(record *domain.PlanRecord, skip []bool) *stepOutputs {
+	o := &stepOutputs{}
+	if record == nil {
+		return o
+	}
+	for i, b := range record.Bundles {
+		if b.Step != nil && (skip == nil || skip[i]) {
+			o.add(b.Step.Outputs)
+		}
+	}
+	return o
+}
+
+// values returns a copy of the outputs.
+func (o *stepOutputs) values() map[string]string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return maps.Clone(o.m)
+}
+
+// add adds the outputs of a step, replacing outputs of the same name.
+func (o *stepOutputs) add(outputs map[string]string) {
+	if len(outputs) == 0 {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.m == nil {
+		o.m = make(map[string]string, len(outputs))
+	}
+	maps.Copy(o.m, outputs)
+}
+
+// resolveOutputs returns the remote bundle with the output placeholders in its manifests replaced with the
+// outputs produced so far. The resolved manifests are kept in memory and applied from there, so that the
+// rendered files still match the plan and never hold the values of outputs.
+func (b ManifestBundle) resolveOutputs(ctx context.Context, p *PlanService) (ManifestBundle, error) {
+	var (
+		buf      bytes.Buffer
+		resolved bool
+	)
+	for _, m := range b.manifests {
+		raw, err := p.fh.ReadFile(m.Name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return b, nil // Reported by the applier.
+		}
+		if err != nil {
+			return b, fmt.Errorf("failed to read manifest %s: %w", m.Name, err)
+		}
+		if domain.HasOutputPlaceholder(raw) {
+			s, err := domain.ResolveOutputs(string(raw), outputsFromContext(ctx).values())
+			if err != nil {
+				return b, fmt.Errorf("unable to resolve the outputs referenced by %s: %w", m.Name, err)
+			}
+			raw, resolved = []byte(s), true
+		}
+		// Every manifest starts a new document, empty documents are ignored.
+		buf.WriteString("---\n")
+		buf.Write(raw)
+		if !bytes.HasSuffix(raw, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+	}
+	if resolved {
+		b.resolved = buf.Bytes()
+	}
+	return b, nil
+}
+
+// outputEdges adds an edge to the graph from each bundle referencing an output to the last earlier bundle
+// declaring it, so that the output is produced before it is referenced. Only earlier bundles are
+// considered, which keeps the graph acyclic. Outputs that no earlier bundle declares are provided by the
+// record of the plan, or reported as undefined when the bundle is applied.
+func (a *AppPlan) outputEdges(bundles []ManifestBundle, graph [][]int) {
+	declared := make(map[string]int)
+	for i, b := range bundles {
+		for _, name := range b.outputReferences(a.svc) {
+			if j, ok := declared[name]; ok && !slices.Contains(graph[i], j) {
+				graph[i] = append(graph[i], j)
+			}
+		}
+		for _, o := range b.declaredOutputs(a.svc) {
+			declared[o.Name] = i
+		}
+	}
+}
+
+// checkSkippedOutputs errors if a bundle that is applied references a sensitive output of a skipped bundle.
+// Sensitive outputs are not recorded, so only applying the step declaring them provides them.
+func (a *AppPlan) checkSkippedOutputs(bundles []ManifestBundle, skip []bool) error {
+	declared := make(map[string]int)
+	var errs error
+	for i, b := range bundles {
+		if !skip[i] {
+			for _, name := range b.outputReferences(a.svc) {
+				j, ok := declared[name]
+				if ok && skip[j] && bundles[j].declaresSensitive(a.svc, name) {
+					errs = errors.Join(errs, fmt.Errorf("bundle %s references the sensitive output %s of bundle %s, "+
+						"which is skipped: sensitive outputs are not recorded, so %[3]s must be applied as well",
+						b.Key(), name, bundles[j].Key()))
+				}
+			}
+		}
+		for _, o := range b.declaredOutputs(a.svc) {
+			declared[o.Name] = i
+		}
+	}
+	return errs
+}
+
+// destroyOutputs returns the outputs that the bundles are destroyed with. Sensitive outputs are not
+// recorded, so undo steps referencing them are rejected. Remote bundles are deleted by name, and keep
+// the placeholders of sensitive outputs as they are.
+func (a *AppPlan) destroyOutputs(bundles []ManifestBundle, record *domain.PlanRecord) (*stepOutputs, error) {
+	sensitive := a.sensitiveOutputs(bundles)
+	var errs error
+	for _, b := range bundles {
+		if !b.isLocal {
+			continue
+		}
+		m, err := b.clientSideApply(a.svc)
+		if err != nil || m.Spec.Undo == nil {
+			continue // Unreadable manifests are reported when the bundle is destroyed.
+		}
+		for _, name := range m.UndoManifest().Spec.OutputReferences() {
+			if _, ok := sensitive[name]; ok {
+				errs = errors.Join(errs, fmt.Errorf("the undo step of bundle %s references the sensitive output %s: "+
+					"sensitive outputs are not recorded, so they are undefined when the plan is destroyed", b.Key(), name))
+			}
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	outputs := &stepOutputs{}
+	outputs.add(sensitive)
+	outputs.add(recordedOutputs(record, nil).values())
+	return outputs, nil
+}
+
+// sensitiveOutputs returns the names of the sensitive outputs declared by the bundles, mapped to their
+// placeholder.
+func (a *AppPlan) sensitiveOutputs(bundles []ManifestBundle) map[string]string {
+	sensitive := make(map[string]string)
+	for _, b := range bundles {
+		for _, o := range b.declaredOutputs(a.svc) {
+			if o.Sensitive {
+				sensitive[o.Name] = domain.OutputPlaceholder(o.Name)
+			}
+		}
+	}
+	return sensitive
+}
+
+// declaredOutputs returns the outputs declared by the step of a local bundle.
+func (b ManifestBundle) declaredOutputs(p *PlanService) []domain.ClientSideApplyOutput {
+	if !b.isLocal {
+		return nil
+	}
+	// Unreadable manifests are reported when the bundle is applied.
+	m, err := b.clientSideApply(p)
+	if err != nil {
+		return nil
+	}
+	return m.Spec.Outputs
+}
+
+// declaresSensitive reports whether the step of the bundle declares the named output as sensitive.
+func (b ManifestBundle) declaresSensitive(p *PlanService, name string) bool {
+	return slices.ContainsFunc(b.declaredOutputs(p), func(o domain.ClientSideApplyOutput) bool {
+		return o.Name == name && o.Sensitive
+	})
+}
+
+// outputReferences returns the names of the outputs referenced by the manifests of the bundle.
+func (b ManifestBundle) outputReferences(p *PlanService) []string {
+	var names []string
+	for _, m := range b.manifests {
+		raw, err := p.fh.ReadFile(m.Name)
+		if err != nil {
+			continue // Reported when the bundle is applied.
+		}
+		for _, name := range domain.OutputReferences(raw) {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
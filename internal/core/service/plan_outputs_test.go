@@ -0,0 +1,110 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/planstate"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+func TestApplyPlanOutputs(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+	must := require.New(t)
+
+	ctx := t.Context()
+	store, err := planstate.NewFileStore(ctx, t.TempDir())
+	must.NoError(err)
+	plan := &AppPlan{
+		svc:      &PlanService{fh: setup(t, "testdata/plan/manifests/outputs"), store: store},
+		RootPlan: testPlanner{name: "outputs"},
+	}
+
+	state, err := plan.Apply(ctx)
+	must.NoError(err)
+	must.Len(state.Report, 4)
+	is.Equal(map[string]string{"address": "0xabc"}, state.Report[0].Outputs)
+	is.Contains(string(state.Report[2].Output), "registering 0xabc")
+
+	// Manifests are applied with the outputs resolved, which leaves the rendered files untouched.
+	is.Equal("kubectl apply -f - --wait\n", string(state.Report[1].Output))
+	raw, err := plan.svc.fh.ReadFile("01-config/00-configmap.yaml")
+	must.NoError(err)
+	is.Contains(string(raw), `address: "${{ outputs.address }}"`)
+
+	// Sensitive outputs are masked, and neither reported nor recorded.
+	is.NotContains(string(state.Report[0].Output), "outputs-test-token")
+	record, err := store.Load(ctx, "outputs", "")
+	must.NoError(err)
+	is.Equal(map[string]string{"address": "0xabc"}, record.Bundles[0].Step.Outputs)
+	is.NotContains(record.Bundles[0].Step.Output, "outputs-test-token")
+
+	// Live objects are compared with the recorded outputs resolved.
+	drift, err := plan.Drift(ctx)
+	must.NoError(err)
+	is.False(drift.HasDrift(), "%+v", drift)
+	outputs := record.Bundles[0].Step.Outputs
+	record.Bundles[0].Step.Outputs = nil
+	must.NoError(store.Save(ctx, record))
+	_, err = plan.Drift(ctx)
+	is.ErrorContains(err, "undefined outputs address")
+	record.Bundles[0].Step.Outputs = outputs
+	must.NoError(store.Save(ctx, record))
+
+	// Steps that are skipped provide the outputs of the last apply, and so do undo steps. Sensitive
+	// outputs are not recorded, so the steps declaring them cannot be skipped.
+	rerun := &AppPlan{
+		svc:      &PlanService{fh: setup(t, "testdata/plan/manifests/outputs"), store: store},
+		RootPlan: testPlanner{name: "outputs"},
+	}
+	_, err = rerun.Apply(ctx, WithFrom("register"))
+	is.ErrorContains(err, "bundle secret/00-secret.yaml references the sensitive output token of bundle "+
+		"deploy/00-cmd.yaml, which is skipped")
+	state, err = rerun.Apply(ctx, WithFrom("register"), WithTo("register"))
+	must.NoError(err)
+	is.Equal(domain.StepStatusSkipped, state.Report[0].Status)
+	is.Contains(string(state.Report[2].Output), "registering 0xabc")
+	_, err = rerun.Destroy(ctx)
+	is.NoError(err)
+	raw, err = rerun.svc.fh.ReadFile("01-config/00-configmap.yaml")
+	must.NoError(err)
+	is.Contains(string(raw), `address: "${{ outputs.address }}"`)
+
+	// Undo steps cannot reference sensitive outputs.
+	undo := &AppPlan{
+		svc:      &PlanService{fh: setup(t, "testdata/plan/manifests/outputs")},
+		RootPlan: testPlanner{name: "outputs"},
+	}
+	raw, err = undo.svc.fh.ReadFile("00-deploy/00-cmd.yaml")
+	must.NoError(err)
+	must.NoError(undo.svc.fh.WriteFile("00-deploy/00-cmd.yaml",
+		[]byte(strings.Replace(string(raw), "undeploy ${{ outputs.address }}", "undeploy ${{ outputs.token }}", 1))))
+	_, err = undo.Destroy(ctx)
+	is.ErrorContains(err, "the undo step of bundle deploy/00-cmd.yaml references the sensitive output token")
+
+	// The applier is given the resolved objects.
+	applier := &fakeApplier{}
+	served := &AppPlan{
+		svc:      &PlanService{fh: setup(t, "testdata/plan/manifests/outputs"), applier: applier},
+		RootPlan: testPlanner{name: "outputs"},
+	}
+	_, err = served.Apply(ctx)
+	must.NoError(err)
+	must.Len(applier.objects, 2)
+	is.Equal(domain.GenericManifest{"address": "0xabc"}, applier.objects[0]["data"])
+	is.Equal(domain.GenericManifest{"token": "outputs-test-token"}, applier.objects[1]["stringData"])
+
+	// Without a record, the outputs are undefined.
+	fresh := &AppPlan{
+		svc:      &PlanService{fh: setup(t, "testdata/plan/manifests/outputs")},
+		RootPlan: testPlanner{name: "outputs"},
+	}
+	state, err = fresh.Apply(ctx, WithFrom("config"), WithTo("config"))
+	is.ErrorContains(err, "undefined outputs address")
+	must.Len(state.Report, 2)
+	is.Equal(domain.StepStatusAborted, state.Report[1].Status)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/clientsideapply"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/infra"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// pruneBundle is the prefix of the reports of the objects deleted by prune.
+const pruneBundle = "prune/"
+
+// ownerLabels returns the labels that mark a rendered resource as owned by the applied plan, the instance
+// of the plan, and the component that rendered it. Resources created outside of a component are only
+// marked with the plan and its instance.
+func (a *AppPlan) ownerLabels(obj cdk8s.ApiObject) map[string]string {
+	if a.RootPlan == nil {
+		return nil
+	}
+	labels := map[string]string{
+		domain.LabelPlan:     domain.LabelValue(a.RootPlan.Name()),
+		domain.LabelInstance: domain.InstanceLabelValue(a.RootPlan.Name(), a.RootPlan.Namespace()),
+	}
+	path := *obj.Node().Path()
+	for _, components := range a.components {
+		for _, c := range components {
+			if p := *c.Node().Path(); path == p || isDescendant(path, p) {
+				labels[domain.LabelComponent] = domain.LabelValue(infra.ExtractResource(c.Node().Id()))
+			}
+		}
+	}
+	return labels
+}
+
+// prune deletes the objects in the cluster that carry the plan and instance labels of the plan, but are not
+// rendered by any of the bundles, e.g. because their component was removed from the plan. Objects of the
+// same plan applied to another namespace carry another instance label, so they are left alone. Each deleted
+// object is reported as a bundle named after the object. Namespaces are never pruned, since deleting a
+// namespace deletes everything in it, including the objects of other plans, and neither are the objects
+// that controllers create with the labels of a rendered object, e.g. the EndpointSlices of a Service.
+func (a *AppPlan) prune(ctx context.Context, bundles []ManifestBundle) []domain.BundleReport {
+	listObjects := a.svc.listObjects
+	switch {
+	case listObjects != nil:
+	case a.svc.applier != nil:
+		listObjects = a.svc.applier.List
+	default:
+		listObjects = kubectlListObjects
+	}
+
+	failed := func(err error) []domain.BundleReport {
+		r := domain.BundleReport{Bundle: pruneBundle, Action: domain.ActionKubectl, Status: domain.StepStatusSucceeded}
+		r.Fail(domain.NewContinueError(dry.Wrapf(err, "pruning plan %q", a.planName())))
+		return []domain.BundleReport{r}
+	}
+	rendered, err := a.svc.renderedObjects(bundles)
+	if err != nil {
+		return failed(err)
+	}
+	live, err := listObjects(ctx, a.ownerSelector())
+	if err != nil {
+		return failed(err)
+	}
+
+	var reports []domain.BundleReport
+	for _, obj := range live {
+		if obj.Kind != "Namespace" && !isRendered(rendered, obj) {
+			reports = append(reports, a.svc.pruneObject(ctx, obj))
+		}
+	}
+	return reports
+}
+
+// ownerSelector returns the label selector matching the objects rendered by this instance of the plan.
+func (a *AppPlan) ownerSelector() string {
+	var namespace string
+	if a.RootPlan != nil {
+		namespace = a.RootPlan.Namespace()
+	}
+	return domain.LabelPlan + "=" + domain.LabelValue(a.planName()) + "," +
+		domain.LabelInstance + "=" + domain.InstanceLabelValue(a.planName(), namespace)
+}
+
+// renderedObjects returns the objects rendered by the remote bundles, keyed by their group, kind,
+// namespace and name.
+func (p *PlanService) renderedObjects(bundles []ManifestBundle) (map[domain.ObjectRef]bool, error) {
+	rendered := make(map[domain.ObjectRef]bool)
+	for _, bundle := range bundles {
+		if bundle.isLocal {
+			continue
+		}
+		objects, err := bundle.objects(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range objects {
+			rendered[objectKey(domain.NewObjectRef(doc))] = true
+		}
+	}
+	return rendered, nil
+}
+
+// isRendered reports whether the live object is one of the rendered objects. Rendered objects without a
+// namespace are applied to the default namespace of the cluster, so they match in any namespace.
+func isRendered(rendered map[domain.ObjectRef]bool, obj domain.ObjectRef) bool {
+	key := objectKey(obj)
+	if rendered[key] {
+		return true
+	}
+	key.Namespace = ""
+	return rendered[key]
+}
+
+// objectKey identifies an object regardless of the version of its API.
+func objectKey(obj domain.ObjectRef) domain.ObjectRef {
+	return domain.ObjectRef{APIVersion: obj.Group(), Kind: obj.Kind, Namespace: obj.Namespace, Name: obj.Name}
+}
+
+// pruneObject deletes the object from the cluster and reports the outcome.
+func (p *PlanService) pruneObject(ctx context.Context, obj domain.ObjectRef) domain.BundleReport {
+	if p.applier != nil {
+		return p.serverSidePrune(ctx, obj)
+	}
+	args := []string{"delete", obj.Resource(), "--ignore-not-found", "--wait"}
+	if obj.Namespace != "" {
+		args = append(args, "-n", obj.Namespace)
+	}
+	m := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{
+			OnFailure: domain.FailureContinue,
+			Action:    domain.ActionKubectl,
+			Args:      args,
+		},
+	}
+	r := domain.BundleReport{
+		Bundle: pruneBundle + obj.String(),
+		Path:   obj.Resource(),
+		Action: m.Spec.Action,
+		Status: domain.StepStatusSucceeded,
+	}
+	runner, err := clientsideapply.NewRunner(m)
+	if err != nil {
+		r.Fail(domain.NewContinueError(err))
+		return r
+	}
+	start := time.Now()
+	res, err := runner.Execute(ctx, m)
+	r.Duration = time.Since(start)
+	if res != nil {
+		r.Output, r.ExitCode = res.Output, res.ExitCode
+	}
+	if err != nil {
+		r.Fail(dry.Wrapf(m.NewError(err), "unable to prune %s", obj))
+	}
+	return r
+}
+
+// kubectlListObjects lists the objects matching the label selector with kubectl, across every namespace
+// and every resource type that can be listed and deleted. Objects created by controllers are left out,
+// see domain.IsControlled.
+func kubectlListObjects(ctx context.Context, selector string) ([]domain.ObjectRef, error) {
+	types, err := kubectlOutput(ctx, "api-resources", "--verbs=list,delete", "-o", "name")
+	if err != nil {
+		return nil, err
+	}
+	out, err := kubectlOutput(ctx, "get", strings.Join(strings.Fields(string(types)), ","),
+		"--all-namespaces", "--ignore-not-found", "-l", selector, "-o", "json")
+	// kubectl still lists the other resource types when one of them cannot be listed, e.g. because its
+	// API service is unavailable. Objects that were not listed are not pruned.
+	var list struct {
+		Items []domain.GenericManifest `json:"items"`
+	}
+	if jsonErr := json.Unmarshal(out, &list); jsonErr != nil {
+		return nil, errors.Join(err, dry.Wrapf(jsonErr, "decoding objects matching %q", selector))
+	}
+	objs := make([]domain.ObjectRef, 0, len(list.Items))
+	for _, item := range list.Items {
+		if !domain.IsControlled(item) {
+			objs = append(objs, domain.NewObjectRef(item))
+		}
+	}
+	return objs, nil
+}
+
+// kubectlOutput runs kubectl with the given arguments and returns its standard output.
+func kubectlOutput(ctx context.Context, args ...string) ([]byte, error) {
+	var stdout bytes.Buffer
+	m := &domain.ClientSideApplyManifest{
+		Spec: domain.ClientSideApplySpec{Action: domain.ActionKubectl, Args: args},
+	}
+	runner, err := clientsideapply.NewRunner(m, clientsideapply.WithOutput(&stdout, io.Discard))
+	if err != nil {
+		return nil, err
+	}
+	_, err = runner.Execute(ctx, m)
+	return stdout.Bytes(), err
+}
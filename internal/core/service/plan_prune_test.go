@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+func TestApplyPlanPrune(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+	is := assert.New(t)
+
+	ctx := t.Context()
+	fh, err := filehandler.New(ctx, t.TempDir())
+	must.NoError(err)
+	root := testPlanner{
+		name:       "root",
+		namespace:  "crib",
+		components: []port.ComponentFunc{configMap("a"), configMap("b")},
+	}
+	svc, err := NewPlanService(ctx, fh)
+	must.NoError(err)
+	var selector string
+	svc.listObjects = func(_ context.Context, s string) ([]domain.ObjectRef, error) {
+		selector = s
+		return []domain.ObjectRef{
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "crib", Name: "a"},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "crib", Name: "removed"},
+			{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "crib", Name: "b"},
+			{APIVersion: "v1", Kind: "Namespace", Name: "crib"},
+		}, nil
+	}
+	plan, err := svc.CreatePlan(ctx, root)
+	must.NoError(err)
+
+	// Every rendered resource is labelled with the plan and its component.
+	bundles := svc.normalizeManifests(svc.findManifests())
+	must.Len(bundles, 2)
+	for _, b := range bundles {
+		raw, err := fh.ReadFile(b.manifests[0].Name)
+		must.NoError(err)
+		for doc, err := range domain.UnmarshalDocument(raw) {
+			must.NoError(err)
+			name := domain.NewObjectRef(doc).Name
+			labels := doc["metadata"].(domain.GenericManifest)["labels"].(domain.GenericManifest)
+			is.Equal(domain.GenericManifest{
+				domain.LabelPlan:      "root",
+				domain.LabelInstance:  "root.crib",
+				domain.LabelComponent: name,
+			}, labels)
+		}
+	}
+
+	// Without the prune option, nothing is listed.
+	state, err := plan.Apply(ctx)
+	must.NoError(err)
+	is.Len(state.Report, 2)
+	is.Empty(selector)
+
+	// Objects of the plan that were not rendered are deleted, except for namespaces.
+	state, err = plan.Apply(ctx, WithPrune())
+	must.NoError(err)
+	is.Equal("crib.sdk/plan=root,crib.sdk/instance=root.crib", selector)
+	must.Len(state.Report, 4)
+	is.Equal("prune/crib/ConfigMap/removed", state.Report[2].Bundle)
+	is.Equal("prune/crib/Deployment/b", state.Report[3].Bundle)
+	is.Equal("kubectl delete Deployment.apps/b --ignore-not-found --wait -n crib\n", string(state.Report[3].Output))
+
+	// A failure to list the objects of the plan is reported without aborting.
+	svc.listObjects = func(context.Context, string) ([]domain.ObjectRef, error) {
+		return nil, errors.New("connection refused")
+	}
+	state, err = plan.Apply(ctx, WithPrune())
+	is.ErrorContains(err, `pruning plan "root": connection refused`)
+	must.Len(state.Report, 3)
+	is.Equal(domain.StepStatusContinued, state.Report[2].Status)
+}
+
+func TestApplyPlanPruneInstances(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+	is := assert.New(t)
+
+	// The objects in the cluster of the plan "root" applied to two namespaces, by their labels.
+	type liveObject struct {
+		ref    domain.ObjectRef
+		labels map[string]string
+	}
+	labels := func(namespace string) map[string]string {
+		return map[string]string{domain.LabelPlan: "root", domain.LabelInstance: domain.InstanceLabelValue("root", namespace)}
+	}
+	cluster := []liveObject{
+		{ref: domain.ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "crib", Name: "a"}, labels: labels("crib")},
+		{ref: domain.ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "crib", Name: "removed"}, labels: labels("crib")},
+		{ref: domain.ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "other", Name: "a"}, labels: labels("other")},
+		{ref: domain.ObjectRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "other", Name: "b"}, labels: labels("other")},
+	}
+	listObjects := func(_ context.Context, selector string) ([]domain.ObjectRef, error) {
+		var objs []domain.ObjectRef
+		for _, obj := range cluster {
+			matches := true
+			for _, requirement := range strings.Split(selector, ",") {
+				key, value, _ := strings.Cut(requirement, "=")
+				matches = matches && obj.labels[key] == value
+			}
+			if matches {
+				objs = append(objs, obj.ref)
+			}
+		}
+		return objs, nil
+	}
+
+	ctx := t.Context()
+	for _, namespace := range []string{"crib", "other"} {
+		fh, err := filehandler.New(ctx, t.TempDir())
+		must.NoError(err)
+		svc, err := NewPlanService(ctx, fh)
+		must.NoError(err)
+		svc.listObjects = listObjects
+		plan, err := svc.CreatePlan(ctx, testPlanner{
+			name:       "root",
+			namespace:  namespace,
+			components: []port.ComponentFunc{configMap("a")},
+		})
+		must.NoError(err)
+
+		// Only the objects of the instance that is applied are pruned.
+		state, err := plan.Apply(ctx, WithPrune())
+		must.NoError(err)
+		var pruned []string
+		for _, r := range state.Report[1:] {
+			pruned = append(pruned, r.Bundle)
+		}
+		is.Equal([]string{"prune/" + namespace + "/ConfigMap/" + map[string]string{"crib": "removed", "other": "b"}[namespace]}, pruned)
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/clientsideapply"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+// chartGraph returns, for each chart of the synthesized app, the positions of the charts it depends on.
+// Charts are positioned in the order of app.Charts(), which is the order cdk8s uses for the ordinal
+// prefix of each chart directory. Next to the dependencies declared with AddDependency between
+// constructs of different charts, cdk8s makes every chart depend on the charts nested in it.
+func chartGraph(app cdk8s.App) [][]int {
+	charts := *app.Charts()
+	positions := make(map[string]int, len(charts))
+	for i, chart := range charts {
+		positions[*chart.Node().Path()] = i
+	}
+
+	graph := make([][]int, len(charts))
+	for i, chart := range charts {
+		for _, dep := range *chart.Node().Dependencies() {
+			if j, ok := positions[*dep.Node().Path()]; ok && j != i {
+				graph[i] = append(graph[i], j)
+			}
+		}
+	}
+	return graph
+}
+
+// bundleGraph returns, for each bundle, the indices of the bundles that must be processed before it.
+//
+// Bundles rendered from the same chart keep their order. The first bundle of a chart depends on the last
+// bundle of each chart that the chart depends on. Charts that rendered no bundles are looked through, so
+// that their own dependencies are used instead. Without a chart graph, e.g. when the plan was not created
+// by CreatePlan, every bundle depends on the previous one. Bundles referencing an output also depend on
+// the bundle declaring it, see outputEdges.
+func (a *AppPlan) bundleGraph(bundles []ManifestBundle) [][]int {
+	graph := make([][]int, len(bundles))
+	if a.charts == nil {
+		for i := 1; i < len(bundles); i++ {
+			graph[i] = []int{i - 1}
+		}
+		return graph
+	}
+
+	// Group the bundles by the chart they were rendered from. Directories without an ordinal
+	// were rendered without chart dependencies, so they only keep their own order.
+	chartBundles := make(map[int][]int)
+	dirBundles := make(map[string][]int)
+	for i, bundle := range bundles {
+		dir := bundle.dir()
+		if prev := dirBundles[dir]; len(prev) > 0 {
+			graph[i] = append(graph[i], prev[len(prev)-1])
+		}
+		dirBundles[dir] = append(dirBundles[dir], i)
+		if chart, _, ok := cutOrdinal(dir); ok && chart < len(a.charts) {
+			chartBundles[chart] = append(chartBundles[chart], i)
+		}
+	}
+
+	// last returns the bundles that complete the given chart.
+	var last func(chart int, visited map[int]bool) []int
+	last = func(chart int, visited map[int]bool) []int {
+		if visited[chart] {
+			return nil
+		}
+		visited[chart] = true
+		if b := chartBundles[chart]; len(b) > 0 {
+			return b[len(b)-1:]
+		}
+		var deps []int
+		for _, dep := range a.charts[chart] {
+			deps = append(deps, last(dep, visited)...)
+		}
+		return deps
+	}
+	for chart, b := range chartBundles {
+		visited := map[int]bool{chart: true}
+		for _, dep := range a.charts[chart] {
+			graph[b[0]] = append(graph[b[0]], last(dep, visited)...)
+		}
+	}
+	if a.svc != nil {
+		a.outputEdges(bundles, graph)
+	}
+	return graph
+}
+
+// applyBundles applies the bundles in the order given by the graph, see bundleGraph, running up to
+// p.concurrency bundles at the same time. Bundles marked in skip are reported as skipped instead of
+// being applied. The checkpoint function, if not nil, is called with each report as soon as the bundle
+// has been processed, one report at a time.
+//
+// It returns the report of each bundle by index. Bundles that were not started because a bundle
+// aborted have a nil report.
+func (p *PlanService) applyBundles(ctx context.Context, bundles []ManifestBundle, graph [][]int, skip []bool, checkpoint func(int, *domain.BundleReport)) []*domain.BundleReport {
+	var (
+		reports    = make([]*domain.BundleReport, len(bundles))
+		waiting    = make([]int, len(bundles))
+		dependents = make([][]int, len(bundles))
+		ready      []int
+		done       = make(chan int, len(bundles))
+		limit      = max(p.concurrency, 1)
+		running    int
+		aborted    bool
+	)
+	for i, deps := range graph {
+		waiting[i] = len(deps)
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], i)
+		}
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	start := func(i int) {
+		if skip[i] {
+			r := bundles[i].report()
+			r.Status = domain.StepStatusSkipped
+			reports[i] = &r
+			done <- i
+			return
+		}
+		var opts []clientsideapply.RunnerOpt
+		switch {
+		case p.quiet:
+			opts = append(opts, clientsideapply.WithOutput(io.Discard, io.Discard))
+		case limit > 1:
+			// Keep the streamed output of bundles that run at the same time apart.
+			opts = append(opts, clientsideapply.WithOutputPrefix(bundles[i].Key()))
+		}
+		if p.progress != nil {
+			p.progress.BundleStarted(bundles[i].report())
+		}
+		go func() {
+			r := bundles[i].applyWithLog(ctx, p, opts...)
+			reports[i] = &r
+			done <- i
+		}()
+	}
+
+	for {
+		// Start ready bundles in index order, which is the order in which they were rendered.
+		for !aborted && running < limit && len(ready) > 0 {
+			start(ready[0])
+			ready = ready[1:]
+			running++
+		}
+		if running == 0 {
+			return reports
+		}
+
+		i := <-done
+		running--
+		if p.progress != nil {
+			p.progress.BundleFinished(*reports[i])
+		}
+		if checkpoint != nil {
+			checkpoint(i, reports[i])
+		}
+		if reports[i].Status == domain.StepStatusAborted {
+			aborted = true
+			continue
+		}
+		for _, dependent := range dependents[i] {
+			if waiting[dependent]--; waiting[dependent] == 0 {
+				pos, _ := slices.BinarySearch(ready, dependent)
+				ready = slices.Insert(ready, pos, dependent)
+			}
+		}
+	}
+}
+
+// dir returns the directory of the manifests in the bundle, relative to the render directory.
+func (b ManifestBundle) dir() string {
+	if len(b.manifests) == 0 {
+		return ""
+	}
+	return filepath.Dir(b.manifests[0].Name)
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal"
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+func TestBundleGraph(t *testing.T) {
+	t.Parallel()
+
+	bundles := []ManifestBundle{
+		{manifests: []Manifest{{Name: "0000-ns/Namespace.a.k8s.yaml"}}},
+		{manifests: []Manifest{{Name: "0001-csa/ClientSideApply.b.k8s.yaml", IsLocal: true}}, isLocal: true},
+		{manifests: []Manifest{{Name: "0001-csa/ClientSideApply.c.k8s.yaml", IsLocal: true}}, isLocal: true},
+		{manifests: []Manifest{{Name: "0003-app/Deployment.d.k8s.yaml"}}},
+		{manifests: []Manifest{{Name: "0004-other/Service.e.k8s.yaml"}}},
+	}
+
+	tests := []struct {
+		name   string
+		charts [][]int
+		want   [][]int
+	}{
+		{
+			name: "sequential without chart graph",
+			want: [][]int{nil, {0}, {1}, {2}, {3}},
+		},
+		{
+			name: "independent charts",
+			// Bundles of the same chart keep their order.
+			charts: [][]int{nil, nil, nil, nil, nil},
+			want:   [][]int{nil, nil, {1}, nil, nil},
+		},
+		{
+			name: "dependencies",
+			// 0001 depends on 0000, 0003 depends on 0001 through 0002, which rendered no bundles.
+			charts: [][]int{nil, {0}, {1}, {2, 0}, nil},
+			want:   [][]int{nil, {0}, {1}, {2, 0}, nil},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := &AppPlan{charts: tc.charts}
+			assert.Equal(t, tc.want, plan.bundleGraph(bundles))
+		})
+	}
+}
+
+func TestBundleGraphOutputs(t *testing.T) {
+	t.Parallel()
+
+	// The ConfigMap, the register step and the Secret reference the outputs of the deploy step, from
+	// independent charts.
+	svc := &PlanService{fh: setup(t, "testdata/plan/manifests/outputs")}
+	bundles := svc.normalizeManifests(svc.findManifests())
+	require.Len(t, bundles, 4)
+	plan := &AppPlan{svc: svc, charts: [][]int{nil, nil, nil, nil}}
+	assert.Equal(t, [][]int{nil, {0}, {0}, {0}}, plan.bundleGraph(bundles))
+}
+
+func TestApplyBundles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		concurrency int
+		graph       [][]int
+		// want lists the bundles that were started, the others were skipped because a bundle aborted.
+		want []bool
+	}{
+		{
+			name:        "sequential",
+			concurrency: 1,
+			graph:       [][]int{nil, {0}, {1}, {2}},
+			want:        []bool{true, true, true, false},
+		},
+		{
+			name:        "independent, one at a time",
+			concurrency: 1,
+			graph:       [][]int{nil, nil, nil, nil},
+			want:        []bool{true, true, true, false},
+		},
+		{
+			name:        "independent, concurrent",
+			concurrency: 4,
+			graph:       [][]int{nil, nil, nil, nil},
+			want:        []bool{true, true, true, true},
+		},
+		{
+			name:        "dependent on the aborted bundle",
+			concurrency: 4,
+			graph:       [][]int{nil, nil, nil, {2}},
+			want:        []bool{true, true, true, false},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &PlanService{fh: setup(t, "testdata/plan/manifests/failures"), concurrency: tc.concurrency}
+			bundles := svc.normalizeManifests(svc.findManifests())
+			require.Len(t, bundles, len(tc.graph))
+
+			reports := svc.applyBundles(t.Context(), bundles, tc.graph, make([]bool, len(bundles)), nil)
+			for i, started := range tc.want {
+				assert.Equal(t, started, reports[i] != nil, bundles[i].Key())
+			}
+			if reports[2] != nil {
+				assert.Equal(t, domain.StepStatusAborted, reports[2].Status)
+			}
+		})
+	}
+}
+
+func TestApplyPlanConcurrency(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	is := assert.New(t)
+
+	plan := &AppPlan{
+		svc:    &PlanService{fh: setup(t, "testdata/plan/manifests/parallel"), concurrency: 3},
+		charts: [][]int{nil, nil, nil},
+	}
+
+	// Each bundle sleeps for a second, they run at the same time.
+	start := time.Now()
+	state, err := plan.Apply(t.Context())
+	require.NoError(t, err)
+	is.Less(time.Since(start), 2*time.Second)
+
+	// Each bundle has its own output, and the report keeps the order of the bundles.
+	require.Len(t, state.Report, 3)
+	for i, name := range []string{"a", "b", "c"} {
+		is.Equal(name+"/00-cmd.yaml", state.Report[i].Bundle)
+		is.Equal(name+": done\n", string(state.Report[i].Output))
+	}
+}
+
+func TestCreatePlanBundleGraph(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+
+	ctx := t.Context()
+	fh, err := filehandler.New(ctx, t.TempDir())
+	must.NoError(err)
+
+	child := testPlanner{name: "child", namespace: "crib", components: []port.ComponentFunc{configMap("c")}}
+	root := testPlanner{
+		name:       "root",
+		namespace:  "crib",
+		components: []port.ComponentFunc{configMap("a"), configMap("b", "a"), configMap("d")},
+		children:   []port.Planner{child},
+	}
+
+	svc, err := NewPlanService(ctx, fh, WithConcurrency(4))
+	must.NoError(err)
+	plan, err := svc.CreatePlan(ctx, root)
+	must.NoError(err)
+
+	// Name each bundle by the ConfigMap it applies.
+	bundles := svc.normalizeManifests(svc.findManifests())
+	must.Len(bundles, 4)
+	names := make([]string, len(bundles))
+	for i, b := range bundles {
+		names[i] = strings.TrimSuffix(strings.TrimPrefix(filepath.Base(b.Key()), "ConfigMap."), ".k8s.yaml")
+	}
+	deps := make(map[string][]string)
+	for i, edges := range plan.bundleGraph(bundles) {
+		for _, j := range edges {
+			deps[names[i]] = append(deps[names[i]], names[j])
+		}
+	}
+
+	// The components of the root plan depend on the components of the child plan, and b on a.
+	is := assert.New(t)
+	is.Empty(deps["c"])
+	is.ElementsMatch([]string{"c"}, deps["a"])
+	is.ElementsMatch([]string{"a", "c"}, deps["b"])
+	is.ElementsMatch([]string{"c"}, deps["d"])
+
+	state, err := plan.Apply(ctx)
+	must.NoError(err)
+	is.Len(state.Report, 4)
+}
+
+// configMap returns a component that renders a chart with a single ConfigMap, depending on the
+// charts of the given sibling components.
+func configMap(name string, deps ...string) port.ComponentFunc {
+	return func(ctx context.Context) (port.Component, error) {
+		parent := internal.ConstructFromContext(ctx)
+		chart := cdk8s.NewChart(parent, dry.ToPtr(name), nil)
+		cdk8s.NewApiObject(chart, dry.ToPtr("cm"), &cdk8s.ApiObjectProps{
+			ApiVersion: dry.ToPtr("v1"),
+			Kind:       dry.ToPtr("ConfigMap"),
+			Metadata:   &cdk8s.ApiObjectMetadata{Name: dry.ToPtr(name)},
+		})
+		for _, dep := range deps {
+			chart.Node().AddDependency(parent.Node().FindChild(dry.ToPtr(dep)))
+		}
+		return chart, nil
+	}
+}
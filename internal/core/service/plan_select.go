@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/plancache"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+// selectCharts returns, for each chart position, whether the bundles of the chart are applied according
+// to the targets and skips of the options. It returns nil when no targets or skips are given.
+//
+// Targets and skips select the components of the plan, see matchComponent. Every chart of a selected
+// component is selected, and with the dependencies option so are the charts it depends on, which
+// include the components of child plans. Skips take precedence over targets.
+func (a *AppPlan) selectCharts(o applyOptions) ([]bool, error) {
+	if len(o.targets) == 0 && len(o.skip) == 0 {
+		return nil, nil
+	}
+	// cdk8s has some level of globally shared state, so we need to acquire a lock.
+	mu.Lock()
+	defer mu.Unlock()
+
+	charts := *a.App.Charts()
+	paths := make([]string, len(charts))
+	for i, chart := range charts {
+		paths[i] = *chart.Node().Path()
+	}
+	// mark sets the charts of the components matching the selector, and reports whether any matched.
+	mark := func(selected []bool, selector string, value bool) bool {
+		var matched bool
+		for _, plan := range planOrder(a.RootPlan) {
+			for _, component := range a.components[plan.Name()] {
+				if !matchComponent(plan, component, selector) {
+					continue
+				}
+				matched = true
+				path := *component.Node().Path()
+				for i, p := range paths {
+					if p == path || isDescendant(p, path) {
+						selected[i] = value
+					}
+				}
+			}
+		}
+		return matched
+	}
+
+	var errs error
+	selected := make([]bool, len(charts))
+	for _, target := range o.targets {
+		if !mark(selected, target, true) {
+			errs = errors.Join(errs, fmt.Errorf("no component matches target %q", target))
+		}
+	}
+	if len(o.targets) == 0 {
+		for i := range selected {
+			selected[i] = true
+		}
+	}
+	if o.dependencies {
+		a.selectDependencies(selected)
+	}
+	for _, skip := range o.skip {
+		if !mark(selected, skip, false) {
+			errs = errors.Join(errs, fmt.Errorf("no component matches skip %q", skip))
+		}
+	}
+	return selected, errs
+}
+
+// selectDependencies selects the charts that the selected charts depend on, directly or not.
+func (a *AppPlan) selectDependencies(selected []bool) {
+	var visit func(chart int)
+	visit = func(chart int) {
+		if chart >= len(a.charts) {
+			return
+		}
+		for _, dep := range a.charts[chart] {
+			if !selected[dep] {
+				selected[dep] = true
+				visit(dep)
+			}
+		}
+	}
+	for chart, ok := range selected {
+		if ok {
+			visit(chart)
+		}
+	}
+}
+
+// matchComponent reports whether the component of the plan matches the selector. A selector names a
+// plan with domain.PlanNodePrefix, e.g. "plan:chainlink-jd", which matches every component of the plan,
+// and otherwise matches components as PlanState.ComponentByName does, e.g. "sdk.HelmChart#anvil".
+func matchComponent(plan port.Planner, component port.Component, selector string) bool {
+	if name, ok := strings.CutPrefix(selector, domain.PlanNodePrefix); ok {
+		return plan.Name() == name
+	}
+	return plancache.MatchComponent(component, selector)
+}
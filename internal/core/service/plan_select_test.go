@@ -0,0 +1,106 @@
+package service
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+func TestApplyPlanTargets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    []ApplyOpt
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "component",
+			opts: []ApplyOpt{WithTargets("b")},
+			want: []string{"b"},
+		},
+		{
+			name: "component with dependencies",
+			// b depends on a, and every component of the root plan on the child plan.
+			opts: []ApplyOpt{WithTargets("b"), WithDependencies()},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "qualified component",
+			opts: []ApplyOpt{WithTargets("d#d")},
+			want: []string{"d"},
+		},
+		{
+			name: "plan",
+			opts: []ApplyOpt{WithTargets("plan:child")},
+			want: []string{"c"},
+		},
+		{
+			name: "skip plan",
+			opts: []ApplyOpt{WithSkip("plan:child")},
+			want: []string{"a", "b", "d"},
+		},
+		{
+			name: "skip takes precedence",
+			opts: []ApplyOpt{WithTargets("plan:root"), WithDependencies(), WithSkip("a", "d-1234abcd")},
+			want: []string{"b", "c"},
+		},
+		{
+			name:    "unknown target",
+			opts:    []ApplyOpt{WithTargets("sdk.HelmChart", "d#missing")},
+			wantErr: `no component matches target "sdk.HelmChart"`,
+		},
+		{
+			name:    "unknown skip",
+			opts:    []ApplyOpt{WithSkip("plan:missing")},
+			wantErr: `no component matches skip "plan:missing"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			must := require.New(t)
+
+			ctx := t.Context()
+			fh, err := filehandler.New(ctx, t.TempDir())
+			must.NoError(err)
+			child := testPlanner{name: "child", namespace: "crib", components: []port.ComponentFunc{configMap("c")}}
+			root := testPlanner{
+				name:       "root",
+				namespace:  "crib",
+				components: []port.ComponentFunc{configMap("a"), configMap("b", "a"), configMap("d")},
+				children:   []port.Planner{child},
+			}
+			svc, err := NewPlanService(ctx, fh)
+			must.NoError(err)
+			plan, err := svc.CreatePlan(ctx, root)
+			must.NoError(err)
+
+			state, err := plan.Apply(ctx, tc.opts...)
+			if tc.wantErr != "" {
+				must.ErrorContains(err, tc.wantErr)
+				return
+			}
+			must.NoError(err)
+
+			// Name each applied bundle by the ConfigMap it applies.
+			var applied []string
+			for _, r := range state.Report {
+				if r.Status == domain.StepStatusSkipped {
+					continue
+				}
+				name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(r.Bundle), "ConfigMap."), ".k8s.yaml")
+				applied = append(applied, name)
+			}
+			assert.ElementsMatch(t, tc.want, applied)
+		})
+	}
+}
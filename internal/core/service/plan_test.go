@@ -77,6 +77,113 @@ func TestCreatePlan(t *testing.T) {
 	is.Len(appPlan.RootPlan.ChildPlans()[0].Components(), 1)
 }
 
+func TestCreatePlanNested(t *testing.T) {
+	t.Parallel()
+	must := require.New(t)
+	ctx := t.Context()
+	fh, err := filehandler.New(t.Context(), t.TempDir())
+	must.NoError(err)
+
+	var (
+		rendered []string
+
+		p1, p2, p3, p4, p5 func() *crib.Plan
+		testComponent      = func(name string) crib.ComponentFunc {
+			return func(ctx context.Context) (crib.Component, error) {
+				rendered = append(rendered, name)
+				return nil, nil
+			}
+		}
+	)
+
+	// p1 depends on p2 and p3, which both depend on p4. p4 depends on p5.
+	{
+		p1 = func() *crib.Plan {
+			return crib.NewPlan("p1", crib.ComponentSet(testComponent("p1")), crib.AddPlan(p2), crib.AddPlan(p3))
+		}
+		p2 = func() *crib.Plan {
+			return crib.NewPlan("p2", crib.ComponentSet(testComponent("p2")), crib.AddPlan(p4))
+		}
+		p3 = func() *crib.Plan {
+			return crib.NewPlan("p3", crib.ComponentSet(testComponent("p3")), crib.AddPlan(p4))
+		}
+		p4 = func() *crib.Plan {
+			return crib.NewPlan("p4", crib.ComponentSet(testComponent("p4")), crib.AddPlan(p5))
+		}
+		p5 = func() *crib.Plan {
+			return crib.NewPlan("p5", crib.ComponentSet(testComponent("p5")))
+		}
+	}
+
+	ps, err := service.NewPlanService(ctx, fh)
+	must.NoError(err)
+	_, err = ps.CreatePlan(ctx, p1().Build())
+	must.NoError(err)
+
+	// Every plan is rendered exactly once, after the plans it depends on.
+	assert.Equal(t, []string{"p5", "p4", "p2", "p3", "p1"}, rendered)
+}
+
+func TestCreatePlanParams(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nodes = crib.Param("nodes", 4, crib.ParamValidate("min=1"), crib.ParamUsage("Number of nodes"))
+		tag   = crib.Param("tag", "latest")
+
+		newPlan = func(got *int) *crib.Plan {
+			child := func() *crib.Plan {
+				return crib.NewPlan("child", crib.Params(tag))
+			}
+			return crib.NewPlan("root",
+				crib.Params(nodes),
+				crib.AddPlan(child),
+				crib.ComponentSet(func(ctx context.Context) (crib.Component, error) {
+					*got = nodes.Value(ctx)
+					return nil, nil
+				}),
+			)
+		}
+	)
+
+	tests := []struct {
+		desc    string
+		params  map[string]any
+		want    int
+		wantErr string
+	}{
+		{desc: "default", want: 4},
+		{desc: "set", params: map[string]any{"nodes": "6", "tag": "v1"}, want: 6},
+		{desc: "invalid", params: map[string]any{"nodes": "0"}, wantErr: `parameter "nodes"`},
+		{desc: "unknown", params: map[string]any{"size": "6"}, wantErr: `plan "root" has no parameter "size"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			must := require.New(t)
+			ctx := t.Context()
+			fh, err := filehandler.New(ctx, t.TempDir())
+			must.NoError(err)
+
+			ps, err := service.NewPlanService(ctx, fh, service.WithParams(tc.params))
+			must.NoError(err)
+			var got int
+			plan, err := ps.CreatePlan(ctx, newPlan(&got).Build())
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			must.NoError(err)
+			assert.Equal(t, tc.want, got)
+
+			preview := plan.Preview(ctx)
+			assert.Contains(t, preview, "- nodes (int): 4")
+			assert.Contains(t, preview, "- tag (string): latest")
+			assert.Contains(t, preview, "Number of nodes")
+		})
+	}
+}
+
 func TestE2ECreatePlan(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
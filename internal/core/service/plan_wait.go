@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/crib-sdk/internal"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+)
+
+type waitDefaultsKey struct{}
+
+// contextWithWaitDefaults returns a context carrying the wait defaults of the applied plan.
+func contextWithWaitDefaults(ctx context.Context, d domain.WaitDefaults) context.Context {
+	return context.WithValue(ctx, waitDefaultsKey{}, d)
+}
+
+// waitDefaultsFromContext returns the wait defaults carried by the context, falling back to the
+// defaults of cribctl.
+func waitDefaultsFromContext(ctx context.Context) domain.WaitDefaults {
+	d, _ := ctx.Value(waitDefaultsKey{}).(domain.WaitDefaults)
+	return d.Or(domain.WaitDefaults{Timeout: domain.DefaultWaitTimeout, Interval: domain.DefaultWaitInterval})
+}
+
+// waitDefaults returns the wait defaults of the plan: those of the PlanService take precedence over
+// the ones declared by the root plan.
+func (a *AppPlan) waitDefaults() domain.WaitDefaults {
+	d := a.svc.wait
+	if plan, ok := a.RootPlan.(waitDefaulter); ok {
+		d = d.Or(plan.WaitDefaults())
+	}
+	return d
+}
+
+// isWait reports whether the bundle is a local Wait bundle.
+func (b ManifestBundle) isWait(p *PlanService) bool {
+	if !b.isLocal || len(b.manifests) != 1 {
+		return false
+	}
+	m, err := p.readManifest(b.manifests[0].Name)
+	return err == nil && m.Kind == domain.Wait
+}
+
+// wait polls the condition of the Wait bundle until it is met. A condition that is not met within
+// its timeout, or that can no longer be met, aborts the plan.
+func (b ManifestBundle) wait(ctx context.Context, p *PlanService) domain.BundleReport {
+	r := b.report()
+	r.Action = domain.ActionWait
+
+	raw, err := p.fh.ReadFile(b.manifests[0].Name)
+	if err != nil {
+		r.Fail(domain.NewAbortError(fmt.Errorf("failed to read manifest %s: %w", b.manifests[0].Name, err)))
+		return r
+	}
+	m, err := domain.UnmarshalManifest[domain.WaitManifest](raw)
+	if err == nil {
+		err = internal.ValidatorFromContext(ctx).Struct(&m.Spec)
+	}
+	if err != nil {
+		r.Fail(domain.NewAbortError(fmt.Errorf("invalid Wait manifest %s: %w", b.manifests[0].Name, err)))
+		return r
+	}
+	spec := m.Spec.WithDefaults(waitDefaultsFromContext(ctx))
+
+	start := time.Now()
+	err = p.waitFor(ctx, &spec)
+	r.Duration = time.Since(start)
+	if err != nil {
+		r.Output = []byte(err.Error() + "\n")
+		r.Fail(domain.NewAbortError(dry.Wrapf(err, "unable to wait for bundle %s", b.String())))
+		return r
+	}
+	r.Output = []byte(spec.String() + " met\n")
+	return r
+}
+
+// waitFor checks the condition every interval until it is met, the timeout expires or the condition
+// can no longer be met. Errors reading the objects are retried, as the API server may not be ready yet.
+func (p *PlanService) waitFor(ctx context.Context, spec *domain.WaitSpec) error {
+	ctx, cancel := context.WithTimeout(ctx, spec.Timeout)
+	defer cancel()
+	ticker := time.NewTicker(spec.Interval)
+	defer ticker.Stop()
+
+	var status string
+	for {
+		ready, current, err := p.checkWait(ctx, spec)
+		if err != nil {
+			return fmt.Errorf("%s can no longer be met: %w", spec, err)
+		}
+		if ready {
+			return nil
+		}
+		// A check interrupted by the timeout says nothing about the condition.
+		if ctx.Err() == nil || status == "" {
+			status = current
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s waiting for %s: %s", spec.Timeout, spec, status)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkWait checks the condition once, see domain.WaitSpec.Evaluate.
+func (p *PlanService) checkWait(ctx context.Context, spec *domain.WaitSpec) (bool, string, error) {
+	if spec.Condition == domain.WaitHTTP {
+		return httpReady(ctx, spec.URL)
+	}
+	read := kubectlRead
+	if p.reader != nil {
+		read = p.reader.Read
+	}
+	objects, err := read(ctx, spec)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+	return spec.Evaluate(objects)
+}
+
+// httpReady reports whether the URL responds with HTTP 200.
+func httpReady(ctx context.Context, url string) (bool, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return false, "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, url + " responded with " + resp.Status, nil
+	}
+	return true, "", nil
+}
+
+// kubectlRead reads the objects that the condition applies to with kubectl get. Objects that do not
+// exist are not returned.
+func kubectlRead(ctx context.Context, spec *domain.WaitSpec) ([]domain.GenericManifest, error) {
+	apiVersion, kind := spec.Object()
+	ref := domain.ObjectRef{APIVersion: apiVersion, Kind: kind, Name: spec.Name}
+	args := []string{"get", ref.Resource(), "--ignore-not-found", "-o", "json"}
+	if spec.Name == "" {
+		args = []string{"get", strings.TrimSuffix(ref.Resource(), "/"), "-l", spec.Selector, "-o", "json"}
+	}
+	if spec.Namespace != "" {
+		args = append(args, "-n", spec.Namespace)
+	}
+	out, err := kubectlOutput(ctx, args...)
+	if err != nil || len(bytes.TrimSpace(out)) == 0 {
+		return nil, err
+	}
+
+	// A single object is returned when reading by name, and a list otherwise.
+	if spec.Name != "" {
+		var obj domain.GenericManifest
+		if err := json.Unmarshal(out, &obj); err != nil {
+			return nil, dry.Wrapf(err, "decoding %s", ref.Resource())
+		}
+		return []domain.GenericManifest{obj}, nil
+	}
+	var list struct {
+		Items []domain.GenericManifest `json:"items"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, dry.Wrapf(err, "decoding %s", spec)
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cdk8s-team/cdk8s-core-go/cdk8s/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/crib-sdk/internal"
+	"github.com/smartcontractkit/crib-sdk/internal/adapter/filehandler"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+	"github.com/smartcontractkit/crib-sdk/internal/core/port"
+)
+
+// fakeReader returns the live objects of the kind of the condition.
+type fakeReader struct {
+	objects map[string][]domain.GenericManifest
+}
+
+func (f *fakeReader) Read(_ context.Context, spec *domain.WaitSpec) ([]domain.GenericManifest, error) {
+	_, kind := spec.Object()
+	return f.objects[kind], nil
+}
+
+// waitPlanner returns a plan with a single Wait for the spec, which declares the given wait defaults.
+type waitPlanner struct {
+	testPlanner
+	defaults domain.WaitDefaults
+}
+
+func (p waitPlanner) WaitDefaults() domain.WaitDefaults { return p.defaults }
+
+func newWaitPlanner(spec map[string]any, defaults domain.WaitDefaults) waitPlanner {
+	wait := func(ctx context.Context) (port.Component, error) {
+		chart := cdk8s.NewChart(internal.ConstructFromContext(ctx), dry.ToPtr("wait"), nil)
+		obj := cdk8s.NewApiObject(chart, dry.ToPtr("wait"), &cdk8s.ApiObjectProps{
+			ApiVersion: dry.ToPtr(domain.CribAPIVersion),
+			Kind:       dry.ToPtr(domain.Wait),
+		})
+		obj.AddJsonPatch(cdk8s.JsonPatch_Add(dry.ToPtr("/spec"), spec))
+		return chart, nil
+	}
+	return waitPlanner{
+		testPlanner: testPlanner{name: "root", namespace: "crib", components: []port.ComponentFunc{wait}},
+		defaults:    defaults,
+	}
+}
+
+func pod(name, ready string) domain.GenericManifest {
+	doc := manifest("v1", "Pod", "crib", name)
+	doc["status"] = map[string]any{"conditions": []any{map[string]any{"type": "Ready", "status": ready}}}
+	return doc
+}
+
+func job(name, conditionType string) domain.GenericManifest {
+	doc := manifest("batch/v1", "Job", "crib", name)
+	doc["status"] = map[string]any{"conditions": []any{map[string]any{"type": conditionType, "status": "True"}}}
+	return doc
+}
+
+func manifest(apiVersion, kind, namespace, name string) domain.GenericManifest {
+	return domain.GenericManifest{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+	}
+}
+
+func TestApplyPlanWait(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	reader := &fakeReader{objects: map[string][]domain.GenericManifest{
+		"Pod": {pod("api-0", "True"), pod("api-1", "True")},
+		"Job": {job("migrate", "Failed")},
+	}}
+	tests := []struct {
+		name    string
+		spec    map[string]any
+		wantOut string
+		wantErr string
+	}{
+		{
+			name:    "pods ready",
+			spec:    map[string]any{"condition": "pod-ready", "namespace": "crib", "selector": "app=api"},
+			wantOut: "pod-ready of Pods app=api in crib met\n",
+		},
+		{
+			name:    "http ready",
+			spec:    map[string]any{"condition": "http", "url": server.URL + "/healthz"},
+			wantOut: "http of " + server.URL + "/healthz met\n",
+		},
+		{
+			name:    "timed out",
+			spec:    map[string]any{"condition": "http", "url": server.URL + "/ready", "timeout": "50ms", "interval": "10ms"},
+			wantErr: "timed out after 50ms waiting for http of " + server.URL + "/ready: " + server.URL + "/ready responded with 503 Service Unavailable",
+		},
+		{
+			name:    "no objects",
+			spec:    map[string]any{"condition": "endpoints", "name": "api", "timeout": "50ms", "interval": "10ms"},
+			wantErr: "no Endpoints/api found",
+		},
+		{
+			name:    "can no longer be met",
+			spec:    map[string]any{"condition": "job-complete", "name": "migrate"},
+			wantErr: "job-complete of Job/migrate can no longer be met: Job/migrate: job failed",
+		},
+		{
+			name:    "invalid",
+			spec:    map[string]any{"condition": "rollout", "name": "api"},
+			wantErr: "invalid Wait manifest",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			internal.JSIIKernelMutex.Lock()
+			defer internal.JSIIKernelMutex.Unlock()
+			must := require.New(t)
+			is := assert.New(t)
+
+			ctx := t.Context()
+			fh, err := filehandler.New(ctx, t.TempDir())
+			must.NoError(err)
+			svc, err := NewPlanService(ctx, fh, WithKubernetesReader(reader))
+			must.NoError(err)
+			plan, err := svc.CreatePlan(ctx, newWaitPlanner(tc.spec, domain.WaitDefaults{}))
+			must.NoError(err)
+
+			state, err := plan.Apply(ctx)
+			must.Len(state.Report, 1)
+			is.Equal(domain.ActionWait, state.Report[0].Action)
+			if tc.wantErr != "" {
+				is.ErrorContains(err, tc.wantErr)
+				is.Equal(domain.StepStatusAborted, state.Report[0].Status)
+				return
+			}
+			must.NoError(err)
+			is.Equal(tc.wantOut, string(state.Report[0].Output))
+
+			// Waiting has nothing to destroy.
+			_, err = plan.Destroy(ctx)
+			is.NoError(err)
+		})
+	}
+}
+
+func TestAppPlanWaitDefaults(t *testing.T) {
+	t.Parallel()
+
+	planner := newWaitPlanner(nil, domain.WaitDefaults{Timeout: time.Minute, Interval: time.Second})
+	tests := []struct {
+		name    string
+		svc     domain.WaitDefaults
+		planner port.Planner
+		want    domain.WaitDefaults
+	}{
+		{
+			name:    "plan defaults",
+			planner: planner,
+			want:    domain.WaitDefaults{Timeout: time.Minute, Interval: time.Second},
+		},
+		{
+			name:    "service overrides the plan",
+			svc:     domain.WaitDefaults{Timeout: time.Hour},
+			planner: planner,
+			want:    domain.WaitDefaults{Timeout: time.Hour, Interval: time.Second},
+		},
+		{
+			name:    "cribctl defaults",
+			planner: planner.testPlanner,
+			want:    domain.WaitDefaults{Timeout: domain.DefaultWaitTimeout, Interval: domain.DefaultWaitInterval},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &AppPlan{svc: &PlanService{wait: tc.svc}, RootPlan: tc.planner}
+			got := waitDefaultsFromContext(contextWithWaitDefaults(t.Context(), a.waitDefaults()))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -1,8 +1,49 @@
 package internal
 
-import "sync"
+import (
+	"context"
+	"sync"
+)
 
 // JSIIKernelMutex is global mutex to synchronize all parallel invocations of jsii kernel
 // This is required to be used in Tests when test code contains any invocations to jsii kernel and uses t.Parallel()
 // It can be also used in the productions code if it contains any parallel invocations.
 var JSIIKernelMutex sync.Mutex
+
+type jsiiLockKey struct{}
+
+// ContextWithJSIILock returns a context carrying a lock that serializes the JSII calls of the goroutines sharing
+// the context, e.g. the components of a Composite that are applied concurrently. The lock is held by the caller
+// until the returned func is called. If the context already carries a lock, it is held by the caller already, so
+// the context is returned as is along with a no-op func.
+func ContextWithJSIILock(ctx context.Context) (context.Context, func()) {
+	if JSIILockFromContext(ctx) != nil {
+		return ctx, func() {}
+	}
+	mu := &sync.Mutex{}
+	mu.Lock()
+	return context.WithValue(ctx, jsiiLockKey{}, mu), mu.Unlock
+}
+
+// JSIILockFromContext returns the lock serializing JSII calls carried by the context, or nil if there is none.
+func JSIILockFromContext(ctx context.Context) *sync.Mutex {
+	if ctx == nil {
+		return nil
+	}
+	mu, _ := ctx.Value(jsiiLockKey{}).(*sync.Mutex)
+	return mu
+}
+
+// WithoutJSIILock runs fn with the JSII lock of the context released, so that the goroutines sharing the context
+// can make JSII calls meanwhile, e.g. while a binary is run. The caller must hold the lock, which is acquired
+// again before returning. fn must not make JSII calls. Without a lock in the context, fn is simply run.
+func WithoutJSIILock(ctx context.Context, fn func()) {
+	mu := JSIILockFromContext(ctx)
+	if mu == nil {
+		fn()
+		return
+	}
+	mu.Unlock()
+	defer mu.Lock()
+	fn()
+}
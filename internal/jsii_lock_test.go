@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithJSIILock(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, JSIILockFromContext(t.Context()))
+	WithoutJSIILock(t.Context(), func() {}) // Runs without a lock to release.
+
+	ctx, unlock := ContextWithJSIILock(t.Context())
+	mu := JSIILockFromContext(ctx)
+	assert.NotNil(t, mu)
+	assert.False(t, mu.TryLock(), "the lock is held by the caller")
+
+	nested, unlockNested := ContextWithJSIILock(ctx)
+	assert.Same(t, mu, JSIILockFromContext(nested), "the lock of the context is reused")
+	unlockNested()
+	assert.False(t, mu.TryLock(), "only the caller that created the lock releases it")
+
+	WithoutJSIILock(ctx, func() {
+		assert.True(t, mu.TryLock(), "the lock is released while fn runs")
+		mu.Unlock()
+	})
+	assert.False(t, mu.TryLock(), "the lock is acquired again")
+
+	unlock()
+	assert.True(t, mu.TryLock())
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/creasty/defaults"
@@ -15,6 +16,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
 )
 
 var instance = sync.OnceValues(func() (*Validator, error) {
@@ -27,6 +29,8 @@ var instance = sync.OnceValues(func() (*Validator, error) {
 		v.RegisterValidation("exclusive_of", exclusiveOf, true),
 		v.RegisterValidation("expr", validateExprLang),
 		v.RegisterValidation("image_uri", validateImageURI),
+		v.RegisterValidation("duration", validateDuration),
+		v.RegisterValidation("csa_action", validateAction),
 	)
 	return dry.Wrapf2(&Validator{Validate: v}, errs, "failed to initialize validator")
 })
@@ -51,6 +55,9 @@ type Validator struct {
 // It will be called before validation. This is useful for setting defaults that are not
 // handled by the `defaults` package, such as very complex values.
 //
+// The values of fields tagged as sensitive, e.g. `sensitive:"true"`, are registered as secrets, so that
+// they are masked in the output of the plan, see domain.RegisterSensitive.
+//
 // It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
 // ou will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
 func (v *Validator) Struct(i any) error {
@@ -59,6 +66,7 @@ func (v *Validator) Struct(i any) error {
 		if err := defaults.Set(i); err != nil {
 			return fmt.Errorf("setting defaults: %w", err)
 		}
+		domain.RegisterSensitive(i)
 	}
 
 	return v.Validate.Struct(i)
@@ -80,6 +88,30 @@ func validateChainSelector(f validator.FieldLevel) bool {
 	return true // Implement your validation logic here
 }
 
+// validateDuration validates that a string field is a non-negative Go duration, e.g. "90s" or "10m".
+func validateDuration(f validator.FieldLevel) bool {
+	if f.Field().Kind() != reflect.String {
+		return false
+	}
+	d, err := time.ParseDuration(f.Field().String())
+	return err == nil && d >= 0
+}
+
+// validateAction validates that a string field names a registered ClientSideApply action. If the struct
+// holding the field has Args, they must be accepted by the action.
+func validateAction(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	var args []string
+	if parent := reflect.Indirect(fl.Parent()); parent.Kind() == reflect.Struct {
+		if f := parent.FieldByName("Args"); f.IsValid() && f.Type() == reflect.TypeFor[[]string]() {
+			args = f.Interface().([]string)
+		}
+	}
+	return domain.ValidateAction(fl.Field().String(), args) == nil
+}
+
 // validateImageURI validates that a field contains a valid Kubernetes image URI.
 // A valid image URI follows the format: [registry[:port]/]namespace/name[:tag|@digest]
 // Examples:
@@ -74,6 +74,26 @@ func TestValidator(t *testing.T) {
 			errAssertion: assert.Error,
 		},
 
+		// duration validation tests
+		{
+			desc:         "Valid Duration",
+			input:        "1m30s",
+			validation:   "duration",
+			errAssertion: assert.NoError,
+		},
+		{
+			desc:         "Negative Duration",
+			input:        "-5s",
+			validation:   "duration",
+			errAssertion: assert.Error,
+		},
+		{
+			desc:         "Invalid Duration",
+			input:        "10",
+			validation:   "duration",
+			errAssertion: assert.Error,
+		},
+
 		// yaml validation tests
 		{
 			desc:         "Valid YAML",
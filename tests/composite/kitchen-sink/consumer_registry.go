@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/crib-sdk/crib"
+	"github.com/smartcontractkit/crib-sdk/internal/core/common/dry"
+	"github.com/smartcontractkit/crib-sdk/internal/core/domain"
+
+	configmap "github.com/smartcontractkit/crib-sdk/crib/scalar/k8s/configmap/v1"
+)
+
+// This file demonstrates consuming the result of one specific producer, when several producers
+// share the same result type. Both docker registries produce a *DockerResults, so the producers are
+// registered with a qualifier and the consumer asks for one of them by name.
+
+type (
+	// RegistryMapper is a consumer of the registry that images are pushed to.
+	RegistryMapper struct{}
+
+	// RegistryParams are the parameters of RegistryMapper. Embedding crib.In has each field provided
+	// separately, and the name tag selects the producer registered with that qualifier.
+	RegistryParams struct {
+		crib.In
+		Ctx      context.Context
+		Registry *DockerResults `name:"registry-5001"`
+	}
+)
+
+// NewRegistryMapper creates a new RegistryMapper component.
+func NewRegistryMapper() *RegistryMapper {
+	return &RegistryMapper{}
+}
+
+// Apply creates a config map with the address of the registry that images are pushed to.
+func (r *RegistryMapper) Apply(params RegistryParams) (crib.Component, error) {
+	component := configmap.Component(&configmap.Props{
+		Data: dry.PtrMapping(map[string]string{
+			"registry": params.Registry.Host() + ":" + params.Registry.Port(),
+		}),
+		Namespace:   domain.DefaultNamespace,
+		AppName:     "kitchen-sink",
+		AppInstance: "registry-mapper",
+		Name:        "registry-mapper",
+	})
+	return component(params.Ctx)
+}
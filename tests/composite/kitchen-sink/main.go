@@ -20,12 +20,13 @@ import (
 // It includes a variety of components that demonstrate the capabilities of the Composite API.
 var composite = crib.NewComposite(
 	// Producers
-	NewDockerRegistry("5000"),
-	NewDockerRegistry("5001"),
+	crib.Named("registry-5000", NewDockerRegistry("5000")),
+	crib.Named("registry-5001", NewDockerRegistry("5001")),
 	NewKindCluster,
 
 	// Consumers
 	NewConfigMapper,
+	NewRegistryMapper,
 )
 
 type (
@@ -53,9 +54,15 @@ func main() {
 	)
 
 	// Apply the plan.
-	_, err := plan.Apply(ctx)
+	state, err := plan.Apply(ctx)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error applying plan: %v\n", err)
+		return
+	}
+
+	// Read the results produced within the composite back out of the plan state.
+	for _, host := range crib.CompositeOutputs[HostnamePrinter](state) {
+		fmt.Printf("%s:%s\n", host.Host(), host.Port())
 	}
 }
 